@@ -0,0 +1,55 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/extendeddaemonset/api/client/clientset/versioned/fake"
+	"github.com/DataDog/extendeddaemonset/api/client/informers/externalversions"
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1/test"
+)
+
+func TestFakeClientsetWithInformers(t *testing.T) {
+	eds := test.NewExtendedDaemonSet("bar", "foo", nil)
+	clientset := fake.NewSimpleClientset(eds)
+
+	got, err := clientset.DatadoghqV1alpha1().ExtendedDaemonSets("bar").Get(t.Context(), "foo", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "foo", got.Name)
+
+	factory := externalversions.NewSharedInformerFactory(clientset, time.Minute)
+	edsInformer := factory.Datadoghq().V1alpha1().ExtendedDaemonSets()
+	ersInformer := factory.Datadoghq().V1alpha1().ExtendedDaemonSetReplicaSets()
+	// Informers must be requested before starting the factory.
+	edsSynced := edsInformer.Informer().HasSynced
+	ersSynced := ersInformer.Informer().HasSynced
+	factory.Start(t.Context().Done())
+	require.True(t, cache.WaitForCacheSync(t.Context().Done(), edsSynced, ersSynced))
+
+	_, err = clientset.DatadoghqV1alpha1().ExtendedDaemonSetReplicaSets("bar").Create(t.Context(), test.NewExtendedDaemonSetReplicaSet("bar", "foo-1", nil), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	lister := edsInformer.Lister()
+	listed, err := lister.ExtendedDaemonSets("bar").Get("foo")
+	require.NoError(t, err)
+	assert.Equal(t, eds.Name, listed.Name)
+
+	assert.Eventually(t, func() bool {
+		ers, getErr := ersInformer.Lister().ExtendedDaemonSetReplicaSets("bar").Get("foo-1")
+
+		return getErr == nil && ers.Name == "foo-1"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "extendeddaemonsets.datadoghq.com", v1alpha1.Resource("extendeddaemonsets").String())
+}
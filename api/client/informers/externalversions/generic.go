@@ -0,0 +1,55 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	"fmt"
+
+	v1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=datadoghq.com, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("extendeddaemonsets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Datadoghq().V1alpha1().ExtendedDaemonSets().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("extendeddaemonsetreplicasets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Datadoghq().V1alpha1().ExtendedDaemonSetReplicaSets().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("extendeddaemonsetsettings"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Datadoghq().V1alpha1().ExtendedDaemonsetSettings().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
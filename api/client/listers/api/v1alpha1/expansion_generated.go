@@ -0,0 +1,32 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ExtendedDaemonSetListerExpansion allows custom methods to be added to
+// ExtendedDaemonSetLister.
+type ExtendedDaemonSetListerExpansion interface{}
+
+// ExtendedDaemonSetNamespaceListerExpansion allows custom methods to be added to
+// ExtendedDaemonSetNamespaceLister.
+type ExtendedDaemonSetNamespaceListerExpansion interface{}
+
+// ExtendedDaemonSetReplicaSetListerExpansion allows custom methods to be added to
+// ExtendedDaemonSetReplicaSetLister.
+type ExtendedDaemonSetReplicaSetListerExpansion interface{}
+
+// ExtendedDaemonSetReplicaSetNamespaceListerExpansion allows custom methods to be added to
+// ExtendedDaemonSetReplicaSetNamespaceLister.
+type ExtendedDaemonSetReplicaSetNamespaceListerExpansion interface{}
+
+// ExtendedDaemonsetSettingListerExpansion allows custom methods to be added to
+// ExtendedDaemonsetSettingLister.
+type ExtendedDaemonsetSettingListerExpansion interface{}
+
+// ExtendedDaemonsetSettingNamespaceListerExpansion allows custom methods to be added to
+// ExtendedDaemonsetSettingNamespaceLister.
+type ExtendedDaemonsetSettingNamespaceListerExpansion interface{}
@@ -0,0 +1,59 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ExtendedDaemonSetReplicaSetLister helps list ExtendedDaemonSetReplicaSets.
+// All objects returned here must be treated as read-only.
+type ExtendedDaemonSetReplicaSetLister interface {
+	// List lists all ExtendedDaemonSetReplicaSets in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ExtendedDaemonSetReplicaSet, err error)
+	// ExtendedDaemonSetReplicaSets returns an object that can list and get ExtendedDaemonSetReplicaSets.
+	ExtendedDaemonSetReplicaSets(namespace string) ExtendedDaemonSetReplicaSetNamespaceLister
+	ExtendedDaemonSetReplicaSetListerExpansion
+}
+
+// extendedDaemonSetReplicaSetLister implements the ExtendedDaemonSetReplicaSetLister interface.
+type extendedDaemonSetReplicaSetLister struct {
+	listers.ResourceIndexer[*v1alpha1.ExtendedDaemonSetReplicaSet]
+}
+
+// NewExtendedDaemonSetReplicaSetLister returns a new ExtendedDaemonSetReplicaSetLister.
+func NewExtendedDaemonSetReplicaSetLister(indexer cache.Indexer) ExtendedDaemonSetReplicaSetLister {
+	return &extendedDaemonSetReplicaSetLister{listers.New[*v1alpha1.ExtendedDaemonSetReplicaSet](indexer, v1alpha1.Resource("extendeddaemonsetreplicaset"))}
+}
+
+// ExtendedDaemonSetReplicaSets returns an object that can list and get ExtendedDaemonSetReplicaSets.
+func (s *extendedDaemonSetReplicaSetLister) ExtendedDaemonSetReplicaSets(namespace string) ExtendedDaemonSetReplicaSetNamespaceLister {
+	return extendedDaemonSetReplicaSetNamespaceLister{listers.NewNamespaced[*v1alpha1.ExtendedDaemonSetReplicaSet](s.ResourceIndexer, namespace)}
+}
+
+// ExtendedDaemonSetReplicaSetNamespaceLister helps list and get ExtendedDaemonSetReplicaSets.
+// All objects returned here must be treated as read-only.
+type ExtendedDaemonSetReplicaSetNamespaceLister interface {
+	// List lists all ExtendedDaemonSetReplicaSets in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ExtendedDaemonSetReplicaSet, err error)
+	// Get retrieves the ExtendedDaemonSetReplicaSet from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ExtendedDaemonSetReplicaSet, error)
+	ExtendedDaemonSetReplicaSetNamespaceListerExpansion
+}
+
+// extendedDaemonSetReplicaSetNamespaceLister implements the ExtendedDaemonSetReplicaSetNamespaceLister
+// interface.
+type extendedDaemonSetReplicaSetNamespaceLister struct {
+	listers.ResourceIndexer[*v1alpha1.ExtendedDaemonSetReplicaSet]
+}
@@ -0,0 +1,59 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ExtendedDaemonsetSettingLister helps list ExtendedDaemonsetSettings.
+// All objects returned here must be treated as read-only.
+type ExtendedDaemonsetSettingLister interface {
+	// List lists all ExtendedDaemonsetSettings in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ExtendedDaemonsetSetting, err error)
+	// ExtendedDaemonsetSettings returns an object that can list and get ExtendedDaemonsetSettings.
+	ExtendedDaemonsetSettings(namespace string) ExtendedDaemonsetSettingNamespaceLister
+	ExtendedDaemonsetSettingListerExpansion
+}
+
+// extendedDaemonsetSettingLister implements the ExtendedDaemonsetSettingLister interface.
+type extendedDaemonsetSettingLister struct {
+	listers.ResourceIndexer[*v1alpha1.ExtendedDaemonsetSetting]
+}
+
+// NewExtendedDaemonsetSettingLister returns a new ExtendedDaemonsetSettingLister.
+func NewExtendedDaemonsetSettingLister(indexer cache.Indexer) ExtendedDaemonsetSettingLister {
+	return &extendedDaemonsetSettingLister{listers.New[*v1alpha1.ExtendedDaemonsetSetting](indexer, v1alpha1.Resource("extendeddaemonsetsetting"))}
+}
+
+// ExtendedDaemonsetSettings returns an object that can list and get ExtendedDaemonsetSettings.
+func (s *extendedDaemonsetSettingLister) ExtendedDaemonsetSettings(namespace string) ExtendedDaemonsetSettingNamespaceLister {
+	return extendedDaemonsetSettingNamespaceLister{listers.NewNamespaced[*v1alpha1.ExtendedDaemonsetSetting](s.ResourceIndexer, namespace)}
+}
+
+// ExtendedDaemonsetSettingNamespaceLister helps list and get ExtendedDaemonsetSettings.
+// All objects returned here must be treated as read-only.
+type ExtendedDaemonsetSettingNamespaceLister interface {
+	// List lists all ExtendedDaemonsetSettings in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ExtendedDaemonsetSetting, err error)
+	// Get retrieves the ExtendedDaemonsetSetting from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ExtendedDaemonsetSetting, error)
+	ExtendedDaemonsetSettingNamespaceListerExpansion
+}
+
+// extendedDaemonsetSettingNamespaceLister implements the ExtendedDaemonsetSettingNamespaceLister
+// interface.
+type extendedDaemonsetSettingNamespaceLister struct {
+	listers.ResourceIndexer[*v1alpha1.ExtendedDaemonsetSetting]
+}
@@ -18,6 +18,16 @@ const (
 	ExtendedDaemonSetReplicaSetCanaryLabelKey = "extendeddaemonsetreplicaset.datadoghq.com/canary"
 	// ExtendedDaemonSetReplicaSetCanaryLabelValue label value used to identify canary Pods.
 	ExtendedDaemonSetReplicaSetCanaryLabelValue = "true"
+	// ExtendedDaemonSetReplicaSetPrePullLabelKey label key used to link an image pre-pull Pod to a ExtendedDaemonSetReplicaSet.
+	ExtendedDaemonSetReplicaSetPrePullLabelKey = "extendeddaemonsetreplicaset.datadoghq.com/pre-pull"
+	// ExtendedDaemonSetDrainRequestedAnnotationKey annotation key added on Pods to ask them to drain before their deletion.
+	// The value is the RFC3339 time of the request.
+	ExtendedDaemonSetDrainRequestedAnnotationKey = "extendeddaemonset.datadoghq.com/drain-requested"
+	// ExtendedDaemonSetDrainCompleteAnnotationKey annotation key set on Pods by themselves once they are drained.
+	ExtendedDaemonSetDrainCompleteAnnotationKey = "extendeddaemonset.datadoghq.com/drain-complete"
+	// ExtendedDaemonSetCanaryNodeLabelKey label key set on Nodes to always select them for the canary deployments with the value `true`,
+	// or to never select them with the value `false`.
+	ExtendedDaemonSetCanaryNodeLabelKey = "extendeddaemonset.datadoghq.com/canary-node"
 	// MD5ExtendedDaemonSetAnnotationKey annotation key use on Pods in order to identify which PodTemplateSpec have been used to generate it.
 	MD5ExtendedDaemonSetAnnotationKey = "extendeddaemonset.datadoghq.com/templatehash"
 	// ExtendedDaemonSetCanaryValidAnnotationKey annotation key used on Pods in order to detect if a canary deployment is considered valid.
@@ -40,6 +50,15 @@ const (
 	ExtendedDaemonSetRollingUpdatePausedAnnotationKey = "extendeddaemonset.datadoghq.com/rolling-update-paused"
 	// ExtendedDaemonSetRolloutFrozenAnnotationKey annotation key used on ExtendedDaemonset in order to detect if a rollout is frozen.
 	ExtendedDaemonSetRolloutFrozenAnnotationKey = "extendeddaemonset.datadoghq.com/rollout-frozen"
+	// ExtendedDaemonSetDryRunAnnotationKey annotation key used on ExtendedDaemonset in order to only plan the pods creations and deletions, without executing them.
+	ExtendedDaemonSetDryRunAnnotationKey = "extendeddaemonset.datadoghq.com/dry-run"
+	// ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey annotation key used on ExtendedDaemonset to approve the next batch of a rolling update
+	// with manual batch approval. The value is `<replicaset name>/<last completed batch number>`, so an approval left over from a previous
+	// rollout doesn't approve the batches of a new ExtendedDaemonSetReplicaSet.
+	ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey = "extendeddaemonset.datadoghq.com/rolling-update-approved-batch"
+	// ExtendedDaemonSetRolloutGroupLabelKey label key used on ExtendedDaemonset to group the ExtendedDaemonsets, in the same namespace,
+	// whose canary deployments run on the same nodes and fail together.
+	ExtendedDaemonSetRolloutGroupLabelKey = "extendeddaemonset.datadoghq.com/rollout-group"
 
 	// ValueStringTrue is the string value of bool `true`.
 	ValueStringTrue = "true"
@@ -0,0 +1,9 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package v1alpha1
+
+// Hub marks v1alpha1 as the conversion hub: it is the storage version, and the version used by the controllers.
+func (*ExtendedDaemonSet) Hub() {}
@@ -20,14 +20,20 @@ const (
 	defaultCanaryAutoPauseMaxRestarts = 2
 	defaultCanaryAutoFailEnabled      = true
 	defaultCanaryAutoFailMaxRestarts  = 5
+	defaultCanaryAutoRollback         = true
 	defaultSlowStartIntervalDuration  = 1
 	defaultMaxParallelPodCreation     = 250
+	defaultMaxUnhealthyPodRestarts    = 5
 	defaultReconcileFrequency         = 10 * time.Second
 )
 
 // IsDefaultedExtendedDaemonSet used to know if a ExtendedDaemonSet is already defaulted
 // returns true if yes, else no.
 func IsDefaultedExtendedDaemonSet(dd *ExtendedDaemonSet) bool {
+	if dd.Spec.Strategy.Type == "" {
+		return false
+	}
+
 	if !IsDefaultedExtendedDaemonSetSpecStrategyRollingUpdate(&dd.Spec.Strategy.RollingUpdate) {
 		return false
 	}
@@ -74,6 +80,10 @@ func IsDefaultedExtendedDaemonSetSpecStrategyRollingUpdate(rollingupdate *Extend
 		return false
 	}
 
+	if rollingupdate.MaxUnhealthyPods != nil && rollingupdate.MaxUnhealthyPodRestarts == nil {
+		return false
+	}
+
 	return true
 }
 
@@ -100,6 +110,10 @@ func IsDefaultedExtendedDaemonSetSpecStrategyCanary(canary *ExtendedDaemonSetSpe
 		return false
 	}
 
+	if canary.AutoRollback == nil {
+		return false
+	}
+
 	return true
 }
 
@@ -117,6 +131,10 @@ func DefaultExtendedDaemonSetSpec(spec *ExtendedDaemonSetSpec, defaultValidation
 	// reset template name
 	spec.Template.Name = ""
 
+	if spec.Strategy.Type == "" {
+		spec.Strategy.Type = ExtendedDaemonSetSpecStrategyTypeRollingUpdate
+	}
+
 	DefaultExtendedDaemonSetSpecStrategyRollingUpdate(&spec.Strategy.RollingUpdate)
 
 	if spec.Strategy.Canary != nil {
@@ -159,6 +177,10 @@ func DefaultExtendedDaemonSetSpecStrategyCanary(c *ExtendedDaemonSetSpecStrategy
 	}
 	DefaultExtendedDaemonSetSpecStrategyCanaryAutoFail(c.AutoFail)
 
+	if c.AutoRollback == nil {
+		c.AutoRollback = NewBool(defaultCanaryAutoRollback)
+	}
+
 	if c.NoRestartsDuration == nil && c.ValidationMode == ExtendedDaemonSetSpecStrategyCanaryValidationModeAuto {
 		c.NoRestartsDuration = &metav1.Duration{
 			Duration: defaultCanaryNoRestartsDuration * time.Minute,
@@ -214,5 +236,9 @@ func DefaultExtendedDaemonSetSpecStrategyRollingUpdate(rollingupdate *ExtendedDa
 
 	rollingupdate.SlowStartAdditiveIncrease = intstr.ValueOrDefault(rollingupdate.SlowStartAdditiveIncrease, intstr.FromInt(1))
 
+	if rollingupdate.MaxUnhealthyPods != nil && rollingupdate.MaxUnhealthyPodRestarts == nil {
+		rollingupdate.MaxUnhealthyPodRestarts = NewInt32(defaultMaxUnhealthyPodRestarts)
+	}
+
 	return rollingupdate
 }
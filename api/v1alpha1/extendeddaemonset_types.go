@@ -28,18 +28,100 @@ type ExtendedDaemonSetSpec struct {
 	// More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicationcontroller#pod-template
 	Template corev1.PodTemplateSpec `json:"template"`
 
+	// The minimum number of seconds for which a newly created pod should
+	// be ready without any of its container crashing, for it to be considered
+	// available. It is used by the canary and rolling update strategies.
+	// Defaults to 0 (pod will be considered available as soon as it is ready).
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// NodeMetadata lists the node labels and annotations copied into the pods, as pod labels, pod annotations
+	// or container environment variables, when the pods are created. A change of a copied node label or
+	// annotation value triggers the replacement of the pod running on the node.
+	// +optional
+	// +listType=atomic
+	NodeMetadata []ExtendedDaemonSetNodeMetadata `json:"nodeMetadata,omitempty"`
+
+	// StartupTaint defines a node taint that the controller removes from a node once the ExtendedDaemonSet
+	// pod running on this node is ready. It allows to keep the workloads away from a node until the daemon is running.
+	// +optional
+	StartupTaint *ExtendedDaemonSetStartupTaint `json:"startupTaint,omitempty"`
+
 	// Daemonset deployment strategy.
 	Strategy ExtendedDaemonSetSpecStrategy `json:"strategy"`
+
+	// DependsOn is the list of the ExtendedDaemonSets, in the same namespace, that need to have completed
+	// their rollout before a new template of this ExtendedDaemonSet starts to be deployed.
+	// +optional
+	// +listType=set
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
+// ExtendedDaemonSetStartupTaint defines the node taint removed once the ExtendedDaemonSet pod running on the node is ready.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetStartupTaint struct {
+	// Key is the key of the taint removed from the node. The pod template needs to tolerate this taint.
+	Key string `json:"key"`
+	// Effect is the effect of the taint added back on the node when ReAddOnPodDeletion is true.
+	// Default value is NoSchedule.
+	// +optional
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+	// ReAddOnPodDeletion if true, the taint is added back on the node when its ExtendedDaemonSet pod is deleted.
+	// Default value is false.
+	// +optional
+	ReAddOnPodDeletion *bool `json:"reAddOnPodDeletion,omitempty"`
+}
+
+// ExtendedDaemonSetNodeMetadata defines a node label or annotation copied into the pods, and its targets in the pods.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetNodeMetadata struct {
+	// NodeLabel is the key of the node label to copy. Only one of NodeLabel and NodeAnnotation can be set.
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
+	// NodeAnnotation is the key of the node annotation to copy. Only one of NodeLabel and NodeAnnotation can be set.
+	// +optional
+	NodeAnnotation string `json:"nodeAnnotation,omitempty"`
+	// PodLabel is the key of the pod label set with the node label or annotation value. It can't be a key of the selector
+	// or a key reserved by the controller (with an `extendeddaemonset*.datadoghq.com` prefix). The node values that are not
+	// valid label values are not copied.
+	// +optional
+	PodLabel string `json:"podLabel,omitempty"`
+	// PodAnnotation is the key of the pod annotation set with the node label or annotation value. It can't be a key
+	// reserved by the controller (with an `extendeddaemonset*.datadoghq.com` prefix).
+	// +optional
+	PodAnnotation string `json:"podAnnotation,omitempty"`
+	// Env is the name of the environment variable set with the node label or annotation value in all the pod containers.
+	// +optional
+	Env string `json:"env,omitempty"`
+}
+
+// ExtendedDaemonSetSpecStrategyType type representing the ExtendedDaemonSet update strategy type.
+// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+type ExtendedDaemonSetSpecStrategyType string
+
+const (
+	// ExtendedDaemonSetSpecStrategyTypeRollingUpdate replaces outdated pods progressively, following the rollingUpdate configuration.
+	ExtendedDaemonSetSpecStrategyTypeRollingUpdate ExtendedDaemonSetSpecStrategyType = "RollingUpdate"
+	// ExtendedDaemonSetSpecStrategyTypeOnDelete only creates pods with the new template when an outdated pod has been deleted.
+	ExtendedDaemonSetSpecStrategyTypeOnDelete ExtendedDaemonSetSpecStrategyType = "OnDelete"
+)
+
 // ExtendedDaemonSetSpecStrategy defines the deployment strategy of ExtendedDaemonSet.
 // +k8s:openapi-gen=true
 type ExtendedDaemonSetSpecStrategy struct {
+	// Type of update strategy. Can be "RollingUpdate" (default) or "OnDelete".
+	// With "OnDelete", pods using an outdated template are only replaced once they are deleted externally.
+	Type          ExtendedDaemonSetSpecStrategyType          `json:"type,omitempty"`
 	RollingUpdate ExtendedDaemonSetSpecStrategyRollingUpdate `json:"rollingUpdate,omitempty"`
 	// Canary deployment configuration
 	Canary *ExtendedDaemonSetSpecStrategyCanary `json:"canary,omitempty"`
 	// ReconcileFrequency use to configure how often the ExtendedDeamonset will be fully reconcile, default is 10sec.
 	ReconcileFrequency *metav1.Duration `json:"reconcileFrequency,omitempty"`
+	// UseEvictionAPI if true, the pods are evicted with the Eviction API instead of being deleted, to respect
+	// the PodDisruptionBudgets. An eviction refused by a PodDisruptionBudget is retried later.
+	// Default value is false.
+	UseEvictionAPI *bool `json:"useEvictionAPI,omitempty"`
 }
 
 // ExtendedDaemonSetSpecStrategyRollingUpdate defines the rolling update deployment strategy of ExtendedDaemonSet.
@@ -59,6 +141,11 @@ type ExtendedDaemonSetSpecStrategyRollingUpdate struct {
 	// The maxium number of pods created in parallel.
 	// Default value is 250.
 	MaxParallelPodCreation *int32 `json:"maxParallelPodCreation,omitempty"`
+	// MaxParallelNewNodePodCreation if set, the pods of the nodes that joined the cluster after the creation of the
+	// replicaset are created outside of the slow start and MaxParallelPodCreation limits, up to this number of pods
+	// in parallel. Those nodes never ran an outdated pod, so there is no need to throttle their pods creation.
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelNewNodePodCreation *int32 `json:"maxParallelNewNodePodCreation,omitempty"`
 	// SlowStartIntervalDuration the duration between to 2
 	// Default value is 1min.
 	SlowStartIntervalDuration *metav1.Duration `json:"slowStartIntervalDuration,omitempty"`
@@ -67,6 +154,50 @@ type ExtendedDaemonSetSpecStrategyRollingUpdate struct {
 	// number of DaemonSet pods at the start of the update (ex: 10%).
 	// Default value is 5.
 	SlowStartAdditiveIncrease *intstr.IntOrString `json:"slowStartAdditiveIncrease,omitempty"`
+	// ManualBatchApproval if true, the pods are updated by batches of MaxUnavailable pods, and the
+	// rolling update is paused after each batch until the next one is approved with `kubectl eds rollout next`.
+	// Default value is false.
+	ManualBatchApproval *bool `json:"manualBatchApproval,omitempty"`
+	// PauseAt defines checkpoints at which the rolling update is automatically paused, once the number of nodes
+	// running the new template reaches them. Values can be absolute numbers (ex: 5) or percentages of the total
+	// number of DaemonSet pods (ex: 10%).
+	// +listType=atomic
+	PauseAt []intstr.IntOrString `json:"pauseAt,omitempty"`
+	// PauseAtAutoResumeDuration if set, a rolling update paused at a PauseAt checkpoint is automatically resumed
+	// after this duration, if no pod from the new template restarted in the meantime.
+	PauseAtAutoResumeDuration *metav1.Duration `json:"pauseAtAutoResumeDuration,omitempty"`
+	// PrePullImages if true, the images of the new pod template are pulled on a node, with a pre-pull pod,
+	// before the old pod running on this node is deleted.
+	// Default value is false.
+	PrePullImages *bool `json:"prePullImages,omitempty"`
+	// DrainTimeout if set, the outdated pods are asked to drain before being deleted: the controller adds the
+	// `extendeddaemonset.datadoghq.com/drain-requested` annotation on the pod, and waits until the pod sets the
+	// `extendeddaemonset.datadoghq.com/drain-complete` annotation or until the timeout expires. The draining pods
+	// are counted against maxUnavailable.
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// DoNotDisruptNodeAnnotationKeys the rolling update doesn't delete the outdated pods running on the nodes
+	// with one of these annotations (ex: karpenter.sh/do-not-disrupt), unless its value is "false".
+	// The pods are updated once the annotation disappears.
+	// +listType=set
+	DoNotDisruptNodeAnnotationKeys []string `json:"doNotDisruptNodeAnnotationKeys,omitempty"`
+	// DoNotDisruptNodeLabelKeys the rolling update doesn't delete the outdated pods running on the nodes
+	// with one of these labels, unless its value is "false".
+	// The pods are updated once the label disappears.
+	// +listType=set
+	DoNotDisruptNodeLabelKeys []string `json:"doNotDisruptNodeLabelKeys,omitempty"`
+	// MaxUnhealthyPods if set, enables the health gate of the rolling update: the rolling update is automatically
+	// paused once more than this number of the up-to-date pods are unhealthy, because they cannot start
+	// (ex: CrashLoopBackOff) or restarted more than MaxUnhealthyPodRestarts times. Value can be an absolute number
+	// (ex: 5) or a percentage of the up-to-date pods (ex: 5%). Absolute number is calculated from percentage by rounding down.
+	// The rolling update stays paused until it is resumed manually.
+	MaxUnhealthyPods *intstr.IntOrString `json:"maxUnhealthyPods,omitempty"`
+	// MaxUnhealthyPodRestarts the number of restarts above which an up-to-date pod is unhealthy for the health gate.
+	// Default value is 5 when MaxUnhealthyPods is set.
+	MaxUnhealthyPodRestarts *int32 `json:"maxUnhealthyPodRestarts,omitempty"`
+	// FailOnUnhealthyPods if true, the rolling update is failed instead of paused when the health gate is reached:
+	// the pods of the replicaset are neither created nor deleted anymore, until the pod template is updated.
+	// Default value is false.
+	FailOnUnhealthyPods *bool `json:"failOnUnhealthyPods,omitempty"`
 }
 
 // ExtendedDaemonSetSpecStrategyCanaryValidationMode type representing the ExtendedDaemonSetSpecStrategyCanary validation mode.
@@ -94,6 +225,62 @@ type ExtendedDaemonSetSpecStrategyCanary struct {
 	NoRestartsDuration *metav1.Duration `json:"noRestartsDuration,omitempty"`
 	// ValidationMode used to configure how a canary deployment is validated. Possible values are 'auto' (default) and 'manual'
 	ValidationMode ExtendedDaemonSetSpecStrategyCanaryValidationMode `json:"validationMode,omitempty"`
+	// NodeSelectionPolicy defines how the canary nodes are selected.
+	// Default policy is 'leastRestarts'.
+	NodeSelectionPolicy *ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy `json:"nodeSelectionPolicy,omitempty"`
+	// AutoRollback restores the ExtendedDaemonSet pod template and node metadata to the ones of the active replicaset
+	// when the canary deployment fails. The failed template is not deployed again.
+	// Default value is true.
+	AutoRollback *bool `json:"autoRollback,omitempty"`
+}
+
+// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType type representing the canary node selection policy.
+// +kubebuilder:validation:Enum=leastRestarts;random;oldestNodes;newestNodes;explicit;preferLabel
+type ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType string
+
+const (
+	// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyLeastRestarts selects first the nodes where the ExtendedDaemonSet pods restarted the least.
+	ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyLeastRestarts ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType = "leastRestarts"
+	// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyRandom selects the nodes randomly.
+	ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyRandom ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType = "random"
+	// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyOldestNodes selects first the oldest nodes.
+	ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyOldestNodes ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType = "oldestNodes"
+	// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyNewestNodes selects first the newest nodes.
+	ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyNewestNodes ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType = "newestNodes"
+	// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyExplicit selects only the listed nodes.
+	ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyExplicit ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType = "explicit"
+	// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel selects first the nodes with the highest weight of preferred labels.
+	ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType = "preferLabel"
+)
+
+// ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy defines how the canary nodes are selected.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy struct {
+	// Type of the node selection policy. Possible values are 'leastRestarts' (default), 'random', 'oldestNodes',
+	// 'newestNodes', 'explicit' and 'preferLabel'.
+	Type ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType `json:"type,omitempty"`
+	// Seed used to shuffle the nodes with the 'random' policy, to get a reproducible selection.
+	// A random seed is used if not set.
+	Seed *int64 `json:"seed,omitempty"`
+	// NodeNames the nodes to select, by order of preference, with the 'explicit' policy.
+	// +listType=atomic
+	NodeNames []string `json:"nodeNames,omitempty"`
+	// PreferredLabels the weighted node labels used with the 'preferLabel' policy: the nodes with the highest
+	// sum of weights are selected first.
+	// +listType=atomic
+	PreferredLabels []ExtendedDaemonSetSpecStrategyCanaryPreferredLabel `json:"preferredLabels,omitempty"`
+}
+
+// ExtendedDaemonSetSpecStrategyCanaryPreferredLabel defines a weighted node label of the 'preferLabel' canary node selection policy.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetSpecStrategyCanaryPreferredLabel struct {
+	// Key of the node label.
+	Key string `json:"key"`
+	// Value of the node label. Any value matches if not set.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Weight added to the nodes with this label.
+	Weight int32 `json:"weight"`
 }
 
 // ExtendedDaemonSetSpecStrategyCanaryAutoPause defines the canary deployment AutoPause parameters of the ExtendedDaemonSet.
@@ -135,8 +322,12 @@ const (
 	ExtendedDaemonSetStatusStateRunning ExtendedDaemonSetStatusState = "Running"
 	// ExtendedDaemonSetStatusStateRollingUpdatePaused the ExtendedDaemonSet rolling update is paused.
 	ExtendedDaemonSetStatusStateRollingUpdatePaused ExtendedDaemonSetStatusState = "RollingUpdate Paused"
+	// ExtendedDaemonSetStatusStateRollingUpdateFailed the ExtendedDaemonSet rolling update is failed by its health gate.
+	ExtendedDaemonSetStatusStateRollingUpdateFailed ExtendedDaemonSetStatusState = "RollingUpdate Failed"
 	// ExtendedDaemonSetStatusStateRolloutFrozen the ExtendedDaemonSet rollout is frozen.
 	ExtendedDaemonSetStatusStateRolloutFrozen ExtendedDaemonSetStatusState = "Rollout frozen"
+	// ExtendedDaemonSetStatusStateWaitingOnDependency the ExtendedDaemonSet rollout waits for the rollout of its dependencies to complete.
+	ExtendedDaemonSetStatusStateWaitingOnDependency ExtendedDaemonSetStatusState = "Waiting on dependency"
 	// ExtendedDaemonSetStatusStateCanary the ExtendedDaemonSet currently run a new version with a Canary deployment.
 	ExtendedDaemonSetStatusStateCanary ExtendedDaemonSetStatusState = "Canary"
 	// ExtendedDaemonSetStatusStateCanaryPaused the Canary deployment of the ExtendedDaemonSet is paused.
@@ -179,6 +370,14 @@ const (
 	ExtendedDaemonSetStatusReasonPreStartHookError ExtendedDaemonSetStatusReason = "PreStartHookError"
 	// ExtendedDaemonSetStatusReasonPostStartHookError represent PostStartHookError as the reason for the ExtendedDaemonSet status state.
 	ExtendedDaemonSetStatusReasonPostStartHookError ExtendedDaemonSetStatusReason = "PostStartHookError"
+	// ExtendedDaemonSetStatusReasonWaitingForBatchApproval represents a rolling update waiting for the approval of its next batch.
+	ExtendedDaemonSetStatusReasonWaitingForBatchApproval ExtendedDaemonSetStatusReason = "WaitingForBatchApproval"
+	// ExtendedDaemonSetStatusReasonUnhealthyPods represents a rolling update stopped by its health gate, because of too many unhealthy up-to-date pods.
+	ExtendedDaemonSetStatusReasonUnhealthyPods ExtendedDaemonSetStatusReason = "UnhealthyPods"
+	// ExtendedDaemonSetStatusReasonWaitingOnDependency represents a rollout waiting for the rollout of its dependencies to complete.
+	ExtendedDaemonSetStatusReasonWaitingOnDependency ExtendedDaemonSetStatusReason = "WaitingOnDependency"
+	// ExtendedDaemonSetStatusReasonRolloutGroupFailed represents the failure of the canary deployment of another member of the rollout group.
+	ExtendedDaemonSetStatusReasonRolloutGroupFailed ExtendedDaemonSetStatusReason = "RolloutGroupFailed"
 	// ExtendedDaemonSetStatusReasonPreCreateHookError represent PreCreateHookError as the reason for the ExtendedDaemonSet status state.
 	ExtendedDaemonSetStatusReasonPreCreateHookError ExtendedDaemonSetStatusReason = "PreCreateHookError"
 	// ExtendedDaemonSetStatusReasonStartError represent StartError as the reason for the ExtendedDaemonSet status state.
@@ -197,6 +396,14 @@ const (
 	ConditionTypeEDSCanaryPaused ExtendedDaemonSetConditionType = "Canary-Paused"
 	// ConditionTypeEDSCanaryFailed ExtendedDaemonSetis in canary mode.
 	ConditionTypeEDSCanaryFailed ExtendedDaemonSetConditionType = "Canary-Failed"
+	// ConditionTypeEDSCanaryRolledBack ExtendedDaemonSet pod template already failed its canary deployment, and is not deployed again.
+	ConditionTypeEDSCanaryRolledBack ExtendedDaemonSetConditionType = "Canary-RolledBack"
+	// ConditionTypeEDSProgressing ExtendedDaemonSet rollout (canary or rolling update) is in progress.
+	ConditionTypeEDSProgressing ExtendedDaemonSetConditionType = "Progressing"
+	// ConditionTypeEDSAvailable ExtendedDaemonSet has the minimum number of available pods allowed by the rolling update maxUnavailable.
+	ConditionTypeEDSAvailable ExtendedDaemonSetConditionType = "Available"
+	// ConditionTypeEDSDegraded ExtendedDaemonSet rollout needs an intervention: failed or automatically paused canary, or replicaset reconcile error.
+	ConditionTypeEDSDegraded ExtendedDaemonSetConditionType = "Degraded"
 )
 
 // ExtendedDaemonSetCondition describes the state of a ExtendedDaemonSet at a certain point.
@@ -222,16 +429,42 @@ type ExtendedDaemonSetCondition struct {
 // ExtendedDaemonSetStatus defines the observed state of ExtendedDaemonSet
 // +k8s:openapi-gen=true
 type ExtendedDaemonSetStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration       int64 `json:"observedGeneration,omitempty"`
 	Desired                  int32 `json:"desired"`
 	Current                  int32 `json:"current"`
 	Ready                    int32 `json:"ready"`
 	Available                int32 `json:"available"`
 	UpToDate                 int32 `json:"upToDate"`
 	IgnoredUnresponsiveNodes int32 `json:"ignoredUnresponsiveNodes"`
+	// NumberMisscheduled is the number of nodes that are running the daemon pod, but are not supposed to run it.
+	// +optional
+	NumberMisscheduled int32 `json:"numberMisscheduled,omitempty"`
+	// UpdatedNumberScheduled is the number of nodes that are running the up-to-date daemon pod.
+	// +optional
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled,omitempty"`
+	// NumberUnavailable is the number of nodes that should be running the daemon pod and have none of
+	// the daemon pod running and available.
+	// +optional
+	NumberUnavailable int32 `json:"numberUnavailable,omitempty"`
+	// CollisionCount is the number of extra ExtendedDaemonSetReplicaSets whose pod template hash
+	// collides with the up-to-date one.
+	// +optional
+	CollisionCount int32 `json:"collisionCount,omitempty"`
 
 	State            ExtendedDaemonSetStatusState   `json:"state,omitempty"`
 	ActiveReplicaSet string                         `json:"activeReplicaSet"`
 	Canary           *ExtendedDaemonSetStatusCanary `json:"canary,omitempty"`
+	// RollingUpdateBatch contains the current batch of the rolling update when the manual batch approval is enabled.
+	// +optional
+	RollingUpdateBatch *ExtendedDaemonSetStatusRollingUpdateBatch `json:"rollingUpdateBatch,omitempty"`
+	// RollingUpdateCheckpoint contains the last rollingUpdate.pauseAt checkpoint reached by the rolling update.
+	// +optional
+	RollingUpdateCheckpoint *ExtendedDaemonSetStatusRollingUpdateCheckpoint `json:"rollingUpdateCheckpoint,omitempty"`
+	// FailedTemplateHash is the hash of the last pod template rolled back after a canary deployment failure.
+	// +optional
+	FailedTemplateHash string `json:"failedTemplateHash,omitempty"`
 
 	// Reason provides an explanation for canary deployment autopause
 	// +optional
@@ -251,6 +484,32 @@ type ExtendedDaemonSetStatusCanary struct {
 	Nodes []string `json:"nodes,omitempty"`
 }
 
+// ExtendedDaemonSetStatusRollingUpdateCheckpoint defines the last rollingUpdate.pauseAt checkpoint reached by the rolling update.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetStatusRollingUpdateCheckpoint struct {
+	// ReplicaSet is the name of the replicaset whose rolling update reached the checkpoint.
+	ReplicaSet string `json:"replicaSet"`
+	// PauseAt is the checkpoint reached.
+	PauseAt intstr.IntOrString `json:"pauseAt"`
+	// PausedTime is the time at which the rolling update was paused at the checkpoint.
+	PausedTime metav1.Time `json:"pausedTime"`
+	// Resumed is true once the rolling update has been resumed after the checkpoint, automatically or not.
+	Resumed bool `json:"resumed,omitempty"`
+}
+
+// ExtendedDaemonSetStatusRollingUpdateBatch defines the observed state of a rolling update with manual batch approval.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetStatusRollingUpdateBatch struct {
+	// Number of the current batch, starting at 1.
+	Number int32 `json:"number"`
+	// Nodes on which the pods are updated during the current batch.
+	// +listType=set
+	Nodes []string `json:"nodes,omitempty"`
+	// Completed is true when all the pods of the current batch are up-to-date and available:
+	// the rolling update is paused until the next batch is approved.
+	Completed bool `json:"completed,omitempty"`
+}
+
 // ExtendedDaemonSet is the Schema for the extendeddaemonsets API.
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
@@ -266,6 +525,7 @@ type ExtendedDaemonSetStatusCanary struct {
 // +kubebuilder:printcolumn:name="canary rs",type="string",JSONPath=".status.canary.replicaSet"
 // +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:path=extendeddaemonsets,shortName=eds
+// +kubebuilder:storageversion
 // +k8s:openapi-gen=true
 // +genclient
 type ExtendedDaemonSet struct {
@@ -5,7 +5,15 @@
 
 package v1alpha1
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
 
 var (
 	// ErrInvalidAutoFailRestarts is returned in case of a validation failure for maxRestarts in autoFail.
@@ -16,6 +24,16 @@ var (
 	ErrNoRestartsDurationWithManualValidationMode = errors.New("canary noRestartsDuration does not have effect with validationMode=manual")
 	// ErrInvalidCanaryTimeout is returned when the autoFail canaryTimeout is invalid.
 	ErrInvalidCanaryTimeout = errors.New("canary autoFail.canaryTimeout must be greater than the canary duration")
+	// ErrInvalidPauseAt is returned when a rollingUpdate.pauseAt checkpoint is invalid.
+	ErrInvalidPauseAt = errors.New("rollingUpdate.pauseAt checkpoints must be positive numbers or percentages")
+	// ErrInvalidMaxUnhealthyPods is returned when the rollingUpdate.maxUnhealthyPods is invalid.
+	ErrInvalidMaxUnhealthyPods = errors.New("rollingUpdate.maxUnhealthyPods must be a positive number or percentage")
+	// ErrInvalidNodeMetadata is returned when a nodeMetadata entry is invalid.
+	ErrInvalidNodeMetadata = errors.New("nodeMetadata entries must set either nodeLabel or nodeAnnotation, and at least one valid podLabel, podAnnotation or env target, without reserved or selector keys")
+	// ErrInvalidStartupTaint is returned when the startupTaint is invalid.
+	ErrInvalidStartupTaint = errors.New("startupTaint must have a valid key, and an effect among NoSchedule, PreferNoSchedule and NoExecute")
+	// ErrInvalidCanaryNodeSelectionPolicy is returned when the canary nodeSelectionPolicy is invalid.
+	ErrInvalidCanaryNodeSelectionPolicy = errors.New("canary nodeSelectionPolicy must set nodeNames with the explicit policy, and preferredLabels with valid keys with the preferLabel policy")
 )
 
 // ValidateExtendedDaemonSetSpec validates an ExtendedDaemonSet spec
@@ -38,7 +56,111 @@ func ValidateExtendedDaemonSetSpec(spec *ExtendedDaemonSetSpec) error {
 				return ErrNoRestartsDurationWithManualValidationMode
 			}
 		}
+
+		if canary.NodeSelectionPolicy != nil && !isValidCanaryNodeSelectionPolicy(canary.NodeSelectionPolicy) {
+			return ErrInvalidCanaryNodeSelectionPolicy
+		}
+	}
+
+	for _, pauseAt := range spec.Strategy.RollingUpdate.PauseAt {
+		if value, err := intstr.GetScaledValueFromIntOrPercent(&pauseAt, 100, true); err != nil || value <= 0 {
+			return ErrInvalidPauseAt
+		}
+	}
+
+	if maxUnhealthyPods := spec.Strategy.RollingUpdate.MaxUnhealthyPods; maxUnhealthyPods != nil {
+		if value, err := intstr.GetScaledValueFromIntOrPercent(maxUnhealthyPods, 100, false); err != nil || value < 0 {
+			return ErrInvalidMaxUnhealthyPods
+		}
+	}
+
+	for _, nodeMetadata := range spec.NodeMetadata {
+		if !isValidNodeMetadata(&nodeMetadata, spec.Selector) {
+			return ErrInvalidNodeMetadata
+		}
+	}
+
+	if spec.StartupTaint != nil && !isValidStartupTaint(spec.StartupTaint) {
+		return ErrInvalidStartupTaint
 	}
 
 	return nil
 }
+
+func isValidCanaryNodeSelectionPolicy(policy *ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy) bool {
+	switch policy.Type {
+	case ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyExplicit:
+		return len(policy.NodeNames) > 0
+	case ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel:
+		if len(policy.PreferredLabels) == 0 {
+			return false
+		}
+		for _, label := range policy.PreferredLabels {
+			if len(validation.IsQualifiedName(label.Key)) > 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isValidStartupTaint(startupTaint *ExtendedDaemonSetStartupTaint) bool {
+	if len(validation.IsQualifiedName(startupTaint.Key)) > 0 {
+		return false
+	}
+	switch startupTaint.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidNodeMetadata(nodeMetadata *ExtendedDaemonSetNodeMetadata, selector *metav1.LabelSelector) bool {
+	if (nodeMetadata.NodeLabel == "") == (nodeMetadata.NodeAnnotation == "") {
+		return false
+	}
+	if nodeMetadata.PodLabel == "" && nodeMetadata.PodAnnotation == "" && nodeMetadata.Env == "" {
+		return false
+	}
+	if nodeMetadata.PodLabel != "" && (len(validation.IsQualifiedName(nodeMetadata.PodLabel)) > 0 || isReservedMetadataKey(nodeMetadata.PodLabel) || isSelectorKey(selector, nodeMetadata.PodLabel)) {
+		return false
+	}
+	if nodeMetadata.PodAnnotation != "" && (len(validation.IsQualifiedName(nodeMetadata.PodAnnotation)) > 0 || isReservedMetadataKey(nodeMetadata.PodAnnotation)) {
+		return false
+	}
+	if nodeMetadata.Env != "" && len(validation.IsEnvVarName(nodeMetadata.Env)) > 0 {
+		return false
+	}
+
+	return true
+}
+
+// isReservedMetadataKey returns true for the pod label and annotation keys managed by the controller,
+// like extendeddaemonset.datadoghq.com/name or extendeddaemonsetreplicaset.datadoghq.com/name.
+func isReservedMetadataKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+
+	return strings.HasSuffix(prefix, ".datadoghq.com") && strings.Contains(prefix, "extendeddaemonset")
+}
+
+// isSelectorKey returns true if the label key is used by the selector, so overriding it would unselect the pods.
+func isSelectorKey(selector *metav1.LabelSelector, key string) bool {
+	if selector == nil {
+		return false
+	}
+	if _, found := selector.MatchLabels[key]; found {
+		return true
+	}
+	for _, requirement := range selector.MatchExpressions {
+		if requirement.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -51,6 +52,69 @@ func TestValidateExtendedDaemonSetSpec(t *testing.T) {
 	*validAutoPauseNoAutoFail.Strategy.Canary.AutoFail.Enabled = false
 	*validAutoPauseNoAutoFail.Strategy.Canary.AutoFail.MaxRestarts = 1
 
+	validPauseAt := validNoCanary.DeepCopy()
+	validPauseAt.Strategy.RollingUpdate.PauseAt = []intstr.IntOrString{intstr.FromString("10%"), intstr.FromInt(50)}
+
+	invalidPauseAt := validNoCanary.DeepCopy()
+	invalidPauseAt.Strategy.RollingUpdate.PauseAt = []intstr.IntOrString{intstr.FromString("10")}
+
+	validMaxUnhealthyPods := validNoCanary.DeepCopy()
+	validMaxUnhealthyPods.Strategy.RollingUpdate.MaxUnhealthyPods = &intstr.IntOrString{Type: intstr.String, StrVal: "5%"}
+
+	invalidMaxUnhealthyPods := validNoCanary.DeepCopy()
+	invalidMaxUnhealthyPods.Strategy.RollingUpdate.MaxUnhealthyPods = &intstr.IntOrString{Type: intstr.Int, IntVal: -1}
+
+	validNodeMetadata := validNoCanary.DeepCopy()
+	validNodeMetadata.NodeMetadata = []ExtendedDaemonSetNodeMetadata{
+		{NodeLabel: "topology.kubernetes.io/zone", PodLabel: "zone", Env: "DD_NODE_ZONE"},
+		{NodeAnnotation: "pool", PodAnnotation: "example.com/pool"},
+	}
+
+	invalidNodeMetadataNoTarget := validNoCanary.DeepCopy()
+	invalidNodeMetadataNoTarget.NodeMetadata = []ExtendedDaemonSetNodeMetadata{{NodeLabel: "topology.kubernetes.io/zone"}}
+
+	invalidNodeMetadataBothSources := validNoCanary.DeepCopy()
+	invalidNodeMetadataBothSources.NodeMetadata = []ExtendedDaemonSetNodeMetadata{{NodeLabel: "zone", NodeAnnotation: "zone", PodLabel: "zone"}}
+
+	invalidNodeMetadataEnv := validNoCanary.DeepCopy()
+	invalidNodeMetadataEnv.NodeMetadata = []ExtendedDaemonSetNodeMetadata{{NodeLabel: "zone", Env: "1-ZONE"}}
+
+	invalidNodeMetadataReservedLabel := validNoCanary.DeepCopy()
+	invalidNodeMetadataReservedLabel.NodeMetadata = []ExtendedDaemonSetNodeMetadata{{NodeLabel: "zone", PodLabel: ExtendedDaemonSetReplicaSetNameLabelKey}}
+
+	invalidNodeMetadataReservedAnnotation := validNoCanary.DeepCopy()
+	invalidNodeMetadataReservedAnnotation.NodeMetadata = []ExtendedDaemonSetNodeMetadata{{NodeLabel: "zone", PodAnnotation: MD5ExtendedDaemonSetAnnotationKey}}
+
+	invalidNodeMetadataSelectorLabel := validNoCanary.DeepCopy()
+	invalidNodeMetadataSelectorLabel.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}}
+	invalidNodeMetadataSelectorLabel.NodeMetadata = []ExtendedDaemonSetNodeMetadata{{NodeLabel: "zone", PodLabel: "app"}}
+
+	validStartupTaint := validNoCanary.DeepCopy()
+	validStartupTaint.StartupTaint = &ExtendedDaemonSetStartupTaint{Key: "example.com/agent-not-ready", ReAddOnPodDeletion: NewBool(true)}
+
+	invalidStartupTaintEffect := validNoCanary.DeepCopy()
+	invalidStartupTaintEffect.StartupTaint = &ExtendedDaemonSetStartupTaint{Key: "example.com/agent-not-ready", Effect: "NoRun"}
+
+	invalidStartupTaintKey := validNoCanary.DeepCopy()
+	invalidStartupTaintKey.StartupTaint = &ExtendedDaemonSetStartupTaint{}
+
+	validNodeSelectionPolicy := validWithCanary.DeepCopy()
+	validNodeSelectionPolicy.Strategy.Canary.NodeSelectionPolicy = &ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+		Type:            ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel,
+		PreferredLabels: []ExtendedDaemonSetSpecStrategyCanaryPreferredLabel{{Key: "example.com/canary", Weight: 10}},
+	}
+
+	invalidNodeSelectionPolicyExplicit := validWithCanary.DeepCopy()
+	invalidNodeSelectionPolicyExplicit.Strategy.Canary.NodeSelectionPolicy = &ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+		Type: ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyExplicit,
+	}
+
+	invalidNodeSelectionPolicyLabelKey := validWithCanary.DeepCopy()
+	invalidNodeSelectionPolicyLabelKey.Strategy.Canary.NodeSelectionPolicy = &ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+		Type:            ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel,
+		PreferredLabels: []ExtendedDaemonSetSpecStrategyCanaryPreferredLabel{{Key: "invalid key", Weight: 10}},
+	}
+
 	invalidCanaryTimeout := validWithCanary.DeepCopy()
 	*invalidCanaryTimeout.Strategy.Canary.AutoPause.Enabled = true
 	*invalidCanaryTimeout.Strategy.Canary.AutoFail.Enabled = true
@@ -118,6 +182,86 @@ func TestValidateExtendedDaemonSetSpec(t *testing.T) {
 			spec: invalidManualValidationNoRestartsDuration,
 			err:  ErrNoRestartsDurationWithManualValidationMode,
 		},
+		{
+			name: "valid pauseAt",
+			spec: validPauseAt,
+		},
+		{
+			name: "invalid pauseAt",
+			spec: invalidPauseAt,
+			err:  ErrInvalidPauseAt,
+		},
+		{
+			name: "valid maxUnhealthyPods",
+			spec: validMaxUnhealthyPods,
+		},
+		{
+			name: "invalid maxUnhealthyPods",
+			spec: invalidMaxUnhealthyPods,
+			err:  ErrInvalidMaxUnhealthyPods,
+		},
+		{
+			name: "valid nodeMetadata",
+			spec: validNodeMetadata,
+		},
+		{
+			name: "invalid nodeMetadata without target",
+			spec: invalidNodeMetadataNoTarget,
+			err:  ErrInvalidNodeMetadata,
+		},
+		{
+			name: "invalid nodeMetadata with node label and annotation",
+			spec: invalidNodeMetadataBothSources,
+			err:  ErrInvalidNodeMetadata,
+		},
+		{
+			name: "invalid nodeMetadata env name",
+			spec: invalidNodeMetadataEnv,
+			err:  ErrInvalidNodeMetadata,
+		},
+		{
+			name: "invalid nodeMetadata with a reserved pod label",
+			spec: invalidNodeMetadataReservedLabel,
+			err:  ErrInvalidNodeMetadata,
+		},
+		{
+			name: "invalid nodeMetadata with a reserved pod annotation",
+			spec: invalidNodeMetadataReservedAnnotation,
+			err:  ErrInvalidNodeMetadata,
+		},
+		{
+			name: "invalid nodeMetadata with a selector pod label",
+			spec: invalidNodeMetadataSelectorLabel,
+			err:  ErrInvalidNodeMetadata,
+		},
+		{
+			name: "valid startupTaint",
+			spec: validStartupTaint,
+		},
+		{
+			name: "invalid startupTaint effect",
+			spec: invalidStartupTaintEffect,
+			err:  ErrInvalidStartupTaint,
+		},
+		{
+			name: "invalid startupTaint without key",
+			spec: invalidStartupTaintKey,
+			err:  ErrInvalidStartupTaint,
+		},
+		{
+			name: "valid canary nodeSelectionPolicy",
+			spec: validNodeSelectionPolicy,
+		},
+		{
+			name: "invalid explicit canary nodeSelectionPolicy without nodeNames",
+			spec: invalidNodeSelectionPolicyExplicit,
+			err:  ErrInvalidCanaryNodeSelectionPolicy,
+		},
+		{
+			name: "invalid preferLabel canary nodeSelectionPolicy label key",
+			spec: invalidNodeSelectionPolicyLabelKey,
+			err:  ErrInvalidCanaryNodeSelectionPolicy,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
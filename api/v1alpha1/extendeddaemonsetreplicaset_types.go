@@ -28,6 +28,11 @@ type ExtendedDaemonSetReplicaSetSpec struct {
 	// Populated by the system. It can be set only during the creation.
 	// +optional
 	TemplateGeneration string `json:"templateGeneration,omitempty"`
+	// NodeMetadata lists the node labels and annotations copied into the pods. It is copied from the
+	// ExtendedDaemonSet spec, and is part of the TemplateGeneration hash.
+	// +optional
+	// +listType=atomic
+	NodeMetadata []ExtendedDaemonSetNodeMetadata `json:"nodeMetadata,omitempty"`
 }
 
 // ExtendedDaemonSetReplicaSetSpecStrategy defines the desired state of ExtendedDaemonSet
@@ -40,16 +45,118 @@ type ExtendedDaemonSetReplicaSetSpecStrategy struct {
 // ExtendedDaemonSetReplicaSetStatus defines the observed state of ExtendedDaemonSetReplicaSet
 // +k8s:openapi-gen=true
 type ExtendedDaemonSetReplicaSetStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration       int64  `json:"observedGeneration,omitempty"`
 	Status                   string `json:"status"`
 	Desired                  int32  `json:"desired"`
 	Current                  int32  `json:"current"`
 	Ready                    int32  `json:"ready"`
 	Available                int32  `json:"available"`
 	IgnoredUnresponsiveNodes int32  `json:"ignoredUnresponsiveNodes"`
+	// NumberMisscheduled is the number of nodes that are running the daemon pod, but don't fit
+	// the replicaset pod template.
+	// +optional
+	NumberMisscheduled int32 `json:"numberMisscheduled,omitempty"`
+	// UpdatedNumberScheduled is the number of nodes that are running a scheduled pod created
+	// from the replicaset pod template.
+	// +optional
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled,omitempty"`
+	// NumberUnavailable is the number of nodes that should be running the replicaset pod and have none of
+	// the pod running and available.
+	// +optional
+	NumberUnavailable int32 `json:"numberUnavailable,omitempty"`
+	// NumberPrePulled is the number of nodes running an outdated pod where the images of the replicaset
+	// pod template are already pulled. It is only set when the rolling update pre-pulls the images.
+	// +optional
+	NumberPrePulled int32 `json:"numberPrePulled,omitempty"`
+	// NumberDraining is the number of nodes running an outdated pod that was asked to drain and is not drained yet.
+	// It is only set when the rolling update drains the pods.
+	// +optional
+	NumberDraining int32 `json:"numberDraining,omitempty"`
+	// NumberDeferred is the number of nodes running an outdated pod whose update is deferred because
+	// the node is annotated or labelled as do-not-disrupt.
+	// +optional
+	NumberDeferred int32 `json:"numberDeferred,omitempty"`
+	// NewNodes is the number of nodes without pod that joined the cluster after the creation of the replicaset. It is only
+	// set when the rolling update creates their pods outside of the slow start limits, with maxParallelNewNodePodCreation.
+	// +optional
+	NewNodes int32 `json:"newNodes,omitempty"`
 	// Conditions Represents the latest available observations of a DaemonSet's current state.
 	// +listType=map
 	// +listMapKey=type
 	Conditions []ExtendedDaemonSetReplicaSetCondition `json:"conditions,omitempty"`
+	// UnfitNodes contains a sample of the nodes that don't fit the pod template, with the reasons
+	// returned by the scheduling predicates. The number of nodes reported is bounded.
+	// +optional
+	// +listType=map
+	// +listMapKey=node
+	UnfitNodes []ExtendedDaemonSetReplicaSetUnfitNode `json:"unfitNodes,omitempty"`
+	// DryRunPlan contains the pods creations and deletions that the controller would have done
+	// during the last reconcile. It is only set when the ExtendedDaemonSet is in dry-run mode.
+	// +optional
+	DryRunPlan *ExtendedDaemonSetReplicaSetDryRunPlan `json:"dryRunPlan,omitempty"`
+	// RollingUpdateBatch contains the current batch of the rolling update when the manual batch approval is enabled.
+	// +optional
+	RollingUpdateBatch *ExtendedDaemonSetStatusRollingUpdateBatch `json:"rollingUpdateBatch,omitempty"`
+}
+
+// ExtendedDaemonSetReplicaSetDryRunPlan describes the pods actions planned by the controller in dry-run mode.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetReplicaSetDryRunPlan struct {
+	// PodsToCreate is the number of pods the controller would have created.
+	PodsToCreate int32 `json:"podsToCreate"`
+	// PodsToDelete is the number of pods the controller would have deleted.
+	PodsToDelete int32 `json:"podsToDelete"`
+	// Actions contains a sample of the planned actions. The number of actions reported is bounded.
+	// +optional
+	// +listType=atomic
+	Actions []ExtendedDaemonSetReplicaSetPlannedAction `json:"actions,omitempty"`
+}
+
+// ExtendedDaemonSetReplicaSetPlannedAction describes a pod creation or deletion planned in dry-run mode.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetReplicaSetPlannedAction struct {
+	// Type of the action: Create or Delete.
+	Type PlannedActionType `json:"type"`
+	// Node is the name of the node where the action applies.
+	Node string `json:"node"`
+	// Pod is the name of the pod to delete.
+	// +optional
+	Pod string `json:"pod,omitempty"`
+	// Reason is a human readable explanation of the action.
+	Reason string `json:"reason"`
+}
+
+// PlannedActionType type use to represent the type of a planned action.
+type PlannedActionType string
+
+const (
+	// PlannedActionTypeCreate the controller would create a pod.
+	PlannedActionTypeCreate PlannedActionType = "Create"
+	// PlannedActionTypeDelete the controller would delete a pod.
+	PlannedActionTypeDelete PlannedActionType = "Delete"
+)
+
+// ExtendedDaemonSetReplicaSetUnfitNode describes why the ExtendedDaemonSetReplicaSet pod can't be scheduled on a node.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetReplicaSetUnfitNode struct {
+	// Node is the name of the node.
+	Node string `json:"node"`
+	// Reasons contains the failures returned by the scheduling predicates.
+	// +optional
+	// +listType=atomic
+	Reasons []ExtendedDaemonSetReplicaSetUnfitReason `json:"reasons,omitempty"`
+}
+
+// ExtendedDaemonSetReplicaSetUnfitReason describes a scheduling predicate failure.
+// +k8s:openapi-gen=true
+type ExtendedDaemonSetReplicaSetUnfitReason struct {
+	// Predicate is the name of the predicate that rejected the node: NodeSelector, NodeAffinity or PodToleratesNodeTaints.
+	Predicate string `json:"predicate"`
+	// Message is a human readable explanation of the failure:
+	// which selector term, which affinity requirement or which taint failed.
+	Message string `json:"message"`
 }
 
 // ExtendedDaemonSetReplicaSetCondition describes the state of a ExtendedDaemonSetReplicaSet at a certain point.
@@ -80,6 +187,10 @@ const (
 	ConditionTypeActive ExtendedDaemonSetReplicaSetConditionType = "Active"
 	// ConditionTypeRollingUpdatePaused ExtendedDaemonSetReplicaSet is active but the rolling update is paused.
 	ConditionTypeRollingUpdatePaused ExtendedDaemonSetReplicaSetConditionType = "RollingUpdatePaused"
+	// ConditionTypeRollingUpdateFailed ExtendedDaemonSetReplicaSet rolling update is failed by its health gate.
+	ConditionTypeRollingUpdateFailed ExtendedDaemonSetReplicaSetConditionType = "RollingUpdateFailed"
+	// ConditionTypeRollingUpdateCompleted ExtendedDaemonSetReplicaSet pods have all been updated and available at least once.
+	ConditionTypeRollingUpdateCompleted ExtendedDaemonSetReplicaSetConditionType = "RollingUpdateCompleted"
 	// ConditionTypeRolloutFrozen ExtendedDaemonSetReplicaSet is active but the rollout is frozen.
 	ConditionTypeRolloutFrozen ExtendedDaemonSetReplicaSetConditionType = "RolloutFrozen"
 	// ConditionTypeCanary ExtendedDaemonSetReplicaSet is in canary mode.
@@ -94,6 +205,8 @@ const (
 	ConditionTypePodCreation ExtendedDaemonSetReplicaSetConditionType = "PodCreation"
 	// ConditionTypePodDeletion Pod(s) deletion condition.
 	ConditionTypePodDeletion ExtendedDaemonSetReplicaSetConditionType = "PodDeletion"
+	// ConditionTypePodEvictionBlocked the eviction of some pods was refused because of a PodDisruptionBudget.
+	ConditionTypePodEvictionBlocked ExtendedDaemonSetReplicaSetConditionType = "PodEvictionBlocked"
 	// ConditionTypePodRestarting Pod(s) restarting condition.
 	ConditionTypePodRestarting ExtendedDaemonSetReplicaSetConditionType = "PodRestarting"
 	// ConditionTypePodCannotStart Pod(s) cannot start condition.
@@ -104,6 +217,8 @@ const (
 	ConditionTypeCanaryPaused ExtendedDaemonSetReplicaSetConditionType = "Canary-Paused"
 	// ConditionTypeCanaryFailed ExtendedDaemonSetReplicaSet is in canary mode.
 	ConditionTypeCanaryFailed ExtendedDaemonSetReplicaSetConditionType = "Canary-Failed"
+	// ConditionTypeDryRun ExtendedDaemonSetReplicaSet pods creations and deletions are only planned, not executed.
+	ConditionTypeDryRun ExtendedDaemonSetReplicaSetConditionType = "DryRun"
 )
 
 // ExtendedDaemonSetReplicaSet is the Schema for the extendeddaemonsetreplicasets API.
@@ -5,6 +5,8 @@
 
 package v1alpha1
 
+import "fmt"
+
 // NewInt32 returns pointer on a new int32 value instance.
 func NewInt32(i int32) *int32 {
 	return &i
@@ -14,3 +16,23 @@ func NewInt32(i int32) *int32 {
 func NewBool(b bool) *bool {
 	return &b
 }
+
+// RollingUpdateApprovedBatchValue returns the value of the rolling-update-approved-batch annotation approving
+// the batch following the batch number `completedBatch` of the replicaset `ersName`.
+func RollingUpdateApprovedBatchValue(ersName string, completedBatch int32) string {
+	return fmt.Sprintf("%s/%d", ersName, completedBatch)
+}
+
+// GetNodeMetadataValue returns the value of the node label or annotation referenced by the nodeMetadata,
+// and false if the node doesn't have it.
+func GetNodeMetadataValue(nodeMetadata *ExtendedDaemonSetNodeMetadata, nodeLabels, nodeAnnotations map[string]string) (string, bool) {
+	if nodeMetadata.NodeLabel != "" {
+		value, found := nodeLabels[nodeMetadata.NodeLabel]
+
+		return value, found
+	}
+
+	value, found := nodeAnnotations[nodeMetadata.NodeAnnotation]
+
+	return value, found
+}
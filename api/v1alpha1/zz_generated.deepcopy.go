@@ -92,6 +92,21 @@ func (in *ExtendedDaemonSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetNodeMetadata) DeepCopyInto(out *ExtendedDaemonSetNodeMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetNodeMetadata.
+func (in *ExtendedDaemonSetNodeMetadata) DeepCopy() *ExtendedDaemonSetNodeMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetNodeMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedDaemonSetReplicaSet) DeepCopyInto(out *ExtendedDaemonSetReplicaSet) {
 	*out = *in
@@ -136,6 +151,26 @@ func (in *ExtendedDaemonSetReplicaSetCondition) DeepCopy() *ExtendedDaemonSetRep
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetReplicaSetDryRunPlan) DeepCopyInto(out *ExtendedDaemonSetReplicaSetDryRunPlan) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]ExtendedDaemonSetReplicaSetPlannedAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetReplicaSetDryRunPlan.
+func (in *ExtendedDaemonSetReplicaSetDryRunPlan) DeepCopy() *ExtendedDaemonSetReplicaSetDryRunPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetReplicaSetDryRunPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedDaemonSetReplicaSetList) DeepCopyInto(out *ExtendedDaemonSetReplicaSetList) {
 	*out = *in
@@ -168,6 +203,21 @@ func (in *ExtendedDaemonSetReplicaSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetReplicaSetPlannedAction) DeepCopyInto(out *ExtendedDaemonSetReplicaSetPlannedAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetReplicaSetPlannedAction.
+func (in *ExtendedDaemonSetReplicaSetPlannedAction) DeepCopy() *ExtendedDaemonSetReplicaSetPlannedAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetReplicaSetPlannedAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedDaemonSetReplicaSetSpec) DeepCopyInto(out *ExtendedDaemonSetReplicaSetSpec) {
 	*out = *in
@@ -177,6 +227,11 @@ func (in *ExtendedDaemonSetReplicaSetSpec) DeepCopyInto(out *ExtendedDaemonSetRe
 		(*in).DeepCopyInto(*out)
 	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.NodeMetadata != nil {
+		in, out := &in.NodeMetadata, &out.NodeMetadata
+		*out = make([]ExtendedDaemonSetNodeMetadata, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetReplicaSetSpec.
@@ -216,6 +271,23 @@ func (in *ExtendedDaemonSetReplicaSetStatus) DeepCopyInto(out *ExtendedDaemonSet
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UnfitNodes != nil {
+		in, out := &in.UnfitNodes, &out.UnfitNodes
+		*out = make([]ExtendedDaemonSetReplicaSetUnfitNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRunPlan != nil {
+		in, out := &in.DryRunPlan, &out.DryRunPlan
+		*out = new(ExtendedDaemonSetReplicaSetDryRunPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollingUpdateBatch != nil {
+		in, out := &in.RollingUpdateBatch, &out.RollingUpdateBatch
+		*out = new(ExtendedDaemonSetStatusRollingUpdateBatch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetReplicaSetStatus.
@@ -228,6 +300,41 @@ func (in *ExtendedDaemonSetReplicaSetStatus) DeepCopy() *ExtendedDaemonSetReplic
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetReplicaSetUnfitNode) DeepCopyInto(out *ExtendedDaemonSetReplicaSetUnfitNode) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]ExtendedDaemonSetReplicaSetUnfitReason, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetReplicaSetUnfitNode.
+func (in *ExtendedDaemonSetReplicaSetUnfitNode) DeepCopy() *ExtendedDaemonSetReplicaSetUnfitNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetReplicaSetUnfitNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetReplicaSetUnfitReason) DeepCopyInto(out *ExtendedDaemonSetReplicaSetUnfitReason) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetReplicaSetUnfitReason.
+func (in *ExtendedDaemonSetReplicaSetUnfitReason) DeepCopy() *ExtendedDaemonSetReplicaSetUnfitReason {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetReplicaSetUnfitReason)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedDaemonSetSpec) DeepCopyInto(out *ExtendedDaemonSetSpec) {
 	*out = *in
@@ -237,7 +344,22 @@ func (in *ExtendedDaemonSetSpec) DeepCopyInto(out *ExtendedDaemonSetSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Template.DeepCopyInto(&out.Template)
+	if in.NodeMetadata != nil {
+		in, out := &in.NodeMetadata, &out.NodeMetadata
+		*out = make([]ExtendedDaemonSetNodeMetadata, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartupTaint != nil {
+		in, out := &in.StartupTaint, &out.StartupTaint
+		*out = new(ExtendedDaemonSetStartupTaint)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpec.
@@ -264,6 +386,11 @@ func (in *ExtendedDaemonSetSpecStrategy) DeepCopyInto(out *ExtendedDaemonSetSpec
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.UseEvictionAPI != nil {
+		in, out := &in.UseEvictionAPI, &out.UseEvictionAPI
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecStrategy.
@@ -314,6 +441,16 @@ func (in *ExtendedDaemonSetSpecStrategyCanary) DeepCopyInto(out *ExtendedDaemonS
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.NodeSelectionPolicy != nil {
+		in, out := &in.NodeSelectionPolicy, &out.NodeSelectionPolicy
+		*out = new(ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecStrategyCanary.
@@ -391,6 +528,51 @@ func (in *ExtendedDaemonSetSpecStrategyCanaryAutoPause) DeepCopy() *ExtendedDaem
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy) DeepCopyInto(out *ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy) {
+	*out = *in
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeNames != nil {
+		in, out := &in.NodeNames, &out.NodeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreferredLabels != nil {
+		in, out := &in.PreferredLabels, &out.PreferredLabels
+		*out = make([]ExtendedDaemonSetSpecStrategyCanaryPreferredLabel, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy.
+func (in *ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy) DeepCopy() *ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecStrategyCanaryPreferredLabel) DeepCopyInto(out *ExtendedDaemonSetSpecStrategyCanaryPreferredLabel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecStrategyCanaryPreferredLabel.
+func (in *ExtendedDaemonSetSpecStrategyCanaryPreferredLabel) DeepCopy() *ExtendedDaemonSetSpecStrategyCanaryPreferredLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecStrategyCanaryPreferredLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedDaemonSetSpecStrategyRollingUpdate) DeepCopyInto(out *ExtendedDaemonSetSpecStrategyRollingUpdate) {
 	*out = *in
@@ -409,6 +591,11 @@ func (in *ExtendedDaemonSetSpecStrategyRollingUpdate) DeepCopyInto(out *Extended
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxParallelNewNodePodCreation != nil {
+		in, out := &in.MaxParallelNewNodePodCreation, &out.MaxParallelNewNodePodCreation
+		*out = new(int32)
+		**out = **in
+	}
 	if in.SlowStartIntervalDuration != nil {
 		in, out := &in.SlowStartIntervalDuration, &out.SlowStartIntervalDuration
 		*out = new(v1.Duration)
@@ -419,6 +606,56 @@ func (in *ExtendedDaemonSetSpecStrategyRollingUpdate) DeepCopyInto(out *Extended
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.ManualBatchApproval != nil {
+		in, out := &in.ManualBatchApproval, &out.ManualBatchApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PauseAt != nil {
+		in, out := &in.PauseAt, &out.PauseAt
+		*out = make([]intstr.IntOrString, len(*in))
+		copy(*out, *in)
+	}
+	if in.PauseAtAutoResumeDuration != nil {
+		in, out := &in.PauseAtAutoResumeDuration, &out.PauseAtAutoResumeDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PrePullImages != nil {
+		in, out := &in.PrePullImages, &out.PrePullImages
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DoNotDisruptNodeAnnotationKeys != nil {
+		in, out := &in.DoNotDisruptNodeAnnotationKeys, &out.DoNotDisruptNodeAnnotationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DoNotDisruptNodeLabelKeys != nil {
+		in, out := &in.DoNotDisruptNodeLabelKeys, &out.DoNotDisruptNodeLabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxUnhealthyPods != nil {
+		in, out := &in.MaxUnhealthyPods, &out.MaxUnhealthyPods
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnhealthyPodRestarts != nil {
+		in, out := &in.MaxUnhealthyPodRestarts, &out.MaxUnhealthyPodRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailOnUnhealthyPods != nil {
+		in, out := &in.FailOnUnhealthyPods, &out.FailOnUnhealthyPods
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecStrategyRollingUpdate.
@@ -431,6 +668,26 @@ func (in *ExtendedDaemonSetSpecStrategyRollingUpdate) DeepCopy() *ExtendedDaemon
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStartupTaint) DeepCopyInto(out *ExtendedDaemonSetStartupTaint) {
+	*out = *in
+	if in.ReAddOnPodDeletion != nil {
+		in, out := &in.ReAddOnPodDeletion, &out.ReAddOnPodDeletion
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStartupTaint.
+func (in *ExtendedDaemonSetStartupTaint) DeepCopy() *ExtendedDaemonSetStartupTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStartupTaint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedDaemonSetStatus) DeepCopyInto(out *ExtendedDaemonSetStatus) {
 	*out = *in
@@ -439,6 +696,16 @@ func (in *ExtendedDaemonSetStatus) DeepCopyInto(out *ExtendedDaemonSetStatus) {
 		*out = new(ExtendedDaemonSetStatusCanary)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RollingUpdateBatch != nil {
+		in, out := &in.RollingUpdateBatch, &out.RollingUpdateBatch
+		*out = new(ExtendedDaemonSetStatusRollingUpdateBatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollingUpdateCheckpoint != nil {
+		in, out := &in.RollingUpdateCheckpoint, &out.RollingUpdateCheckpoint
+		*out = new(ExtendedDaemonSetStatusRollingUpdateCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ExtendedDaemonSetCondition, len(*in))
@@ -478,6 +745,43 @@ func (in *ExtendedDaemonSetStatusCanary) DeepCopy() *ExtendedDaemonSetStatusCana
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStatusRollingUpdateBatch) DeepCopyInto(out *ExtendedDaemonSetStatusRollingUpdateBatch) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStatusRollingUpdateBatch.
+func (in *ExtendedDaemonSetStatusRollingUpdateBatch) DeepCopy() *ExtendedDaemonSetStatusRollingUpdateBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStatusRollingUpdateBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStatusRollingUpdateCheckpoint) DeepCopyInto(out *ExtendedDaemonSetStatusRollingUpdateCheckpoint) {
+	*out = *in
+	out.PauseAt = in.PauseAt
+	in.PausedTime.DeepCopyInto(&out.PausedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStatusRollingUpdateCheckpoint.
+func (in *ExtendedDaemonSetStatusRollingUpdateCheckpoint) DeepCopy() *ExtendedDaemonSetStatusRollingUpdateCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStatusRollingUpdateCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedDaemonsetSetting) DeepCopyInto(out *ExtendedDaemonsetSetting) {
 	*out = *in
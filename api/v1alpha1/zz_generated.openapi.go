@@ -17,23 +17,33 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSet":                            schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSet(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSet":                  schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSet(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetSpec":              schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSpec(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetSpecStrategy":      schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSpecStrategy(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetStatus":            schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetStatus(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpec":                        schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpec(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategy":                schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanary":          schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanary(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail":  schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryAutoFail(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause": schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryAutoPause(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate":   schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyRollingUpdate(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatus":                      schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatus(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusCanary":                schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatusCanary(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSetting":                     schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSetting(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSettingContainerSpec":        schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSettingContainerSpec(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSettingSpec":                 schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSettingSpec(ref),
-		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSettingStatus":               schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSettingStatus(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSet":                                      schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSet(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetNodeMetadata":                          schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetNodeMetadata(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSet":                            schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSet(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetDryRunPlan":                  schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetDryRunPlan(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetPlannedAction":               schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetPlannedAction(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetSpec":                        schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSpec(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetSpecStrategy":                schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSpecStrategy(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetStatus":                      schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetStatus(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetUnfitNode":                   schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetUnfitNode(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetUnfitReason":                 schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetUnfitReason(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpec":                                  schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpec(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategy":                          schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanary":                    schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanary(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail":            schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryAutoFail(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause":           schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryAutoPause(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy": schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryPreferredLabel":      schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryPreferredLabel(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate":             schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyRollingUpdate(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStartupTaint":                          schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStartupTaint(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatus":                                schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatus(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusCanary":                          schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatusCanary(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch":              schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatusRollingUpdateBatch(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint":         schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatusRollingUpdateCheckpoint(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSetting":                               schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSetting(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSettingContainerSpec":                  schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSettingContainerSpec(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSettingSpec":                           schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSettingSpec(ref),
+		"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonsetSettingStatus":                         schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSettingStatus(ref),
 	}
 }
 
@@ -84,6 +94,54 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSet(ref common.
 	}
 }
 
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetNodeMetadata(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetNodeMetadata defines a node label or annotation copied into the pods, and its targets in the pods.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeLabel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeLabel is the key of the node label to copy. Only one of NodeLabel and NodeAnnotation can be set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeAnnotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeAnnotation is the key of the node annotation to copy. Only one of NodeLabel and NodeAnnotation can be set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podLabel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodLabel is the key of the pod label set with the node label or annotation value. It can't be a key of the selector or a key reserved by the controller (with an `extendeddaemonset*.datadoghq.com` prefix). The node values that are not valid label values are not copied.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podAnnotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodAnnotation is the key of the pod annotation set with the node label or annotation value. It can't be a key reserved by the controller (with an `extendeddaemonset*.datadoghq.com` prefix).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env is the name of the environment variable set with the node label or annotation value in all the pod containers.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSet(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -131,6 +189,102 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSet(r
 	}
 }
 
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetDryRunPlan(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetReplicaSetDryRunPlan describes the pods actions planned by the controller in dry-run mode.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"podsToCreate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodsToCreate is the number of pods the controller would have created.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"podsToDelete": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodsToDelete is the number of pods the controller would have deleted.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"actions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Actions contains a sample of the planned actions. The number of actions reported is bounded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetPlannedAction"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"podsToCreate", "podsToDelete"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetPlannedAction"},
+	}
+}
+
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetPlannedAction(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetReplicaSetPlannedAction describes a pod creation or deletion planned in dry-run mode.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the action: Create or Delete.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"node": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Node is the name of the node where the action applies.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pod is the name of the pod to delete.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is a human readable explanation of the action.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "node", "reason"},
+			},
+		},
+	}
+}
+
 func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -158,12 +312,31 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSp
 							Format:      "",
 						},
 					},
+					"nodeMetadata": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeMetadata lists the node labels and annotations copied into the pods. It is copied from the ExtendedDaemonSet spec, and is part of the TemplateGeneration hash.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetNodeMetadata"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"template"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PodTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetNodeMetadata", "k8s.io/api/core/v1.PodTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -200,6 +373,13 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSt
 				Description: "ExtendedDaemonSetReplicaSetStatus defines the observed state of ExtendedDaemonSetReplicaSet",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation observed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
@@ -242,6 +422,55 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSt
 							Format:  "int32",
 						},
 					},
+					"numberMisscheduled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberMisscheduled is the number of nodes that are running the daemon pod, but don't fit the replicaset pod template.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"updatedNumberScheduled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdatedNumberScheduled is the number of nodes that are running a scheduled pod created from the replicaset pod template.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"numberUnavailable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberUnavailable is the number of nodes that should be running the replicaset pod and have none of the pod running and available.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"numberPrePulled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberPrePulled is the number of nodes running an outdated pod where the images of the replicaset pod template are already pulled. It is only set when the rolling update pre-pulls the images.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"numberDraining": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberDraining is the number of nodes running an outdated pod that was asked to drain and is not drained yet. It is only set when the rolling update drains the pods.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"numberDeferred": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberDeferred is the number of nodes running an outdated pod whose update is deferred because the node is annotated or labelled as do-not-disrupt.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"newNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NewNodes is the number of nodes without pod that joined the cluster after the creation of the replicaset. It is only set when the rolling update creates their pods outside of the slow start limits, with maxParallelNewNodePodCreation.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"conditions": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -264,118 +493,291 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetSt
 							},
 						},
 					},
+					"unfitNodes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"node",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "UnfitNodes contains a sample of the nodes that don't fit the pod template, with the reasons returned by the scheduling predicates. The number of nodes reported is bounded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetUnfitNode"),
+									},
+								},
+							},
+						},
+					},
+					"dryRunPlan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRunPlan contains the pods creations and deletions that the controller would have done during the last reconcile. It is only set when the ExtendedDaemonSet is in dry-run mode.",
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetDryRunPlan"),
+						},
+					},
+					"rollingUpdateBatch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RollingUpdateBatch contains the current batch of the rolling update when the manual batch approval is enabled.",
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch"),
+						},
+					},
 				},
 				Required: []string{"status", "desired", "current", "ready", "available", "ignoredUnresponsiveNodes"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetCondition"},
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetCondition", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetDryRunPlan", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetUnfitNode", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch"},
 	}
 }
 
-func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetUnfitNode(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ExtendedDaemonSetSpec defines the desired state of ExtendedDaemonSet",
+				Description: "ExtendedDaemonSetReplicaSetUnfitNode describes why the ExtendedDaemonSetReplicaSet pod can't be scheduled on a node.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"selector": {
+					"node": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A label query over pods that are managed by the daemon set. Must match in order to be controlled. If empty, defaulted to labels on Pod template. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							Description: "Node is the name of the node.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"template": {
-						SchemaProps: spec.SchemaProps{
-							Description: "An object that describes the pod that will be created. The ExtendedDaemonSet will create exactly one copy of this pod on every node that matches the template's node selector (or on every node if no node selector is specified). More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicationcontroller#pod-template",
-							Default:     map[string]interface{}{},
-							Ref:         ref("k8s.io/api/core/v1.PodTemplateSpec"),
+					"reasons": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
 						},
-					},
-					"strategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Daemonset deployment strategy.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategy"),
+							Description: "Reasons contains the failures returned by the scheduling predicates.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetUnfitReason"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"template", "strategy"},
+				Required: []string{"node"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategy", "k8s.io/api/core/v1.PodTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetReplicaSetUnfitReason"},
 	}
 }
 
-func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetReplicaSetUnfitReason(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ExtendedDaemonSetSpecStrategy defines the deployment strategy of ExtendedDaemonSet.",
+				Description: "ExtendedDaemonSetReplicaSetUnfitReason describes a scheduling predicate failure.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"rollingUpdate": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate"),
-						},
-					},
-					"canary": {
+					"predicate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Canary deployment configuration",
-							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanary"),
+							Description: "Predicate is the name of the predicate that rejected the node: NodeSelector, NodeAffinity or PodToleratesNodeTaints.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"reconcileFrequency": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ReconcileFrequency use to configure how often the ExtendedDeamonset will be fully reconcile, default is 10sec.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+							Description: "Message is a human readable explanation of the failure: which selector term, which affinity requirement or which taint failed.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"predicate", "message"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanary", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ExtendedDaemonSetSpecStrategyCanary defines the canary deployment strategy of ExtendedDaemonSet.",
+				Description: "ExtendedDaemonSetSpec defines the desired state of ExtendedDaemonSet",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"replicas": {
+					"selector": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+							Description: "A label query over pods that are managed by the daemon set. Must match in order to be controlled. If empty, defaulted to labels on Pod template. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
-					"duration": {
+					"template": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+							Description: "An object that describes the pod that will be created. The ExtendedDaemonSet will create exactly one copy of this pod on every node that matches the template's node selector (or on every node if no node selector is specified). More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicationcontroller#pod-template",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.PodTemplateSpec"),
 						},
 					},
-					"nodeSelector": {
+					"minReadySeconds": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							Description: "The minimum number of seconds for which a newly created pod should be ready without any of its container crashing, for it to be considered available. It is used by the canary and rolling update strategies. Defaults to 0 (pod will be considered available as soon as it is ready).",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"nodeAntiAffinityKeys": {
+					"nodeMetadata": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "set",
+								"x-kubernetes-list-type": "atomic",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "NodeMetadata lists the node labels and annotations copied into the pods, as pod labels, pod annotations or container environment variables, when the pods are created. A change of a copied node label or annotation value triggers the replacement of the pod running on the node.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetNodeMetadata"),
+									},
+								},
+							},
+						},
+					},
+					"startupTaint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartupTaint defines a node taint that the controller removes from a node once the ExtendedDaemonSet pod running on this node is ready. It allows to keep the workloads away from a node until the daemon is running.",
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStartupTaint"),
+						},
+					},
+					"strategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Daemonset deployment strategy.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategy"),
+						},
+					},
+					"dependsOn": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn is the list of the ExtendedDaemonSets, in the same namespace, that need to have completed their rollout before a new template of this ExtendedDaemonSet starts to be deployed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"template", "strategy"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetNodeMetadata", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategy", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStartupTaint", "k8s.io/api/core/v1.PodTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetSpecStrategy defines the deployment strategy of ExtendedDaemonSet.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of update strategy. Can be \"RollingUpdate\" (default) or \"OnDelete\". With \"OnDelete\", pods using an outdated template are only replaced once they are deleted externally.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rollingUpdate": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate"),
+						},
+					},
+					"canary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Canary deployment configuration",
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanary"),
+						},
+					},
+					"reconcileFrequency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReconcileFrequency use to configure how often the ExtendedDeamonset will be fully reconcile, default is 10sec.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"useEvictionAPI": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UseEvictionAPI if true, the pods are evicted with the Eviction API instead of being deleted, to respect the PodDisruptionBudgets. An eviction refused by a PodDisruptionBudget is retried later. Default value is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanary", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetSpecStrategyCanary defines the canary deployment strategy of ExtendedDaemonSet.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"nodeAntiAffinityKeys": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
 										Type:    []string{"string"},
 										Format:  "",
 									},
@@ -406,11 +808,24 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy
 							Format:      "",
 						},
 					},
+					"nodeSelectionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelectionPolicy defines how the canary nodes are selected. Default policy is 'leastRestarts'.",
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy"),
+						},
+					},
+					"autoRollback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutoRollback restores the ExtendedDaemonSet pod template and node metadata to the ones of the active replicaset when the canary deployment fails. The failed template is not deployed again. Default value is true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
@@ -490,6 +905,111 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy
 	}
 }
 
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy defines how the canary nodes are selected.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the node selection policy. Possible values are 'leastRestarts' (default), 'random', 'oldestNodes', 'newestNodes', 'explicit' and 'preferLabel'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"seed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Seed used to shuffle the nodes with the 'random' policy, to get a reproducible selection. A random seed is used if not set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"nodeNames": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeNames the nodes to select, by order of preference, with the 'explicit' policy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"preferredLabels": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "PreferredLabels the weighted node labels used with the 'preferLabel' policy: the nodes with the highest sum of weights are selected first.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryPreferredLabel"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetSpecStrategyCanaryPreferredLabel"},
+	}
+}
+
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyCanaryPreferredLabel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetSpecStrategyCanaryPreferredLabel defines a weighted node label of the 'preferLabel' canary node selection policy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key of the node label.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value of the node label. Any value matches if not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"weight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Weight added to the nodes with this label.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"key", "weight"},
+			},
+		},
+	}
+}
+
 func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategyRollingUpdate(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -516,6 +1036,13 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy
 							Format:      "int32",
 						},
 					},
+					"maxParallelNewNodePodCreation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxParallelNewNodePodCreation if set, the pods of the nodes that joined the cluster after the creation of the replicaset are created outside of the slow start and MaxParallelPodCreation limits, up to this number of pods in parallel. Those nodes never ran an outdated pod, so there is no need to throttle their pods creation.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"slowStartIntervalDuration": {
 						SchemaProps: spec.SchemaProps{
 							Description: "SlowStartIntervalDuration the duration between to 2 Default value is 1min.",
@@ -528,6 +1055,110 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy
 							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
 						},
 					},
+					"manualBatchApproval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManualBatchApproval if true, the pods are updated by batches of MaxUnavailable pods, and the rolling update is paused after each batch until the next one is approved with `kubectl eds rollout next`. Default value is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"pauseAt": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "PauseAt defines checkpoints at which the rolling update is automatically paused, once the number of nodes running the new template reaches them. Values can be absolute numbers (ex: 5) or percentages of the total number of DaemonSet pods (ex: 10%).",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+									},
+								},
+							},
+						},
+					},
+					"pauseAtAutoResumeDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PauseAtAutoResumeDuration if set, a rolling update paused at a PauseAt checkpoint is automatically resumed after this duration, if no pod from the new template restarted in the meantime.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"prePullImages": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrePullImages if true, the images of the new pod template are pulled on a node, with a pre-pull pod, before the old pod running on this node is deleted. Default value is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"drainTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DrainTimeout if set, the outdated pods are asked to drain before being deleted: the controller adds the `extendeddaemonset.datadoghq.com/drain-requested` annotation on the pod, and waits until the pod sets the `extendeddaemonset.datadoghq.com/drain-complete` annotation or until the timeout expires. The draining pods are counted against maxUnavailable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"doNotDisruptNodeAnnotationKeys": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DoNotDisruptNodeAnnotationKeys the rolling update doesn't delete the outdated pods running on the nodes with one of these annotations (ex: karpenter.sh/do-not-disrupt), unless its value is \"false\". The pods are updated once the annotation disappears.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"doNotDisruptNodeLabelKeys": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DoNotDisruptNodeLabelKeys the rolling update doesn't delete the outdated pods running on the nodes with one of these labels, unless its value is \"false\". The pods are updated once the label disappears.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"maxUnhealthyPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxUnhealthyPods if set, enables the health gate of the rolling update: the rolling update is automatically paused once more than this number of the up-to-date pods are unhealthy, because they cannot start (ex: CrashLoopBackOff) or restarted more than MaxUnhealthyPodRestarts times. Value can be an absolute number (ex: 5) or a percentage of the up-to-date pods (ex: 5%). Absolute number is calculated from percentage by rounding down. The rolling update stays paused until it is resumed manually.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"maxUnhealthyPodRestarts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxUnhealthyPodRestarts the number of restarts above which an up-to-date pod is unhealthy for the health gate. Default value is 5 when MaxUnhealthyPods is set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"failOnUnhealthyPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailOnUnhealthyPods if true, the rolling update is failed instead of paused when the health gate is reached: the pods of the replicaset are neither created nor deleted anymore, until the pod template is updated. Default value is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -536,6 +1167,42 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetSpecStrategy
 	}
 }
 
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStartupTaint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetStartupTaint defines the node taint removed once the ExtendedDaemonSet pod running on the node is ready.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the key of the taint removed from the node. The pod template needs to tolerate this taint.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"effect": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Effect is the effect of the taint added back on the node when ReAddOnPodDeletion is true. Default value is NoSchedule.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reAddOnPodDeletion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReAddOnPodDeletion if true, the taint is added back on the node when its ExtendedDaemonSet pod is deleted. Default value is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+	}
+}
+
 func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -543,6 +1210,13 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatus(ref c
 				Description: "ExtendedDaemonSetStatus defines the observed state of ExtendedDaemonSet",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation observed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"desired": {
 						SchemaProps: spec.SchemaProps{
 							Default: 0,
@@ -585,6 +1259,34 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatus(ref c
 							Format:  "int32",
 						},
 					},
+					"numberMisscheduled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberMisscheduled is the number of nodes that are running the daemon pod, but are not supposed to run it.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"updatedNumberScheduled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdatedNumberScheduled is the number of nodes that are running the up-to-date daemon pod.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"numberUnavailable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumberUnavailable is the number of nodes that should be running the daemon pod and have none of the daemon pod running and available.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"collisionCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CollisionCount is the number of extra ExtendedDaemonSetReplicaSets whose pod template hash collides with the up-to-date one.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"state": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -603,6 +1305,25 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatus(ref c
 							Ref: ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusCanary"),
 						},
 					},
+					"rollingUpdateBatch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RollingUpdateBatch contains the current batch of the rolling update when the manual batch approval is enabled.",
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch"),
+						},
+					},
+					"rollingUpdateCheckpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RollingUpdateCheckpoint contains the last rollingUpdate.pauseAt checkpoint reached by the rolling update.",
+							Ref:         ref("github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint"),
+						},
+					},
+					"failedTemplateHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedTemplateHash is the hash of the last pod template rolled back after a canary deployment failure.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"reason": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Reason provides an explanation for canary deployment autopause",
@@ -637,7 +1358,7 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatus(ref c
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetCondition", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusCanary"},
+			"github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetCondition", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusCanary", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch", "github.com/DataDog/extendeddaemonset/api/v1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint"},
 	}
 }
 
@@ -681,6 +1402,98 @@ func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatusCanary
 	}
 }
 
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatusRollingUpdateBatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetStatusRollingUpdateBatch defines the observed state of a rolling update with manual batch approval.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"number": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of the current batch, starting at 1.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"nodes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes on which the pods are updated during the current batch.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"completed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Completed is true when all the pods of the current batch are up-to-date and available: the rolling update is paused until the next batch is approved.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"number"},
+			},
+		},
+	}
+}
+
+func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonSetStatusRollingUpdateCheckpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExtendedDaemonSetStatusRollingUpdateCheckpoint defines the last rollingUpdate.pauseAt checkpoint reached by the rolling update.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicaSet": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicaSet is the name of the replicaset whose rolling update reached the checkpoint.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pauseAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PauseAt is the checkpoint reached.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"pausedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PausedTime is the time at which the rolling update was paused at the checkpoint.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"resumed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resumed is true once the rolling update has been resumed after the checkpoint, automatically or not.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"replicaSet", "pauseAt", "pausedTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+	}
+}
+
 func schema_DataDog_extendeddaemonset_api_v1alpha1_ExtendedDaemonsetSetting(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
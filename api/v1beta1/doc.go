@@ -0,0 +1,9 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// The clientset, listers and informers generators only read the package tags from the doc.go file.
+// +groupName=datadoghq.com
+
+package v1beta1
@@ -0,0 +1,293 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// ConvertTo converts this ExtendedDaemonSet to the Hub version (v1alpha1).
+// The rollout control fields are converted to the annotations the controller reads.
+func (src *ExtendedDaemonSet) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.ExtendedDaemonSet)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type: %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec.Selector = src.Spec.Selector.DeepCopy()
+	dst.Spec.Template = *src.Spec.Template.DeepCopy()
+	dst.Spec.MinReadySeconds = src.Spec.MinReadySeconds
+	dst.Spec.DependsOn = src.Spec.DependsOn
+	dst.Spec.NodeMetadata = convertNodeMetadataToHub(src.Spec.NodeMetadata)
+	dst.Spec.StartupTaint = (*v1alpha1.ExtendedDaemonSetStartupTaint)(src.Spec.StartupTaint.DeepCopy())
+	dst.Spec.Strategy = v1alpha1.ExtendedDaemonSetSpecStrategy{
+		Type:               v1alpha1.ExtendedDaemonSetSpecStrategyType(src.Spec.Strategy.Type),
+		RollingUpdate:      v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate(*src.Spec.Strategy.RollingUpdate.DeepCopy()),
+		ReconcileFrequency: src.Spec.Strategy.ReconcileFrequency.DeepCopy(),
+		UseEvictionAPI:     src.Spec.Strategy.UseEvictionAPI,
+	}
+	if src.Spec.Canary != nil {
+		dst.Spec.Strategy.Canary = convertCanaryToHub(src.Spec.Canary)
+	}
+	dst.Status = convertStatusToHub(&src.Status)
+
+	annotations := map[string]string{}
+	if src.Spec.Paused {
+		annotations[pausedAnnotationKey(src.Status.Canary != nil)] = v1alpha1.ValueStringTrue
+	}
+	if src.Spec.Canary != nil && src.Spec.Canary.Validated != "" {
+		annotations[v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey] = src.Spec.Canary.Validated
+	}
+	if src.Spec.MigrateFrom != "" {
+		annotations[v1alpha1.ExtendedDaemonSetOldDaemonsetAnnotationKey] = src.Spec.MigrateFrom
+	}
+	if len(annotations) > 0 && dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	for key, value := range annotations {
+		dst.Annotations[key] = value
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+// The rollout control annotations are promoted to spec fields and removed from the metadata.
+func (dst *ExtendedDaemonSet) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.ExtendedDaemonSet)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type: %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = ExtendedDaemonSetSpec{
+		Selector:        src.Spec.Selector.DeepCopy(),
+		Template:        *src.Spec.Template.DeepCopy(),
+		MinReadySeconds: src.Spec.MinReadySeconds,
+		DependsOn:       src.Spec.DependsOn,
+		NodeMetadata:    convertNodeMetadataFromHub(src.Spec.NodeMetadata),
+		StartupTaint:    (*ExtendedDaemonSetStartupTaint)(src.Spec.StartupTaint.DeepCopy()),
+		Strategy: ExtendedDaemonSetSpecStrategy{
+			Type:               ExtendedDaemonSetSpecStrategyType(src.Spec.Strategy.Type),
+			RollingUpdate:      ExtendedDaemonSetSpecStrategyRollingUpdate(*src.Spec.Strategy.RollingUpdate.DeepCopy()),
+			ReconcileFrequency: src.Spec.Strategy.ReconcileFrequency.DeepCopy(),
+			UseEvictionAPI:     src.Spec.Strategy.UseEvictionAPI,
+		},
+	}
+	if src.Spec.Strategy.Canary != nil {
+		dst.Spec.Canary = convertCanaryFromHub(src.Spec.Strategy.Canary)
+	}
+	dst.Status = convertStatusFromHub(&src.Status)
+
+	// Only the pause annotation of the current rollout phase is promoted: the other one
+	// is ignored by the controller, and is kept as an annotation to not lose it.
+	key := pausedAnnotationKey(src.Status.Canary != nil)
+	if dst.Annotations[key] == v1alpha1.ValueStringTrue {
+		dst.Spec.Paused = true
+		delete(dst.Annotations, key)
+	}
+	if value, found := dst.Annotations[v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey]; found && dst.Spec.Canary != nil {
+		dst.Spec.Canary.Validated = value
+		delete(dst.Annotations, v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey)
+	}
+	if value, found := dst.Annotations[v1alpha1.ExtendedDaemonSetOldDaemonsetAnnotationKey]; found {
+		dst.Spec.MigrateFrom = value
+		delete(dst.Annotations, v1alpha1.ExtendedDaemonSetOldDaemonsetAnnotationKey)
+	}
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	return nil
+}
+
+func pausedAnnotationKey(canaryActive bool) string {
+	if canaryActive {
+		return v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey
+	}
+
+	return v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey
+}
+
+func convertNodeMetadataToHub(src []ExtendedDaemonSetNodeMetadata) []v1alpha1.ExtendedDaemonSetNodeMetadata {
+	if src == nil {
+		return nil
+	}
+	out := make([]v1alpha1.ExtendedDaemonSetNodeMetadata, 0, len(src))
+	for _, item := range src {
+		out = append(out, v1alpha1.ExtendedDaemonSetNodeMetadata(item))
+	}
+
+	return out
+}
+
+func convertNodeMetadataFromHub(src []v1alpha1.ExtendedDaemonSetNodeMetadata) []ExtendedDaemonSetNodeMetadata {
+	if src == nil {
+		return nil
+	}
+	out := make([]ExtendedDaemonSetNodeMetadata, 0, len(src))
+	for _, item := range src {
+		out = append(out, ExtendedDaemonSetNodeMetadata(item))
+	}
+
+	return out
+}
+
+func convertCanaryToHub(src *ExtendedDaemonSetSpecCanary) *v1alpha1.ExtendedDaemonSetSpecStrategyCanary {
+	in := src.DeepCopy()
+	out := &v1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+		Replicas:             in.Replicas,
+		Duration:             in.Duration,
+		NodeSelector:         in.NodeSelector,
+		NodeAntiAffinityKeys: in.NodeAntiAffinityKeys,
+		NoRestartsDuration:   in.NoRestartsDuration,
+		ValidationMode:       v1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationMode(in.ValidationMode),
+		AutoRollback:         in.AutoRollback,
+	}
+	if in.AutoPause != nil {
+		out.AutoPause = (*v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause)(in.AutoPause)
+	}
+	if in.AutoFail != nil {
+		out.AutoFail = (*v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail)(in.AutoFail)
+	}
+	if policy := in.NodeSelectionPolicy; policy != nil {
+		out.NodeSelectionPolicy = &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+			Type:      v1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyType(policy.Type),
+			Seed:      policy.Seed,
+			NodeNames: policy.NodeNames,
+		}
+		for _, label := range policy.PreferredLabels {
+			out.NodeSelectionPolicy.PreferredLabels = append(out.NodeSelectionPolicy.PreferredLabels, v1alpha1.ExtendedDaemonSetSpecStrategyCanaryPreferredLabel(label))
+		}
+	}
+
+	return out
+}
+
+func convertCanaryFromHub(src *v1alpha1.ExtendedDaemonSetSpecStrategyCanary) *ExtendedDaemonSetSpecCanary {
+	in := src.DeepCopy()
+	out := &ExtendedDaemonSetSpecCanary{
+		Replicas:             in.Replicas,
+		Duration:             in.Duration,
+		NodeSelector:         in.NodeSelector,
+		NodeAntiAffinityKeys: in.NodeAntiAffinityKeys,
+		NoRestartsDuration:   in.NoRestartsDuration,
+		ValidationMode:       ExtendedDaemonSetSpecCanaryValidationMode(in.ValidationMode),
+		AutoRollback:         in.AutoRollback,
+	}
+	if in.AutoPause != nil {
+		out.AutoPause = (*ExtendedDaemonSetSpecCanaryAutoPause)(in.AutoPause)
+	}
+	if in.AutoFail != nil {
+		out.AutoFail = (*ExtendedDaemonSetSpecCanaryAutoFail)(in.AutoFail)
+	}
+	if policy := in.NodeSelectionPolicy; policy != nil {
+		out.NodeSelectionPolicy = &ExtendedDaemonSetSpecCanaryNodeSelectionPolicy{
+			Type:      ExtendedDaemonSetSpecCanaryNodeSelectionPolicyType(policy.Type),
+			Seed:      policy.Seed,
+			NodeNames: policy.NodeNames,
+		}
+		for _, label := range policy.PreferredLabels {
+			out.NodeSelectionPolicy.PreferredLabels = append(out.NodeSelectionPolicy.PreferredLabels, ExtendedDaemonSetSpecCanaryPreferredLabel(label))
+		}
+	}
+
+	return out
+}
+
+// convertStatusToHub converts the status to v1alpha1. The conditions LastUpdateTime, that doesn't exist
+// in metav1.Condition, is set to the LastTransitionTime. The status is owned by the controller, which
+// only writes it with the v1alpha1 version, so this approximation is never persisted.
+func convertStatusToHub(src *ExtendedDaemonSetStatus) v1alpha1.ExtendedDaemonSetStatus {
+	in := src.DeepCopy()
+	out := v1alpha1.ExtendedDaemonSetStatus{
+		ObservedGeneration:       in.ObservedGeneration,
+		Desired:                  in.Desired,
+		Current:                  in.Current,
+		Ready:                    in.Ready,
+		Available:                in.Available,
+		UpToDate:                 in.UpToDate,
+		IgnoredUnresponsiveNodes: in.IgnoredUnresponsiveNodes,
+		NumberMisscheduled:       in.NumberMisscheduled,
+		UpdatedNumberScheduled:   in.UpdatedNumberScheduled,
+		NumberUnavailable:        in.NumberUnavailable,
+		CollisionCount:           in.CollisionCount,
+		State:                    v1alpha1.ExtendedDaemonSetStatusState(in.State),
+		ActiveReplicaSet:         in.ActiveReplicaSet,
+		Reason:                   v1alpha1.ExtendedDaemonSetStatusReason(in.Reason),
+		FailedTemplateHash:       in.FailedTemplateHash,
+	}
+	if in.Canary != nil {
+		out.Canary = (*v1alpha1.ExtendedDaemonSetStatusCanary)(in.Canary)
+	}
+	if in.RollingUpdateBatch != nil {
+		out.RollingUpdateBatch = (*v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch)(in.RollingUpdateBatch)
+	}
+	if in.RollingUpdateCheckpoint != nil {
+		out.RollingUpdateCheckpoint = (*v1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint)(in.RollingUpdateCheckpoint)
+	}
+	for _, cond := range in.Conditions {
+		out.Conditions = append(out.Conditions, v1alpha1.ExtendedDaemonSetCondition{
+			Type:               v1alpha1.ExtendedDaemonSetConditionType(cond.Type),
+			Status:             corev1.ConditionStatus(cond.Status),
+			LastTransitionTime: cond.LastTransitionTime,
+			LastUpdateTime:     cond.LastTransitionTime,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+		})
+	}
+
+	return out
+}
+
+func convertStatusFromHub(src *v1alpha1.ExtendedDaemonSetStatus) ExtendedDaemonSetStatus {
+	in := src.DeepCopy()
+	out := ExtendedDaemonSetStatus{
+		ObservedGeneration:       in.ObservedGeneration,
+		Desired:                  in.Desired,
+		Current:                  in.Current,
+		Ready:                    in.Ready,
+		Available:                in.Available,
+		UpToDate:                 in.UpToDate,
+		IgnoredUnresponsiveNodes: in.IgnoredUnresponsiveNodes,
+		NumberMisscheduled:       in.NumberMisscheduled,
+		UpdatedNumberScheduled:   in.UpdatedNumberScheduled,
+		NumberUnavailable:        in.NumberUnavailable,
+		CollisionCount:           in.CollisionCount,
+		State:                    ExtendedDaemonSetStatusState(in.State),
+		ActiveReplicaSet:         in.ActiveReplicaSet,
+		Reason:                   ExtendedDaemonSetStatusReason(in.Reason),
+		FailedTemplateHash:       in.FailedTemplateHash,
+	}
+	if in.Canary != nil {
+		out.Canary = (*ExtendedDaemonSetStatusCanary)(in.Canary)
+	}
+	if in.RollingUpdateBatch != nil {
+		out.RollingUpdateBatch = (*ExtendedDaemonSetStatusRollingUpdateBatch)(in.RollingUpdateBatch)
+	}
+	if in.RollingUpdateCheckpoint != nil {
+		out.RollingUpdateCheckpoint = (*ExtendedDaemonSetStatusRollingUpdateCheckpoint)(in.RollingUpdateCheckpoint)
+	}
+	for _, cond := range in.Conditions {
+		out.Conditions = append(out.Conditions, metav1.Condition{
+			Type:               string(cond.Type),
+			Status:             metav1.ConditionStatus(cond.Status),
+			ObservedGeneration: in.ObservedGeneration,
+			LastTransitionTime: cond.LastTransitionTime,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,256 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+var (
+	transitionTime = metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	maxUnavailable = intstr.FromString("10%")
+	canaryReplicas = intstr.FromInt(3)
+	enabled        = true
+	maxRestarts    = int32(2)
+)
+
+func newHub(annotations map[string]string, canaryStatus *v1alpha1.ExtendedDaemonSetStatusCanary) *v1alpha1.ExtendedDaemonSet {
+	return &v1alpha1.ExtendedDaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo", Annotations: annotations, Generation: 3},
+		Spec: v1alpha1.ExtendedDaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "foo:v2"}}},
+			},
+			DependsOn: []string{"bar"},
+			NodeMetadata: []v1alpha1.ExtendedDaemonSetNodeMetadata{
+				{NodeLabel: "topology.kubernetes.io/zone", Env: "DD_NODE_ZONE"},
+			},
+			StartupTaint: &v1alpha1.ExtendedDaemonSetStartupTaint{Key: "example.com/agent-not-ready", ReAddOnPodDeletion: &enabled},
+			Strategy: v1alpha1.ExtendedDaemonSetSpecStrategy{
+				RollingUpdate:  v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{MaxUnavailable: &maxUnavailable},
+				UseEvictionAPI: &enabled,
+				Canary: &v1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+					Replicas:             &canaryReplicas,
+					Duration:             &metav1.Duration{Duration: 10 * time.Minute},
+					NodeAntiAffinityKeys: []string{"zone"},
+					AutoPause:            &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause{Enabled: &enabled, MaxRestarts: &maxRestarts},
+					AutoFail:             &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail{Enabled: &enabled},
+					ValidationMode:       v1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationModeManual,
+					AutoRollback:         &enabled,
+					NodeSelectionPolicy: &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+						Type:            v1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel,
+						PreferredLabels: []v1alpha1.ExtendedDaemonSetSpecStrategyCanaryPreferredLabel{{Key: "example.com/canary", Weight: 10}},
+					},
+				},
+			},
+		},
+		Status: v1alpha1.ExtendedDaemonSetStatus{
+			Desired:            10,
+			Current:            10,
+			State:              v1alpha1.ExtendedDaemonSetStatusStateCanaryPaused,
+			ActiveReplicaSet:   "foo-1",
+			Canary:             canaryStatus,
+			Reason:             v1alpha1.ExtendedDaemonSetStatusReasonCLB,
+			FailedTemplateHash: "failed-hash",
+			Conditions: []v1alpha1.ExtendedDaemonSetCondition{
+				{
+					Type:               v1alpha1.ConditionTypeEDSCanaryPaused,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					LastUpdateTime:     transitionTime,
+					Reason:             string(v1alpha1.ExtendedDaemonSetStatusReasonCLB),
+					Message:            "canary paused",
+				},
+			},
+		},
+	}
+}
+
+func TestExtendedDaemonSetConvertFrom(t *testing.T) {
+	canaryStatus := &v1alpha1.ExtendedDaemonSetStatusCanary{ReplicaSet: "foo-2", Nodes: []string{"node1"}}
+
+	tests := []struct {
+		name            string
+		hub             *v1alpha1.ExtendedDaemonSet
+		wantPaused      bool
+		wantValidated   string
+		wantMigrateFrom string
+		wantAnnotations map[string]string
+	}{
+		{
+			name: "no rollout annotations",
+			hub:  newHub(map[string]string{"foo": "bar"}, nil),
+			wantAnnotations: map[string]string{
+				"foo": "bar",
+			},
+		},
+		{
+			name: "canary paused and validated",
+			hub: newHub(map[string]string{
+				v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey: v1alpha1.ValueStringTrue,
+				v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey:  "foo-2",
+			}, canaryStatus),
+			wantPaused:    true,
+			wantValidated: "foo-2",
+		},
+		{
+			name: "rolling update paused",
+			hub: newHub(map[string]string{
+				v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: v1alpha1.ValueStringTrue,
+			}, nil),
+			wantPaused: true,
+		},
+		{
+			name: "rolling update pause annotation ignored during the canary",
+			hub: newHub(map[string]string{
+				v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: v1alpha1.ValueStringTrue,
+			}, canaryStatus),
+			wantAnnotations: map[string]string{
+				v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: v1alpha1.ValueStringTrue,
+			},
+		},
+		{
+			name: "canary resumed",
+			hub: newHub(map[string]string{
+				v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey:   v1alpha1.ValueStringFalse,
+				v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey: v1alpha1.ValueStringTrue,
+			}, canaryStatus),
+			wantAnnotations: map[string]string{
+				v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey:   v1alpha1.ValueStringFalse,
+				v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey: v1alpha1.ValueStringTrue,
+			},
+		},
+		{
+			name: "migration from a daemonset",
+			hub: newHub(map[string]string{
+				v1alpha1.ExtendedDaemonSetOldDaemonsetAnnotationKey: "foo-ds",
+			}, nil),
+			wantMigrateFrom: "foo-ds",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &ExtendedDaemonSet{}
+			require.NoError(t, got.ConvertFrom(tt.hub))
+
+			assert.Equal(t, tt.wantPaused, got.Spec.Paused)
+			require.NotNil(t, got.Spec.Canary)
+			assert.Equal(t, tt.wantValidated, got.Spec.Canary.Validated)
+			assert.Equal(t, tt.wantMigrateFrom, got.Spec.MigrateFrom)
+			assert.Equal(t, []string{"bar"}, got.Spec.DependsOn)
+			assert.Equal(t, tt.wantAnnotations, got.Annotations)
+			assert.Equal(t, &maxUnavailable, got.Spec.Strategy.RollingUpdate.MaxUnavailable)
+			assert.Equal(t, ExtendedDaemonSetSpecCanaryValidationModeManual, got.Spec.Canary.ValidationMode)
+			require.Len(t, got.Status.Conditions, 1)
+			assert.Equal(t, metav1.Condition{
+				Type:               string(v1alpha1.ConditionTypeEDSCanaryPaused),
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Reason:             string(v1alpha1.ExtendedDaemonSetStatusReasonCLB),
+				Message:            "canary paused",
+			}, got.Status.Conditions[0])
+
+			// round trip back to the hub version
+			hub := &v1alpha1.ExtendedDaemonSet{}
+			require.NoError(t, got.ConvertTo(hub))
+			assert.True(t, apiequality.Semantic.DeepEqual(tt.hub, hub), "round trip mismatch:\nwant: %#v\ngot:  %#v", tt.hub, hub)
+		})
+	}
+}
+
+func TestExtendedDaemonSetConvertTo(t *testing.T) {
+	newSpoke := func(spec ExtendedDaemonSetSpec, canaryStatus *ExtendedDaemonSetStatusCanary) *ExtendedDaemonSet {
+		spec.Template = corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "foo:v2"}}},
+		}
+
+		return &ExtendedDaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo", Annotations: map[string]string{"foo": "bar"}},
+			Spec:       spec,
+			Status: ExtendedDaemonSetStatus{
+				ActiveReplicaSet: "foo-1",
+				Canary:           canaryStatus,
+				Conditions: []metav1.Condition{
+					{Type: "Canary-Failed", Status: metav1.ConditionFalse, LastTransitionTime: transitionTime, Reason: "Manual"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		spoke           *ExtendedDaemonSet
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "no rollout fields",
+			spoke:           newSpoke(ExtendedDaemonSetSpec{}, nil),
+			wantAnnotations: map[string]string{"foo": "bar"},
+		},
+		{
+			name: "paused canary",
+			spoke: newSpoke(ExtendedDaemonSetSpec{
+				Paused: true,
+				Canary: &ExtendedDaemonSetSpecCanary{Replicas: &canaryReplicas, Validated: "foo-2"},
+			}, &ExtendedDaemonSetStatusCanary{ReplicaSet: "foo-2"}),
+			wantAnnotations: map[string]string{
+				"foo": "bar",
+				v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey: v1alpha1.ValueStringTrue,
+				v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey:  "foo-2",
+			},
+		},
+		{
+			name: "paused rolling update and migration",
+			spoke: newSpoke(ExtendedDaemonSetSpec{
+				Paused:      true,
+				MigrateFrom: "foo-ds",
+			}, nil),
+			wantAnnotations: map[string]string{
+				"foo": "bar",
+				v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: v1alpha1.ValueStringTrue,
+				v1alpha1.ExtendedDaemonSetOldDaemonsetAnnotationKey:        "foo-ds",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &v1alpha1.ExtendedDaemonSet{}
+			require.NoError(t, tt.spoke.ConvertTo(hub))
+			assert.Equal(t, tt.wantAnnotations, hub.Annotations)
+			assert.Equal(t, tt.spoke.Spec.Canary != nil, hub.Spec.Strategy.Canary != nil)
+			require.Len(t, hub.Status.Conditions, 1)
+			assert.Equal(t, corev1.ConditionFalse, hub.Status.Conditions[0].Status)
+			assert.Equal(t, transitionTime, hub.Status.Conditions[0].LastUpdateTime)
+
+			// round trip back to the spoke version
+			got := &ExtendedDaemonSet{}
+			require.NoError(t, got.ConvertFrom(hub))
+			assert.True(t, apiequality.Semantic.DeepEqual(tt.spoke, got), "round trip mismatch:\nwant: %#v\ngot:  %#v", tt.spoke, got)
+		})
+	}
+}
+
+func TestExtendedDaemonSetIsConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	ok, err := conversion.IsConvertible(scheme, &v1alpha1.ExtendedDaemonSet{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,429 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ExtendedDaemonSetSpec defines the desired state of ExtendedDaemonSet.
+type ExtendedDaemonSetSpec struct {
+	// A label query over pods that are managed by the daemon set.
+	// Must match in order to be controlled.
+	// If empty, defaulted to labels on Pod template.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// An object that describes the pod that will be created.
+	// The ExtendedDaemonSet will create exactly one copy of this pod on every node
+	// that matches the template's node selector (or on every node if no node
+	// selector is specified).
+	// More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicationcontroller#pod-template
+	Template corev1.PodTemplateSpec `json:"template"`
+
+	// The minimum number of seconds for which a newly created pod should
+	// be ready without any of its container crashing, for it to be considered
+	// available. It is used by the canary and rolling update strategies.
+	// Defaults to 0 (pod will be considered available as soon as it is ready).
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// NodeMetadata lists the node labels and annotations copied into the pods, as pod labels, pod annotations
+	// or container environment variables, when the pods are created. A change of a copied node label or
+	// annotation value triggers the replacement of the pod running on the node.
+	// +optional
+	// +listType=atomic
+	NodeMetadata []ExtendedDaemonSetNodeMetadata `json:"nodeMetadata,omitempty"`
+
+	// StartupTaint defines a node taint that the controller removes from a node once the ExtendedDaemonSet
+	// pod running on this node is ready. It allows to keep the workloads away from a node until the daemon is running.
+	// +optional
+	StartupTaint *ExtendedDaemonSetStartupTaint `json:"startupTaint,omitempty"`
+
+	// Daemonset deployment strategy.
+	Strategy ExtendedDaemonSetSpecStrategy `json:"strategy"`
+
+	// DependsOn is the list of the ExtendedDaemonSets, in the same namespace, that need to have completed
+	// their rollout before a new template of this ExtendedDaemonSet starts to be deployed.
+	// +optional
+	// +listType=set
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Canary deployment configuration. When set, a new pod template is first deployed on a subset of the nodes.
+	// +optional
+	Canary *ExtendedDaemonSetSpecCanary `json:"canary,omitempty"`
+
+	// Paused pauses the current rollout: the Canary deployment if one is running, the rolling update otherwise.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// MigrateFrom is the name of a DaemonSet, in the same namespace, whose pods are replaced
+	// node by node during the initial rolling update.
+	// +optional
+	MigrateFrom string `json:"migrateFrom,omitempty"`
+}
+
+// ExtendedDaemonSetStartupTaint defines the node taint removed once the ExtendedDaemonSet pod running on the node is ready.
+type ExtendedDaemonSetStartupTaint struct {
+	// Key is the key of the taint removed from the node. The pod template needs to tolerate this taint.
+	Key string `json:"key"`
+	// Effect is the effect of the taint added back on the node when ReAddOnPodDeletion is true.
+	// Default value is NoSchedule.
+	// +optional
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+	// ReAddOnPodDeletion if true, the taint is added back on the node when its ExtendedDaemonSet pod is deleted.
+	// Default value is false.
+	// +optional
+	ReAddOnPodDeletion *bool `json:"reAddOnPodDeletion,omitempty"`
+}
+
+// ExtendedDaemonSetNodeMetadata defines a node label or annotation copied into the pods, and its targets in the pods.
+type ExtendedDaemonSetNodeMetadata struct {
+	// NodeLabel is the key of the node label to copy. Only one of NodeLabel and NodeAnnotation can be set.
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
+	// NodeAnnotation is the key of the node annotation to copy. Only one of NodeLabel and NodeAnnotation can be set.
+	// +optional
+	NodeAnnotation string `json:"nodeAnnotation,omitempty"`
+	// PodLabel is the key of the pod label set with the node label or annotation value. It can't be a key of the selector
+	// or a key reserved by the controller (with an `extendeddaemonset*.datadoghq.com` prefix). The node values that are not
+	// valid label values are not copied.
+	// +optional
+	PodLabel string `json:"podLabel,omitempty"`
+	// PodAnnotation is the key of the pod annotation set with the node label or annotation value. It can't be a key
+	// reserved by the controller (with an `extendeddaemonset*.datadoghq.com` prefix).
+	// +optional
+	PodAnnotation string `json:"podAnnotation,omitempty"`
+	// Env is the name of the environment variable set with the node label or annotation value in all the pod containers.
+	// +optional
+	Env string `json:"env,omitempty"`
+}
+
+// ExtendedDaemonSetSpecStrategyType type representing the ExtendedDaemonSet update strategy type.
+// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+type ExtendedDaemonSetSpecStrategyType string
+
+const (
+	// ExtendedDaemonSetSpecStrategyTypeRollingUpdate replaces outdated pods progressively, following the rollingUpdate configuration.
+	ExtendedDaemonSetSpecStrategyTypeRollingUpdate ExtendedDaemonSetSpecStrategyType = "RollingUpdate"
+	// ExtendedDaemonSetSpecStrategyTypeOnDelete only creates pods with the new template when an outdated pod has been deleted.
+	ExtendedDaemonSetSpecStrategyTypeOnDelete ExtendedDaemonSetSpecStrategyType = "OnDelete"
+)
+
+// ExtendedDaemonSetSpecStrategy defines the deployment strategy of ExtendedDaemonSet.
+type ExtendedDaemonSetSpecStrategy struct {
+	// Type of update strategy. Can be "RollingUpdate" (default) or "OnDelete".
+	// With "OnDelete", pods using an outdated template are only replaced once they are deleted externally.
+	Type          ExtendedDaemonSetSpecStrategyType          `json:"type,omitempty"`
+	RollingUpdate ExtendedDaemonSetSpecStrategyRollingUpdate `json:"rollingUpdate,omitempty"`
+	// ReconcileFrequency use to configure how often the ExtendedDeamonset will be fully reconcile, default is 10sec.
+	ReconcileFrequency *metav1.Duration `json:"reconcileFrequency,omitempty"`
+	// UseEvictionAPI if true, the pods are evicted with the Eviction API instead of being deleted, to respect
+	// the PodDisruptionBudgets. An eviction refused by a PodDisruptionBudget is retried later.
+	// Default value is false.
+	UseEvictionAPI *bool `json:"useEvictionAPI,omitempty"`
+}
+
+// ExtendedDaemonSetSpecStrategyRollingUpdate defines the rolling update deployment strategy of ExtendedDaemonSet.
+type ExtendedDaemonSetSpecStrategyRollingUpdate struct {
+	// The maximum number of DaemonSet pods that can be unavailable during the
+	// update. Value can be an absolute number (ex: 5) or a percentage of total
+	// number of DaemonSet pods at the start of the update (ex: 10%). Absolute
+	// number is calculated from percentage by rounding up.
+	// This cannot be 0.
+	// Default value is 1.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MaxPodSchedulerFailure the maxinum number of not scheduled on its Node due to a
+	// scheduler failure: resource constraints. Value can be an absolute number (ex: 5) or a percentage of total
+	// number of DaemonSet pods at the start of the update (ex: 10%). Absolute.
+	MaxPodSchedulerFailure *intstr.IntOrString `json:"maxPodSchedulerFailure,omitempty"`
+	// The maxium number of pods created in parallel.
+	// Default value is 250.
+	MaxParallelPodCreation *int32 `json:"maxParallelPodCreation,omitempty"`
+	// MaxParallelNewNodePodCreation if set, the pods of the nodes that joined the cluster after the creation of the
+	// replicaset are created outside of the slow start and MaxParallelPodCreation limits, up to this number of pods
+	// in parallel. Those nodes never ran an outdated pod, so there is no need to throttle their pods creation.
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelNewNodePodCreation *int32 `json:"maxParallelNewNodePodCreation,omitempty"`
+	// SlowStartIntervalDuration the duration between to 2
+	// Default value is 1min.
+	SlowStartIntervalDuration *metav1.Duration `json:"slowStartIntervalDuration,omitempty"`
+	// SlowStartAdditiveIncrease
+	// Value can be an absolute number (ex: 5) or a percentage of total
+	// number of DaemonSet pods at the start of the update (ex: 10%).
+	// Default value is 5.
+	SlowStartAdditiveIncrease *intstr.IntOrString `json:"slowStartAdditiveIncrease,omitempty"`
+	// ManualBatchApproval if true, the pods are updated by batches of MaxUnavailable pods, and the
+	// rolling update is paused after each batch until the next one is approved with `kubectl eds rollout next`.
+	// Default value is false.
+	ManualBatchApproval *bool `json:"manualBatchApproval,omitempty"`
+	// PauseAt defines checkpoints at which the rolling update is automatically paused, once the number of nodes
+	// running the new template reaches them. Values can be absolute numbers (ex: 5) or percentages of the total
+	// number of DaemonSet pods (ex: 10%).
+	// +listType=atomic
+	PauseAt []intstr.IntOrString `json:"pauseAt,omitempty"`
+	// PauseAtAutoResumeDuration if set, a rolling update paused at a PauseAt checkpoint is automatically resumed
+	// after this duration, if no pod from the new template restarted in the meantime.
+	PauseAtAutoResumeDuration *metav1.Duration `json:"pauseAtAutoResumeDuration,omitempty"`
+	// PrePullImages if true, the images of the new pod template are pulled on a node, with a pre-pull pod,
+	// before the old pod running on this node is deleted.
+	// Default value is false.
+	PrePullImages *bool `json:"prePullImages,omitempty"`
+	// DrainTimeout if set, the outdated pods are asked to drain before being deleted: the controller adds the
+	// `extendeddaemonset.datadoghq.com/drain-requested` annotation on the pod, and waits until the pod sets the
+	// `extendeddaemonset.datadoghq.com/drain-complete` annotation or until the timeout expires. The draining pods
+	// are counted against maxUnavailable.
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// DoNotDisruptNodeAnnotationKeys the rolling update doesn't delete the outdated pods running on the nodes
+	// with one of these annotations (ex: karpenter.sh/do-not-disrupt), unless its value is "false".
+	// The pods are updated once the annotation disappears.
+	// +listType=set
+	DoNotDisruptNodeAnnotationKeys []string `json:"doNotDisruptNodeAnnotationKeys,omitempty"`
+	// DoNotDisruptNodeLabelKeys the rolling update doesn't delete the outdated pods running on the nodes
+	// with one of these labels, unless its value is "false".
+	// The pods are updated once the label disappears.
+	// +listType=set
+	DoNotDisruptNodeLabelKeys []string `json:"doNotDisruptNodeLabelKeys,omitempty"`
+	// MaxUnhealthyPods if set, enables the health gate of the rolling update: the rolling update is automatically
+	// paused once more than this number of the up-to-date pods are unhealthy, because they cannot start
+	// (ex: CrashLoopBackOff) or restarted more than MaxUnhealthyPodRestarts times. Value can be an absolute number
+	// (ex: 5) or a percentage of the up-to-date pods (ex: 5%). Absolute number is calculated from percentage by rounding down.
+	// The rolling update stays paused until it is resumed manually.
+	MaxUnhealthyPods *intstr.IntOrString `json:"maxUnhealthyPods,omitempty"`
+	// MaxUnhealthyPodRestarts the number of restarts above which an up-to-date pod is unhealthy for the health gate.
+	// Default value is 5 when MaxUnhealthyPods is set.
+	MaxUnhealthyPodRestarts *int32 `json:"maxUnhealthyPodRestarts,omitempty"`
+	// FailOnUnhealthyPods if true, the rolling update is failed instead of paused when the health gate is reached:
+	// the pods of the replicaset are neither created nor deleted anymore, until the pod template is updated.
+	// Default value is false.
+	FailOnUnhealthyPods *bool `json:"failOnUnhealthyPods,omitempty"`
+}
+
+// ExtendedDaemonSetSpecCanaryValidationMode type representing the ExtendedDaemonSetSpecCanary validation mode.
+// +kubebuilder:validation:Enum=auto;manual
+type ExtendedDaemonSetSpecCanaryValidationMode string
+
+const (
+	// ExtendedDaemonSetSpecCanaryValidationModeAuto the ExtendedDaemonSetSpecCanary automatic validation mode.
+	ExtendedDaemonSetSpecCanaryValidationModeAuto ExtendedDaemonSetSpecCanaryValidationMode = "auto"
+	// ExtendedDaemonSetSpecCanaryValidationModeManual the ExtendedDaemonSetSpecCanary manual validation mode.
+	ExtendedDaemonSetSpecCanaryValidationModeManual ExtendedDaemonSetSpecCanaryValidationMode = "manual"
+)
+
+// ExtendedDaemonSetSpecCanary defines the canary deployment of ExtendedDaemonSet.
+type ExtendedDaemonSetSpecCanary struct {
+	Replicas     *intstr.IntOrString   `json:"replicas,omitempty"`
+	Duration     *metav1.Duration      `json:"duration,omitempty"`
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// +listType=set
+	NodeAntiAffinityKeys []string                              `json:"nodeAntiAffinityKeys,omitempty"`
+	AutoPause            *ExtendedDaemonSetSpecCanaryAutoPause `json:"autoPause,omitempty"`
+	AutoFail             *ExtendedDaemonSetSpecCanaryAutoFail  `json:"autoFail,omitempty"`
+	// NoRestartsDuration defines min duration since last restart to end the canary phase.
+	NoRestartsDuration *metav1.Duration `json:"noRestartsDuration,omitempty"`
+	// ValidationMode used to configure how a canary deployment is validated. Possible values are 'auto' (default) and 'manual'
+	ValidationMode ExtendedDaemonSetSpecCanaryValidationMode `json:"validationMode,omitempty"`
+	// NodeSelectionPolicy defines how the canary nodes are selected.
+	// Default policy is 'leastRestarts'.
+	NodeSelectionPolicy *ExtendedDaemonSetSpecCanaryNodeSelectionPolicy `json:"nodeSelectionPolicy,omitempty"`
+	// AutoRollback restores the ExtendedDaemonSet pod template and node metadata to the ones of the active replicaset
+	// when the canary deployment fails. The failed template is not deployed again.
+	// Default value is true.
+	AutoRollback *bool `json:"autoRollback,omitempty"`
+	// Validated is the name of the canary ExtendedDaemonSetReplicaSet declared valid: the rolling update
+	// starts without waiting for the end of the canary duration. Naming the replicaset prevents
+	// a stale validation from promoting a later canary.
+	// +optional
+	Validated string `json:"validated,omitempty"`
+}
+
+// ExtendedDaemonSetSpecCanaryNodeSelectionPolicyType type representing the canary node selection policy.
+// +kubebuilder:validation:Enum=leastRestarts;random;oldestNodes;newestNodes;explicit;preferLabel
+type ExtendedDaemonSetSpecCanaryNodeSelectionPolicyType string
+
+// ExtendedDaemonSetSpecCanaryNodeSelectionPolicy defines how the canary nodes are selected.
+type ExtendedDaemonSetSpecCanaryNodeSelectionPolicy struct {
+	// Type of the node selection policy. Possible values are 'leastRestarts' (default), 'random', 'oldestNodes',
+	// 'newestNodes', 'explicit' and 'preferLabel'.
+	Type ExtendedDaemonSetSpecCanaryNodeSelectionPolicyType `json:"type,omitempty"`
+	// Seed used to shuffle the nodes with the 'random' policy, to get a reproducible selection.
+	// A random seed is used if not set.
+	Seed *int64 `json:"seed,omitempty"`
+	// NodeNames the nodes to select, by order of preference, with the 'explicit' policy.
+	// +listType=atomic
+	NodeNames []string `json:"nodeNames,omitempty"`
+	// PreferredLabels the weighted node labels used with the 'preferLabel' policy: the nodes with the highest
+	// sum of weights are selected first.
+	// +listType=atomic
+	PreferredLabels []ExtendedDaemonSetSpecCanaryPreferredLabel `json:"preferredLabels,omitempty"`
+}
+
+// ExtendedDaemonSetSpecCanaryPreferredLabel defines a weighted node label of the 'preferLabel' canary node selection policy.
+type ExtendedDaemonSetSpecCanaryPreferredLabel struct {
+	// Key of the node label.
+	Key string `json:"key"`
+	// Value of the node label. Any value matches if not set.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Weight added to the nodes with this label.
+	Weight int32 `json:"weight"`
+}
+
+// ExtendedDaemonSetSpecCanaryAutoPause defines the canary deployment AutoPause parameters of the ExtendedDaemonSet.
+type ExtendedDaemonSetSpecCanaryAutoPause struct {
+	// Enabled enables AutoPause.
+	// Default value is true.
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxRestarts defines the number of tolerable (per pod) Canary pod restarts after which the Canary deployment is autopaused.
+	// Default value is 2.
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+	// MaxSlowStartDuration defines the maximum slow start duration for a pod (stuck in Creating state) after which the Canary deployment is autopaused.
+	// There is no default value.
+	MaxSlowStartDuration *metav1.Duration `json:"maxSlowStartDuration,omitempty"`
+}
+
+// ExtendedDaemonSetSpecCanaryAutoFail defines the canary deployment AutoFail parameters of the ExtendedDaemonSet.
+type ExtendedDaemonSetSpecCanaryAutoFail struct {
+	// Enabled enables AutoFail.
+	// Default value is true.
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxRestarts defines the number of tolerable (per pod) Canary pod restarts after which the Canary deployment is autofailed.
+	// Default value is 5.
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+	// MaxRestartsDuration defines the maximum duration of tolerable Canary pod restarts after which the Canary deployment is autofailed.
+	// There is no default value.
+	MaxRestartsDuration *metav1.Duration `json:"maxRestartsDuration,omitempty"`
+	// CanaryTimeout defines the maximum duration of a Canary, after which the Canary deployment is autofailed. This is a safeguard against lengthy Canary pauses.
+	// There is no default value.
+	CanaryTimeout *metav1.Duration `json:"canaryTimeout,omitempty"`
+}
+
+// ExtendedDaemonSetStatusState type representing the ExtendedDaemonSet state.
+// The possible values are the same as the v1alpha1 ones.
+type ExtendedDaemonSetStatusState string
+
+// ExtendedDaemonSetStatusReason type represents the reason for a ExtendedDaemonSet status state.
+// The possible values are the same as the v1alpha1 ones.
+type ExtendedDaemonSetStatusReason string
+
+// ExtendedDaemonSetStatus defines the observed state of ExtendedDaemonSet.
+type ExtendedDaemonSetStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration       int64 `json:"observedGeneration,omitempty"`
+	Desired                  int32 `json:"desired"`
+	Current                  int32 `json:"current"`
+	Ready                    int32 `json:"ready"`
+	Available                int32 `json:"available"`
+	UpToDate                 int32 `json:"upToDate"`
+	IgnoredUnresponsiveNodes int32 `json:"ignoredUnresponsiveNodes"`
+	// NumberMisscheduled is the number of nodes that are running the daemon pod, but are not supposed to run it.
+	// +optional
+	NumberMisscheduled int32 `json:"numberMisscheduled,omitempty"`
+	// UpdatedNumberScheduled is the number of nodes that are running the up-to-date daemon pod.
+	// +optional
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled,omitempty"`
+	// NumberUnavailable is the number of nodes that should be running the daemon pod and have none of
+	// the daemon pod running and available.
+	// +optional
+	NumberUnavailable int32 `json:"numberUnavailable,omitempty"`
+	// CollisionCount is the number of extra ExtendedDaemonSetReplicaSets whose pod template hash
+	// collides with the up-to-date one.
+	// +optional
+	CollisionCount int32 `json:"collisionCount,omitempty"`
+
+	State            ExtendedDaemonSetStatusState   `json:"state,omitempty"`
+	ActiveReplicaSet string                         `json:"activeReplicaSet"`
+	Canary           *ExtendedDaemonSetStatusCanary `json:"canary,omitempty"`
+	// RollingUpdateBatch contains the current batch of the rolling update when the manual batch approval is enabled.
+	// +optional
+	RollingUpdateBatch *ExtendedDaemonSetStatusRollingUpdateBatch `json:"rollingUpdateBatch,omitempty"`
+	// RollingUpdateCheckpoint contains the last rollingUpdate.pauseAt checkpoint reached by the rolling update.
+	// +optional
+	RollingUpdateCheckpoint *ExtendedDaemonSetStatusRollingUpdateCheckpoint `json:"rollingUpdateCheckpoint,omitempty"`
+	// FailedTemplateHash is the hash of the last pod template rolled back after a canary deployment failure.
+	// +optional
+	FailedTemplateHash string `json:"failedTemplateHash,omitempty"`
+
+	// Reason provides an explanation for canary deployment autopause
+	// +optional
+	Reason ExtendedDaemonSetStatusReason `json:"reason,omitempty"`
+
+	// Conditions Represents the latest available observations of a DaemonSet's current state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ExtendedDaemonSetStatusCanary defines the observed state of ExtendedDaemonSet canary deployment.
+type ExtendedDaemonSetStatusCanary struct {
+	ReplicaSet string `json:"replicaSet"`
+	// +listType=set
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// ExtendedDaemonSetStatusRollingUpdateCheckpoint defines the last rollingUpdate.pauseAt checkpoint reached by the rolling update.
+type ExtendedDaemonSetStatusRollingUpdateCheckpoint struct {
+	// ReplicaSet is the name of the replicaset whose rolling update reached the checkpoint.
+	ReplicaSet string `json:"replicaSet"`
+	// PauseAt is the checkpoint reached.
+	PauseAt intstr.IntOrString `json:"pauseAt"`
+	// PausedTime is the time at which the rolling update was paused at the checkpoint.
+	PausedTime metav1.Time `json:"pausedTime"`
+	// Resumed is true once the rolling update has been resumed after the checkpoint, automatically or not.
+	Resumed bool `json:"resumed,omitempty"`
+}
+
+// ExtendedDaemonSetStatusRollingUpdateBatch defines the observed state of a rolling update with manual batch approval.
+type ExtendedDaemonSetStatusRollingUpdateBatch struct {
+	// Number of the current batch, starting at 1.
+	Number int32 `json:"number"`
+	// Nodes on which the pods are updated during the current batch.
+	// +listType=set
+	Nodes []string `json:"nodes,omitempty"`
+	// Completed is true when all the pods of the current batch are up-to-date and available:
+	// the rolling update is paused until the next batch is approved.
+	Completed bool `json:"completed,omitempty"`
+}
+
+// ExtendedDaemonSet is the Schema for the extendeddaemonsets API.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="desired",type="integer",JSONPath=".status.desired"
+// +kubebuilder:printcolumn:name="current",type="integer",JSONPath=".status.current"
+// +kubebuilder:printcolumn:name="ready",type="integer",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="up-to-date",type="integer",JSONPath=".status.upToDate"
+// +kubebuilder:printcolumn:name="available",type="integer",JSONPath=".status.available"
+// +kubebuilder:printcolumn:name="paused",type="boolean",JSONPath=".spec.paused"
+// +kubebuilder:printcolumn:name="status",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="reason",type="string",JSONPath=".status.reason"
+// +kubebuilder:printcolumn:name="active rs",type="string",JSONPath=".status.activeReplicaSet"
+// +kubebuilder:printcolumn:name="canary rs",type="string",JSONPath=".status.canary.replicaSet"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=extendeddaemonsets,shortName=eds
+type ExtendedDaemonSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExtendedDaemonSetSpec   `json:"spec,omitempty"`
+	Status ExtendedDaemonSetStatus `json:"status,omitempty"`
+}
+
+// ExtendedDaemonSetList contains a list of ExtendedDaemonSet
+// +kubebuilder:object:root=true
+type ExtendedDaemonSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExtendedDaemonSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExtendedDaemonSet{}, &ExtendedDaemonSetList{})
+}
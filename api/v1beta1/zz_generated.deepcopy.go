@@ -0,0 +1,537 @@
+//go:build !ignore_autogenerated
+
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSet) DeepCopyInto(out *ExtendedDaemonSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSet.
+func (in *ExtendedDaemonSet) DeepCopy() *ExtendedDaemonSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExtendedDaemonSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetList) DeepCopyInto(out *ExtendedDaemonSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExtendedDaemonSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetList.
+func (in *ExtendedDaemonSetList) DeepCopy() *ExtendedDaemonSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExtendedDaemonSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetNodeMetadata) DeepCopyInto(out *ExtendedDaemonSetNodeMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetNodeMetadata.
+func (in *ExtendedDaemonSetNodeMetadata) DeepCopy() *ExtendedDaemonSetNodeMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetNodeMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpec) DeepCopyInto(out *ExtendedDaemonSetSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.NodeMetadata != nil {
+		in, out := &in.NodeMetadata, &out.NodeMetadata
+		*out = make([]ExtendedDaemonSetNodeMetadata, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartupTaint != nil {
+		in, out := &in.StartupTaint, &out.StartupTaint
+		*out = new(ExtendedDaemonSetStartupTaint)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(ExtendedDaemonSetSpecCanary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpec.
+func (in *ExtendedDaemonSetSpec) DeepCopy() *ExtendedDaemonSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecCanary) DeepCopyInto(out *ExtendedDaemonSetSpecCanary) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeAntiAffinityKeys != nil {
+		in, out := &in.NodeAntiAffinityKeys, &out.NodeAntiAffinityKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoPause != nil {
+		in, out := &in.AutoPause, &out.AutoPause
+		*out = new(ExtendedDaemonSetSpecCanaryAutoPause)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoFail != nil {
+		in, out := &in.AutoFail, &out.AutoFail
+		*out = new(ExtendedDaemonSetSpecCanaryAutoFail)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NoRestartsDuration != nil {
+		in, out := &in.NoRestartsDuration, &out.NoRestartsDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeSelectionPolicy != nil {
+		in, out := &in.NodeSelectionPolicy, &out.NodeSelectionPolicy
+		*out = new(ExtendedDaemonSetSpecCanaryNodeSelectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecCanary.
+func (in *ExtendedDaemonSetSpecCanary) DeepCopy() *ExtendedDaemonSetSpecCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecCanaryAutoFail) DeepCopyInto(out *ExtendedDaemonSetSpecCanaryAutoFail) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRestartsDuration != nil {
+		in, out := &in.MaxRestartsDuration, &out.MaxRestartsDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CanaryTimeout != nil {
+		in, out := &in.CanaryTimeout, &out.CanaryTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecCanaryAutoFail.
+func (in *ExtendedDaemonSetSpecCanaryAutoFail) DeepCopy() *ExtendedDaemonSetSpecCanaryAutoFail {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecCanaryAutoFail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecCanaryAutoPause) DeepCopyInto(out *ExtendedDaemonSetSpecCanaryAutoPause) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSlowStartDuration != nil {
+		in, out := &in.MaxSlowStartDuration, &out.MaxSlowStartDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecCanaryAutoPause.
+func (in *ExtendedDaemonSetSpecCanaryAutoPause) DeepCopy() *ExtendedDaemonSetSpecCanaryAutoPause {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecCanaryAutoPause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecCanaryNodeSelectionPolicy) DeepCopyInto(out *ExtendedDaemonSetSpecCanaryNodeSelectionPolicy) {
+	*out = *in
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeNames != nil {
+		in, out := &in.NodeNames, &out.NodeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreferredLabels != nil {
+		in, out := &in.PreferredLabels, &out.PreferredLabels
+		*out = make([]ExtendedDaemonSetSpecCanaryPreferredLabel, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecCanaryNodeSelectionPolicy.
+func (in *ExtendedDaemonSetSpecCanaryNodeSelectionPolicy) DeepCopy() *ExtendedDaemonSetSpecCanaryNodeSelectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecCanaryNodeSelectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecCanaryPreferredLabel) DeepCopyInto(out *ExtendedDaemonSetSpecCanaryPreferredLabel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecCanaryPreferredLabel.
+func (in *ExtendedDaemonSetSpecCanaryPreferredLabel) DeepCopy() *ExtendedDaemonSetSpecCanaryPreferredLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecCanaryPreferredLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecStrategy) DeepCopyInto(out *ExtendedDaemonSetSpecStrategy) {
+	*out = *in
+	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+	if in.ReconcileFrequency != nil {
+		in, out := &in.ReconcileFrequency, &out.ReconcileFrequency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UseEvictionAPI != nil {
+		in, out := &in.UseEvictionAPI, &out.UseEvictionAPI
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecStrategy.
+func (in *ExtendedDaemonSetSpecStrategy) DeepCopy() *ExtendedDaemonSetSpecStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetSpecStrategyRollingUpdate) DeepCopyInto(out *ExtendedDaemonSetSpecStrategyRollingUpdate) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxPodSchedulerFailure != nil {
+		in, out := &in.MaxPodSchedulerFailure, &out.MaxPodSchedulerFailure
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxParallelPodCreation != nil {
+		in, out := &in.MaxParallelPodCreation, &out.MaxParallelPodCreation
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxParallelNewNodePodCreation != nil {
+		in, out := &in.MaxParallelNewNodePodCreation, &out.MaxParallelNewNodePodCreation
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SlowStartIntervalDuration != nil {
+		in, out := &in.SlowStartIntervalDuration, &out.SlowStartIntervalDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SlowStartAdditiveIncrease != nil {
+		in, out := &in.SlowStartAdditiveIncrease, &out.SlowStartAdditiveIncrease
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ManualBatchApproval != nil {
+		in, out := &in.ManualBatchApproval, &out.ManualBatchApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PauseAt != nil {
+		in, out := &in.PauseAt, &out.PauseAt
+		*out = make([]intstr.IntOrString, len(*in))
+		copy(*out, *in)
+	}
+	if in.PauseAtAutoResumeDuration != nil {
+		in, out := &in.PauseAtAutoResumeDuration, &out.PauseAtAutoResumeDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PrePullImages != nil {
+		in, out := &in.PrePullImages, &out.PrePullImages
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DoNotDisruptNodeAnnotationKeys != nil {
+		in, out := &in.DoNotDisruptNodeAnnotationKeys, &out.DoNotDisruptNodeAnnotationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DoNotDisruptNodeLabelKeys != nil {
+		in, out := &in.DoNotDisruptNodeLabelKeys, &out.DoNotDisruptNodeLabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxUnhealthyPods != nil {
+		in, out := &in.MaxUnhealthyPods, &out.MaxUnhealthyPods
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnhealthyPodRestarts != nil {
+		in, out := &in.MaxUnhealthyPodRestarts, &out.MaxUnhealthyPodRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailOnUnhealthyPods != nil {
+		in, out := &in.FailOnUnhealthyPods, &out.FailOnUnhealthyPods
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetSpecStrategyRollingUpdate.
+func (in *ExtendedDaemonSetSpecStrategyRollingUpdate) DeepCopy() *ExtendedDaemonSetSpecStrategyRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetSpecStrategyRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStartupTaint) DeepCopyInto(out *ExtendedDaemonSetStartupTaint) {
+	*out = *in
+	if in.ReAddOnPodDeletion != nil {
+		in, out := &in.ReAddOnPodDeletion, &out.ReAddOnPodDeletion
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStartupTaint.
+func (in *ExtendedDaemonSetStartupTaint) DeepCopy() *ExtendedDaemonSetStartupTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStartupTaint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStatus) DeepCopyInto(out *ExtendedDaemonSetStatus) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(ExtendedDaemonSetStatusCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollingUpdateBatch != nil {
+		in, out := &in.RollingUpdateBatch, &out.RollingUpdateBatch
+		*out = new(ExtendedDaemonSetStatusRollingUpdateBatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollingUpdateCheckpoint != nil {
+		in, out := &in.RollingUpdateCheckpoint, &out.RollingUpdateCheckpoint
+		*out = new(ExtendedDaemonSetStatusRollingUpdateCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStatus.
+func (in *ExtendedDaemonSetStatus) DeepCopy() *ExtendedDaemonSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStatusCanary) DeepCopyInto(out *ExtendedDaemonSetStatusCanary) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStatusCanary.
+func (in *ExtendedDaemonSetStatusCanary) DeepCopy() *ExtendedDaemonSetStatusCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStatusCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStatusRollingUpdateBatch) DeepCopyInto(out *ExtendedDaemonSetStatusRollingUpdateBatch) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStatusRollingUpdateBatch.
+func (in *ExtendedDaemonSetStatusRollingUpdateBatch) DeepCopy() *ExtendedDaemonSetStatusRollingUpdateBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStatusRollingUpdateBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedDaemonSetStatusRollingUpdateCheckpoint) DeepCopyInto(out *ExtendedDaemonSetStatusRollingUpdateCheckpoint) {
+	*out = *in
+	out.PauseAt = in.PauseAt
+	in.PausedTime.DeepCopyInto(&out.PausedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedDaemonSetStatusRollingUpdateCheckpoint.
+func (in *ExtendedDaemonSetStatusRollingUpdateCheckpoint) DeepCopy() *ExtendedDaemonSetStatusRollingUpdateCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedDaemonSetStatusRollingUpdateCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -15,13 +15,11 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
 )
 
 var upgradeExample = `
@@ -48,14 +46,15 @@ type Options struct {
 
 // NewOptions provides an instance of Options with default values.
 func NewOptions(streams genericclioptions.IOStreams) *Options {
+	defaults := rollout.DefaultWaitOptions()
 	opts := &Options{
 		configFlags: genericclioptions.NewConfigFlags(false),
 
 		IOStreams:         streams,
-		checkPeriod:       30 * time.Second,
-		checkTimeout:      2 * time.Hour,
-		nodeCompletionPct: 0.95,
-		nodeCompletionMin: 10,
+		checkPeriod:       defaults.Period,
+		checkTimeout:      defaults.Timeout,
+		nodeCompletionPct: defaults.NodeCompletionPct,
+		nodeCompletionMin: defaults.NodeCompletionMin,
 	}
 
 	if val, found := os.LookupEnv("NODE_COMPLETION_PCT"); found {
@@ -150,65 +149,13 @@ func (o *Options) Validate() error {
 func (o *Options) Run() error {
 	o.printOutf("start checking deployment state")
 
-	checkUpgradeDown := func(ctx context.Context) (bool, error) {
-		eds := &v1alpha1.ExtendedDaemonSet{}
-		err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
-		if err != nil && apierrors.IsNotFound(err) {
-			return false, fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
-		} else if err != nil {
-			return false, fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
-		}
-
-		if eds.Status.Canary != nil {
-			o.printOutf("canary running")
-
-			return false, nil
-		}
-
-		// We need to look at the activeReplicaSet of the current ExtendedDaemonSet object, and look at the creationTimestamp of
-		// that replicaset. If the creationTimestamp is older than the last occurrence of the "CanaryFailed" condition of the ExtendedDaemonSet,
-		// it is safe to assume that the canary failed and we should fail this check.
-		var canaryFailedConditionPresent bool
-		var canaryFailedCondition v1alpha1.ExtendedDaemonSetCondition
-		for _, condition := range eds.Status.Conditions {
-			if condition.Type == v1alpha1.ConditionTypeEDSCanaryFailed {
-				canaryFailedConditionPresent = true
-				canaryFailedCondition = condition
-				break
-			}
-		}
-
-		if canaryFailedConditionPresent {
-			ers := &v1alpha1.ExtendedDaemonSetReplicaSet{}
-			err = o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: eds.Status.ActiveReplicaSet}, ers)
-			if err == nil {
-				if ers.CreationTimestamp.Before(&canaryFailedCondition.LastTransitionTime) {
-					return false, errors.New("active canary has a creation timestamp before the last CanaryFailed condition, meaning the deployment failed")
-				}
-			} else {
-				o.printOutf("error getting replicaset %s: %s", eds.Status.ActiveReplicaSet, err.Error())
-			}
-		}
-
-		// Make sure the eds is in a Running state to avoid a case where the eds is in a Canary state and the number of canary pods
-		// is high enough to pass the threshold.
-		if eds.Status.State != v1alpha1.ExtendedDaemonSetStatusStateRunning {
-			return false, fmt.Errorf("eds is not in a Running state, it is in a %s state", eds.Status.State)
-		}
-
-		if float64(eds.Status.UpToDate) > float64(eds.Status.Current)*o.nodeCompletionPct ||
-			eds.Status.Current-eds.Status.UpToDate < o.nodeCompletionMin {
-			o.printOutf("upgrade is now finished (reached threshold): %d, nb updated pods: %d, threshold pct: %f, min threshold: %d", eds.Status.Current, eds.Status.UpToDate, o.nodeCompletionPct, o.nodeCompletionMin)
-
-			return true, nil
-		}
-
-		o.printOutf("still upgrading nb pods: %d, nb updated pods: %d", eds.Status.Current, eds.Status.UpToDate)
-
-		return false, nil
-	}
-
-	return wait.PollUntilContextTimeout(context.TODO(), o.checkPeriod, o.checkTimeout, false, checkUpgradeDown)
+	return rollout.WaitForRollout(context.TODO(), o.client, client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, rollout.WaitOptions{
+		Period:            o.checkPeriod,
+		Timeout:           o.checkTimeout,
+		NodeCompletionPct: o.nodeCompletionPct,
+		NodeCompletionMin: o.nodeCompletionMin,
+		Logf:              o.printOutf,
+	})
 }
 
 func (o *Options) printOutf(format string, a ...any) {
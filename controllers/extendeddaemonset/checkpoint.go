@@ -0,0 +1,121 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonset
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	ersconditions "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+)
+
+// manageRollingUpdateCheckpoints pauses the rolling update of the current replicaset, with the rolling-update-paused
+// annotation, once the number of up-to-date pods reaches one of the rollingUpdate.pauseAt checkpoints.
+// If rollingUpdate.pauseAtAutoResumeDuration is set, the rolling update is resumed after this duration if no
+// up-to-date pod restarted since the pause.
+// It returns true if the ExtendedDaemonSet annotations were updated, and the duration after which the auto-resume should be checked.
+func manageRollingUpdateCheckpoints(logger logr.Logger, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, current *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, now time.Time) (bool, time.Duration) {
+	rollingUpdate := &daemonset.Spec.Strategy.RollingUpdate
+	checkpoint := daemonset.Status.RollingUpdateCheckpoint
+	if checkpoint != nil && checkpoint.ReplicaSet != current.Name {
+		// the checkpoint was reached by a previous rolling update
+		checkpoint = nil
+	}
+	defer func() {
+		daemonset.Status.RollingUpdateCheckpoint = checkpoint
+	}()
+
+	isPaused := IsRollingUpdatePaused(daemonset.Annotations)
+	if checkpoint != nil && !checkpoint.Resumed {
+		switch {
+		case daemonset.Annotations[datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] == datadoghqv1alpha1.ValueStringFalse:
+			// resumed manually
+			checkpoint.Resumed = true
+		case !isPaused:
+			// the checkpoint was stored in the status, but the annotation update that paused the rolling update failed
+			logger.Info("Pause rolling update at checkpoint again", "pauseAt", checkpoint.PauseAt.String())
+			if daemonset.Annotations == nil {
+				daemonset.Annotations = map[string]string{}
+			}
+			daemonset.Annotations[datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] = datadoghqv1alpha1.ValueStringTrue
+
+			return true, 0
+		case rollingUpdate.PauseAtAutoResumeDuration == nil:
+			return false, 0
+		case hasPodRestartedSince(current, checkpoint.PausedTime.Time):
+			// a manual action is needed to resume the rolling update
+			return false, 0
+		default:
+			remaining := checkpoint.PausedTime.Add(rollingUpdate.PauseAtAutoResumeDuration.Duration).Sub(now)
+			if remaining > 0 {
+				return false, remaining
+			}
+			logger.Info("Resume rolling update paused at checkpoint", "pauseAt", checkpoint.PauseAt.String())
+			daemonset.Annotations[datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] = datadoghqv1alpha1.ValueStringFalse
+			checkpoint.Resumed = true
+
+			return true, 0
+		}
+	}
+
+	desired := int(daemonset.Status.Desired)
+	upToDate := int(daemonset.Status.UpToDate)
+	if isPaused || upToDate >= desired {
+		return false, 0
+	}
+
+	lastReached := 0
+	if checkpoint != nil {
+		lastReached, _ = intstrutil.GetScaledValueFromIntOrPercent(&checkpoint.PauseAt, desired, true)
+	}
+	var reached *intstrutil.IntOrString
+	reachedValue := lastReached
+	for i := range rollingUpdate.PauseAt {
+		value, err := intstrutil.GetScaledValueFromIntOrPercent(&rollingUpdate.PauseAt[i], desired, true)
+		if err != nil {
+			logger.Error(err, "unable to retrieve the checkpoint from the strategy.rollingUpdate.pauseAt parameter")
+
+			continue
+		}
+		// when several checkpoints are reached at once, the rolling update is paused only once
+		if value > reachedValue && value <= upToDate {
+			reached = &rollingUpdate.PauseAt[i]
+			reachedValue = value
+		}
+	}
+	if reached == nil {
+		return false, 0
+	}
+
+	logger.Info("Pause rolling update at checkpoint", "pauseAt", reached.String(), "upToDate", upToDate, "desired", desired)
+	if daemonset.Annotations == nil {
+		daemonset.Annotations = map[string]string{}
+	}
+	daemonset.Annotations[datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] = datadoghqv1alpha1.ValueStringTrue
+	checkpoint = &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{
+		ReplicaSet: current.Name,
+		PauseAt:    *reached,
+		PausedTime: metav1.NewTime(now),
+	}
+
+	var requeueAfter time.Duration
+	if rollingUpdate.PauseAtAutoResumeDuration != nil {
+		requeueAfter = rollingUpdate.PauseAtAutoResumeDuration.Duration
+	}
+
+	return true, requeueAfter
+}
+
+// hasPodRestartedSince returns true if a pod of the replicaset restarted after `since`.
+func hasPodRestartedSince(rs *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, since time.Time) bool {
+	restartCondition := ersconditions.GetExtendedDaemonSetReplicaSetStatusCondition(&rs.Status, datadoghqv1alpha1.ConditionTypePodRestarting)
+
+	return restartCondition != nil && restartCondition.LastUpdateTime.After(since)
+}
@@ -0,0 +1,172 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func Test_manageRollingUpdateCheckpoints(t *testing.T) {
+	now := time.Now()
+	pausedTime := metav1.NewTime(now.Add(-10 * time.Minute))
+	pauseAt := []intstr.IntOrString{intstr.FromString("10%"), intstr.FromString("50%")}
+	paused := map[string]string{datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: datadoghqv1alpha1.ValueStringTrue}
+	unpaused := map[string]string{datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: datadoghqv1alpha1.ValueStringFalse}
+
+	current := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-1"},
+	}
+	restartingCurrent := current.DeepCopy()
+	restartingCurrent.Status.Conditions = []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+		{
+			Type:           datadoghqv1alpha1.ConditionTypePodRestarting,
+			Status:         corev1.ConditionTrue,
+			LastUpdateTime: metav1.NewTime(now.Add(-time.Minute)),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		upToDate         int32
+		autoResume       *metav1.Duration
+		checkpoint       *datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint
+		current          *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet
+		wantUpdated      bool
+		wantRequeueAfter time.Duration
+		wantPaused       bool
+		wantCheckpoint   *datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint
+	}{
+		{
+			name:     "no checkpoint reached",
+			upToDate: 5,
+		},
+		{
+			name:           "first checkpoint reached",
+			upToDate:       12,
+			wantUpdated:    true,
+			wantPaused:     true,
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: metav1.NewTime(now)},
+		},
+		{
+			name:             "two checkpoints reached at once, with auto resume",
+			upToDate:         60,
+			autoResume:       &metav1.Duration{Duration: 5 * time.Minute},
+			wantUpdated:      true,
+			wantRequeueAfter: 5 * time.Minute,
+			wantPaused:       true,
+			wantCheckpoint:   &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[1], PausedTime: metav1.NewTime(now)},
+		},
+		{
+			name:           "checkpoint already reached and resumed",
+			upToDate:       30,
+			checkpoint:     &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime, Resumed: true},
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime, Resumed: true},
+		},
+		{
+			name:           "checkpoint manually resumed",
+			annotations:    unpaused,
+			upToDate:       30,
+			checkpoint:     &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime, Resumed: true},
+		},
+		{
+			name:           "checkpoint reached, paused annotation not set => paused again",
+			upToDate:       30,
+			checkpoint:     &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+			wantUpdated:    true,
+			wantPaused:     true,
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+		},
+		{
+			name:           "checkpoint reached by a previous replicaset",
+			upToDate:       12,
+			checkpoint:     &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-0", PauseAt: pauseAt[1], PausedTime: pausedTime, Resumed: true},
+			wantUpdated:    true,
+			wantPaused:     true,
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: metav1.NewTime(now)},
+		},
+		{
+			name:           "paused without auto resume",
+			annotations:    paused,
+			upToDate:       12,
+			checkpoint:     &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+			wantPaused:     true,
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+		},
+		{
+			name:             "auto resume duration not elapsed",
+			annotations:      paused,
+			upToDate:         12,
+			autoResume:       &metav1.Duration{Duration: 15 * time.Minute},
+			checkpoint:       &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+			wantRequeueAfter: 5 * time.Minute,
+			wantPaused:       true,
+			wantCheckpoint:   &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+		},
+		{
+			name:           "auto resumed",
+			annotations:    paused,
+			upToDate:       12,
+			autoResume:     &metav1.Duration{Duration: 5 * time.Minute},
+			checkpoint:     &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+			wantUpdated:    true,
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime, Resumed: true},
+		},
+		{
+			name:           "not auto resumed because a pod restarted",
+			annotations:    paused,
+			upToDate:       12,
+			autoResume:     &metav1.Duration{Duration: 5 * time.Minute},
+			checkpoint:     &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+			current:        restartingCurrent,
+			wantPaused:     true,
+			wantCheckpoint: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateCheckpoint{ReplicaSet: "foo-1", PauseAt: pauseAt[0], PausedTime: pausedTime},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemonset := &datadoghqv1alpha1.ExtendedDaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec: datadoghqv1alpha1.ExtendedDaemonSetSpec{
+					Strategy: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+						RollingUpdate: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+							PauseAt:                   pauseAt,
+							PauseAtAutoResumeDuration: tt.autoResume,
+						},
+					},
+				},
+				Status: datadoghqv1alpha1.ExtendedDaemonSetStatus{
+					Desired:                 100,
+					UpToDate:                tt.upToDate,
+					RollingUpdateCheckpoint: tt.checkpoint,
+				},
+			}
+			for k, v := range tt.annotations {
+				daemonset.Annotations[k] = v
+			}
+			rs := current
+			if tt.current != nil {
+				rs = tt.current
+			}
+
+			updated, requeueAfter := manageRollingUpdateCheckpoints(logf.Log.WithName("test"), daemonset, rs, now)
+			assert.Equal(t, tt.wantUpdated, updated)
+			assert.Equal(t, tt.wantRequeueAfter, requeueAfter.Round(time.Second))
+			assert.Equal(t, tt.wantPaused, IsRollingUpdatePaused(daemonset.Annotations))
+			assert.Equal(t, tt.wantCheckpoint, daemonset.Status.RollingUpdateCheckpoint)
+		})
+	}
+}
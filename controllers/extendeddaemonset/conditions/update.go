@@ -34,15 +34,19 @@ type UpdateConditionOptions struct {
 	// SupportLastUpdate is an option to avoid updating the `LastUpdateTime` during every reconcile loop.
 	// This option is useful when only `LastTransitionTime` is the important information.
 	SupportLastUpdate bool
+	// UpdateReasonIfNotTrue is an option to also update the `Reason` and `Message` when the condition is not `True`.
+	// This option is useful for conditions whose `False` status also needs to be explained.
+	UpdateReasonIfNotTrue bool
 }
 
 // UpdateExtendedDaemonSetStatusCondition used to update a specific ExtendedDaemonSetConditionType.
 func UpdateExtendedDaemonSetStatusCondition(status *datadoghqv1alpha1.ExtendedDaemonSetStatus, now metav1.Time, t datadoghqv1alpha1.ExtendedDaemonSetConditionType, conditionStatus corev1.ConditionStatus, reason, desc string, options *UpdateConditionOptions) {
 	// manage options
-	var writeFalseIfNotExist, supportLastUpdate bool
+	var writeFalseIfNotExist, supportLastUpdate, updateReasonIfNotTrue bool
 	if options != nil {
 		writeFalseIfNotExist = options.IgnoreFalseConditionIfNotExist
 		supportLastUpdate = options.SupportLastUpdate
+		updateReasonIfNotTrue = options.UpdateReasonIfNotTrue
 	}
 
 	idCondition := getIndexForConditionType(status, t)
@@ -55,7 +59,7 @@ func UpdateExtendedDaemonSetStatusCondition(status *datadoghqv1alpha1.ExtendedDa
 		if supportLastUpdate {
 			status.Conditions[idCondition].LastUpdateTime = now
 		}
-		if conditionStatus == corev1.ConditionTrue {
+		if conditionStatus == corev1.ConditionTrue || updateReasonIfNotTrue {
 			status.Conditions[idCondition].Message = desc
 			status.Conditions[idCondition].Reason = reason
 		}
@@ -31,6 +31,7 @@ import (
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonset/conditions"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonset/nodeselection"
 	ersconditions "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
 	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/scheduler"
 	"github.com/DataDog/extendeddaemonset/pkg/controller/metrics"
@@ -125,6 +126,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		podsCounter.Ready += rs.Status.Ready
 		podsCounter.Current += rs.Status.Current
 		podsCounter.Available += rs.Status.Available
+		podsCounter.NumberMisscheduled += rs.Status.NumberMisscheduled
 
 		// Check if ReplicaSet is currently active
 		if rs.Name == instance.Status.ActiveReplicaSet {
@@ -133,10 +135,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 		// Check if ReplicaSet matches the ExtendedDaemonset Spec
 		if comparison.IsReplicaSetUpToDate(&rs, instance) {
+			if upToDateRS != nil {
+				podsCounter.CollisionCount++
+			}
 			upToDateRS = rs.DeepCopy()
 		}
 	}
 
+	if upToDateRS == nil && activeRS != nil && isTemplateRolledBack(instance) {
+		// The pod template already failed its Canary deployment: the active ReplicaSet stays current and up-to-date until the template changes
+		reqLogger.V(1).Info("Pod template rolled back after a canary failure, not deploying it again", "failedTemplateHash", instance.Status.FailedTemplateHash)
+		upToDateRS = activeRS
+	}
+
 	if upToDateRS == nil {
 		// If there is no ReplicaSet that matches the EDS Spec, create a new one and return to apply the reconcile loop again
 		return r.createNewReplicaSet(reqLogger, instance, podsCounter)
@@ -145,12 +156,38 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	// Select the ReplicaSet that should be current
 	currentRS, requeueAfter := selectCurrentReplicaSet(instance, activeRS, upToDateRS, now)
 
+	// Hold the new ReplicaSet rollout until the dependencies have completed their own rollout
+	var pendingDependencies []string
+	if shouldWaitOnDependencies(instance, activeRS, upToDateRS) {
+		if pendingDependencies, err = r.getPendingDependencies(instance); err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(pendingDependencies) > 0 {
+			reqLogger.Info("Rollout waiting on dependencies", "dependencies", pendingDependencies)
+			currentRS = activeRS
+			requeueAfter = instance.Spec.Strategy.ReconcileFrequency.Duration
+		}
+	}
+
+	// The canary deployments of a rollout group are promoted together
+	if instance.Spec.Strategy.Canary != nil && activeRS != nil && activeRS.Name != upToDateRS.Name && currentRS.Name == upToDateRS.Name {
+		var pendingMember string
+		if pendingMember, err = r.getPendingRolloutGroupMember(instance, now); err != nil {
+			return reconcile.Result{}, err
+		}
+		if pendingMember != "" {
+			reqLogger.Info("Canary deployment waiting on the rollout group", "member", pendingMember)
+			currentRS = activeRS
+			requeueAfter = instance.Spec.Strategy.ReconcileFrequency.Duration
+		}
+	}
+
 	// Remove all ReplicaSets if not used anymore
 	if err = r.cleanupReplicaSet(reqLogger, now, replicaSetList, currentRS, upToDateRS); err != nil {
 		return reconcile.Result{RequeueAfter: requeueAfter}, err
 	}
 
-	_, result, err := r.updateInstanceWithCurrentRS(reqLogger, now, instance, currentRS, upToDateRS, podsCounter)
+	_, result, err := r.updateInstanceWithCurrentRS(reqLogger, now, instance, currentRS, upToDateRS, podsCounter, pendingDependencies)
 	result = utils.MergeResult(result, reconcile.Result{RequeueAfter: requeueAfter})
 
 	return result, err
@@ -198,62 +235,104 @@ func selectCurrentReplicaSet(daemonset *datadoghqv1alpha1.ExtendedDaemonSet, act
 		return upToDateRS, requeueAfter
 	}
 
+	// A failed Canary deployment is never promoted.
+	if IsCanaryDeploymentFailed(upToDateRS) {
+		return activeRS, requeueAfter
+	}
+
 	// If in Canary phase, then only update ReplicaSet if it has ended or been declared valid.
-	var isEnded bool
-	dsAnnotations := daemonset.GetAnnotations()
-	isEnded, requeueAfter = IsCanaryDeploymentEnded(daemonset.Spec.Strategy.Canary, upToDateRS, now)
-	isPaused, _ := IsCanaryDeploymentPaused(dsAnnotations, upToDateRS)
-	isValid := IsCanaryDeploymentValid(dsAnnotations, upToDateRS.GetName())
-	if isValid || (!isPaused && isEnded) {
+	var isCompleted bool
+	isCompleted, requeueAfter = isCanaryDeploymentCompleted(daemonset, upToDateRS, now)
+	if isCompleted {
 		return upToDateRS, requeueAfter
 	}
 
 	return activeRS, requeueAfter
 }
 
-func nonCanaryState(dsAnnotations map[string]string) datadoghqv1alpha1.ExtendedDaemonSetStatusState {
+// isCanaryDeploymentCompleted returns true if the canary deployment of the replicaset has been declared valid,
+// or has ended without being paused.
+func isCanaryDeploymentCompleted(daemonset *datadoghqv1alpha1.ExtendedDaemonSet, rs *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, now time.Time) (bool, time.Duration) {
+	dsAnnotations := daemonset.GetAnnotations()
+	isEnded, requeueAfter := IsCanaryDeploymentEnded(daemonset.Spec.Strategy.Canary, rs, now)
+	isPaused, _ := IsCanaryDeploymentPaused(dsAnnotations, rs)
+	isValid := IsCanaryDeploymentValid(dsAnnotations, rs.GetName())
+
+	return isValid || (!isPaused && isEnded), requeueAfter
+}
+
+func nonCanaryState(dsAnnotations map[string]string, rs *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) datadoghqv1alpha1.ExtendedDaemonSetStatusState {
 	if IsRolloutFrozen(dsAnnotations) {
 		return datadoghqv1alpha1.ExtendedDaemonSetStatusStateRolloutFrozen
 	}
 
-	if IsRollingUpdatePaused(dsAnnotations) {
+	if IsRollingUpdateFailed(rs) {
+		return datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdateFailed
+	}
+
+	if IsRollingUpdatePaused(dsAnnotations) || IsRollingUpdateWaitingForBatchApproval(rs) || IsRollingUpdatePausedForUnhealthyPods(rs) {
 		return datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdatePaused
 	}
 
 	return datadoghqv1alpha1.ExtendedDaemonSetStatusStateRunning
 }
 
-func (r *Reconciler) updateInstanceWithCurrentRS(logger logr.Logger, now time.Time, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, current, upToDate *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, podsCounter podsCounterType) (*datadoghqv1alpha1.ExtendedDaemonSet, reconcile.Result, error) {
+func (r *Reconciler) updateInstanceWithCurrentRS(logger logr.Logger, now time.Time, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, current, upToDate *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, podsCounter podsCounterType, pendingDependencies []string) (*datadoghqv1alpha1.ExtendedDaemonSet, reconcile.Result, error) {
 	newDaemonset := daemonset.DeepCopy()
+	newDaemonset.Status.ObservedGeneration = daemonset.Generation
 	newDaemonset.Status.Current = podsCounter.Current
 	newDaemonset.Status.Ready = podsCounter.Ready
 	newDaemonset.Status.Available = podsCounter.Available
+	newDaemonset.Status.CollisionCount = podsCounter.CollisionCount
+	newDaemonset.Status.NumberMisscheduled = podsCounter.NumberMisscheduled
 	if current != nil {
 		newDaemonset.Status.ActiveReplicaSet = current.Name
 		newDaemonset.Status.Desired = current.Status.Desired
 		newDaemonset.Status.UpToDate = current.Status.Current
-		newDaemonset.Status.State = nonCanaryState(daemonset.GetAnnotations())
+		newDaemonset.Status.State = nonCanaryState(daemonset.GetAnnotations(), current)
 		newDaemonset.Status.IgnoredUnresponsiveNodes = current.Status.IgnoredUnresponsiveNodes
+		newDaemonset.Status.RollingUpdateBatch = current.Status.RollingUpdateBatch.DeepCopy()
 	}
 
 	var updateDaemonsetSpec bool
 	var updateDaemonsetAnnotations bool
-	// If the deployment is in Canary phase, then update status (and spec as needed).
-	if daemonset.Spec.Strategy.Canary != nil {
-		metaNow := metav1.NewTime(now)
+	var canary canaryState
+	metaNow := metav1.NewTime(now)
+	if len(pendingDependencies) > 0 {
+		// The upToDate replicaset rollout, canary included, only starts once the dependencies rollout is completed.
+		newDaemonset.Status.Canary = nil
+		newDaemonset.Status.State = datadoghqv1alpha1.ExtendedDaemonSetStatusStateWaitingOnDependency
+	} else if daemonset.Spec.Strategy.Canary != nil {
+		// If the deployment is in Canary phase, then update status (and spec as needed).
 		isCanaryPaused, pausedReason := IsCanaryDeploymentPaused(daemonset.GetAnnotations(), upToDate)
 		isCanaryFailed := IsCanaryDeploymentFailed(upToDate)
 		isCanaryActive := isCanaryActive(daemonset, current.GetName(), upToDate.GetName(), isCanaryFailed)
 		logger.V(1).Info("canary state", "isCanaryActive", isCanaryActive, "isCanaryFailed", isCanaryFailed, "isCanaryPaused", isCanaryPaused, "pausedReason", pausedReason)
+		canary = canaryState{active: isCanaryActive, failed: isCanaryFailed, paused: isCanaryPaused, pausedReason: pausedReason}
 
 		manageCanaryStatusConditions(&newDaemonset.Status, metaNow, isCanaryFailed, isCanaryPaused, pausedReason, upToDate.GetName())
 
 		manageStatus(&newDaemonset.Status, upToDate, isCanaryActive, isCanaryFailed, isCanaryPaused, pausedReason, daemonset)
 
 		if isCanaryFailed {
-			// Restore active replicaset template. Note: this requires a full daemonset update.
-			newDaemonset.Spec.Template = current.Spec.Template
-			updateDaemonsetSpec = true
+			// The canary deployments of a rollout group fail together.
+			if err := r.failRolloutGroupCanaries(logger, daemonset, now); err != nil {
+				return newDaemonset, reconcile.Result{}, err
+			}
+
+			if IsCanaryAutoRollbackEnabled(daemonset.Spec.Strategy.Canary) {
+				// Restore active replicaset template and node metadata, both part of the replicaset hash. Note: this requires a full daemonset update.
+				newDaemonset.Spec.Template = current.Spec.Template
+				newDaemonset.Spec.NodeMetadata = current.Spec.NodeMetadata
+				updateDaemonsetSpec = true
+
+				// Record the failed template to not deploy it again.
+				failedTemplateHash := upToDate.GetAnnotations()[string(datadoghqv1alpha1.MD5ExtendedDaemonSetAnnotationKey)]
+				if newDaemonset.Status.FailedTemplateHash != failedTemplateHash {
+					newDaemonset.Status.FailedTemplateHash = failedTemplateHash
+					r.recorder.Event(daemonset, corev1.EventTypeWarning, "Canary rollback", fmt.Sprintf("pod template rolled back to the ExtendedDaemonSetReplicaSet %s/%s", current.Namespace, current.Name))
+				}
+			}
 		}
 
 		if isCanaryActive {
@@ -278,6 +357,29 @@ func (r *Reconciler) updateInstanceWithCurrentRS(logger logr.Logger, now time.Ti
 		}
 	}
 
+	if current != nil && upToDate != nil {
+		r.manageCanaryRollbackCondition(daemonset, &newDaemonset.Status, metaNow, current.Name == upToDate.Name && isTemplateRolledBack(daemonset))
+	}
+
+	if upToDate != nil {
+		newDaemonset.Status.UpdatedNumberScheduled = upToDate.Status.UpdatedNumberScheduled
+	}
+	newDaemonset.Status.NumberUnavailable = max(newDaemonset.Status.Desired-newDaemonset.Status.Available, 0)
+
+	var result reconcile.Result
+	if current != nil && !canary.active && len(pendingDependencies) == 0 && len(daemonset.Spec.Strategy.RollingUpdate.PauseAt) > 0 {
+		updated, requeueAfter := manageRollingUpdateCheckpoints(logger, newDaemonset, current, now)
+		if updated {
+			updateDaemonsetAnnotations = true
+			newDaemonset.Status.State = nonCanaryState(newDaemonset.GetAnnotations(), current)
+		}
+		result.RequeueAfter = requeueAfter
+	}
+
+	if current != nil && upToDate != nil {
+		manageRolloutStatusConditions(&newDaemonset.Status, metaNow, newDaemonset, current, upToDate, canary, pendingDependencies)
+	}
+
 	// Check if newDaemonset differs from existing daemonset, and update if so
 	if !apiequality.Semantic.DeepEqual(daemonset, newDaemonset) {
 		logger.Info("Updating ExtendedDaemonSet status")
@@ -310,7 +412,7 @@ func (r *Reconciler) updateInstanceWithCurrentRS(logger logr.Logger, now time.Ti
 		newDaemonset = extendedDaemonsetCopy
 	}
 
-	return newDaemonset, reconcile.Result{}, nil
+	return newDaemonset, result, nil
 }
 
 func (r *Reconciler) selectNodes(logger logr.Logger, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, daemonsetSpec *datadoghqv1alpha1.ExtendedDaemonSetSpec, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, canaryStatus *datadoghqv1alpha1.ExtendedDaemonSetStatusCanary) error {
@@ -358,9 +460,15 @@ func (r *Reconciler) selectNodes(logger logr.Logger, daemonset *datadoghqv1alpha
 	if err != nil {
 		return err
 	}
+	optInNodes, optOutNodes, err := r.getCanaryOptInOutNodes(nodeList, daemonsetSpec.Strategy.Canary.NodeSelector != nil)
+	if err != nil {
+		return err
+	}
 	var currentNodes []string
 	if canaryStatus != nil {
-		currentNodes = canaryStatus.Nodes
+		currentNodes = slices.DeleteFunc(slices.Clone(canaryStatus.Nodes), func(name string) bool {
+			return optOutNodes[name]
+		})
 	}
 
 	nbCanaryPod, err := intstrutil.GetScaledValueFromIntOrPercent(daemonsetSpec.Strategy.Canary.Replicas, int(daemonset.Status.Desired), true)
@@ -368,9 +476,16 @@ func (r *Reconciler) selectNodes(logger logr.Logger, daemonset *datadoghqv1alpha
 		return err
 	}
 
-	// Sort node list to prioritize nodes with least number of restarts
-	sort.Slice(nodeList.Items, func(i, j int) bool {
-		return nodeNameRestarts[nodeList.Items[i].Name] < nodeNameRestarts[nodeList.Items[j].Name]
+	// Sort node list with the canary node selection policy, by default to prioritize nodes with least number of restarts
+	nodeList.Items = nodeselection.NewPolicy(daemonsetSpec.Strategy.Canary.NodeSelectionPolicy).Order(nodeList.Items, nodeNameRestarts)
+
+	// Prioritize the nodes already running the canary deployments of the rollout group members
+	groupCanaryNodes, err := r.getRolloutGroupCanaryNodes(daemonset)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(nodeList.Items, func(i, j int) bool {
+		return groupCanaryNodes[nodeList.Items[i].Name] && !groupCanaryNodes[nodeList.Items[j].Name]
 	})
 
 	// Filter Nodes Unschedulable
@@ -389,11 +504,18 @@ func (r *Reconciler) selectNodes(logger logr.Logger, daemonset *datadoghqv1alpha
 			continue
 		}
 
-		if !scheduler.CheckNodeFitness(logger.WithValues("filter", "Nodes Unschedulabled"), newPod, &node) {
+		if !scheduler.CheckNodeFitness(logger.WithValues("filter", "Nodes Unschedulabled"), newPod, &node).Fit() {
 			currentNodes = append(currentNodes[:id], currentNodes[id+1:]...)
 		}
 	}
 
+	// The opt-in nodes are always selected, even beyond the number of canary replicas
+	for _, node := range optInNodes {
+		if !slices.Contains(currentNodes, node.Name) && scheduler.CheckNodeFitness(logger.WithValues("filter", "Canary opt-in nodes"), newPod, &node).Fit() {
+			currentNodes = append(currentNodes, node.Name)
+		}
+	}
+
 	// Look for other nodes to use as canary
 	if len(currentNodes) < nbCanaryPod {
 		antiAffinityKeysValues := make(map[string]int)
@@ -442,7 +564,7 @@ func (r *Reconciler) selectNodes(logger logr.Logger, daemonset *datadoghqv1alpha
 				antiAffinityKeysValues[antiAffinityKeysValue]++
 			}
 
-			if scheduler.CheckNodeFitness(logger, newPod, &node) {
+			if scheduler.CheckNodeFitness(logger, newPod, &node).Fit() {
 				currentNodes = append(currentNodes, node.Name)
 			}
 			// All nodes are found. We can exit now!
@@ -462,6 +584,41 @@ func (r *Reconciler) selectNodes(logger logr.Logger, daemonset *datadoghqv1alpha
 	return nil
 }
 
+// getCanaryOptInOutNodes removes from the node list the nodes opted out of the canary deployments with the
+// canary-node label, and returns the opted-in nodes and the names of the opted-out nodes. The opted-in nodes
+// are selected even if they don't match the canary nodeSelector, so they are listed separately when it is set.
+func (r *Reconciler) getCanaryOptInOutNodes(nodeList *corev1.NodeList, hasNodeSelector bool) ([]corev1.Node, map[string]bool, error) {
+	if hasNodeSelector {
+		optInNodeList := &corev1.NodeList{}
+		if err := r.client.List(context.TODO(), optInNodeList, client.MatchingLabels{datadoghqv1alpha1.ExtendedDaemonSetCanaryNodeLabelKey: datadoghqv1alpha1.ValueStringTrue}); err != nil {
+			return nil, nil, err
+		}
+		for _, node := range optInNodeList.Items {
+			if !slices.ContainsFunc(nodeList.Items, func(n corev1.Node) bool { return n.Name == node.Name }) {
+				nodeList.Items = append(nodeList.Items, node)
+			}
+		}
+	}
+
+	var optInNodes []corev1.Node
+	optOutNodes := map[string]bool{}
+	candidates := make([]corev1.Node, 0, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		switch node.Labels[datadoghqv1alpha1.ExtendedDaemonSetCanaryNodeLabelKey] {
+		case datadoghqv1alpha1.ValueStringTrue:
+			optInNodes = append(optInNodes, node)
+		case datadoghqv1alpha1.ValueStringFalse:
+			optOutNodes[node.Name] = true
+
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	nodeList.Items = candidates
+
+	return optInNodes, optOutNodes, nil
+}
+
 func isCanaryActive(daemonset *datadoghqv1alpha1.ExtendedDaemonSet, activeERSName string, upToDateERSName string, isCanaryFailed bool) bool {
 	if daemonset.Spec.Strategy.Canary == nil {
 		return false
@@ -474,6 +631,22 @@ func isCanaryActive(daemonset *datadoghqv1alpha1.ExtendedDaemonSet, activeERSNam
 	return true
 }
 
+// manageCanaryRollbackCondition reports with the Canary-RolledBack condition that the pod template already failed
+// its canary deployment and is not deployed again. The event is only sent when the condition becomes true.
+func (r *Reconciler) manageCanaryRollbackCondition(daemonset *datadoghqv1alpha1.ExtendedDaemonSet, status *datadoghqv1alpha1.ExtendedDaemonSetStatus, now metav1.Time, templateRolledBack bool) {
+	if !templateRolledBack {
+		conditions.UpdateExtendedDaemonSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypeEDSCanaryRolledBack, corev1.ConditionFalse, "", "", nil)
+
+		return
+	}
+
+	msg := fmt.Sprintf("pod template %s already failed its canary deployment, it is not deployed again", daemonset.Status.FailedTemplateHash)
+	if !conditions.IsConditionTrue(&daemonset.Status, datadoghqv1alpha1.ConditionTypeEDSCanaryRolledBack) {
+		r.recorder.Event(daemonset, corev1.EventTypeWarning, "Canary rollback", msg)
+	}
+	conditions.UpdateExtendedDaemonSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypeEDSCanaryRolledBack, corev1.ConditionTrue, "TemplateRolledBack", msg, nil)
+}
+
 func manageCanaryStatusConditions(status *datadoghqv1alpha1.ExtendedDaemonSetStatus, now metav1.Time, isCanaryFailed bool, isCanaryPaused bool, pausedReason datadoghqv1alpha1.ExtendedDaemonSetStatusReason, ersName string) *datadoghqv1alpha1.ExtendedDaemonSetStatus {
 	updateOptions := &conditions.UpdateConditionOptions{
 		IgnoreFalseConditionIfNotExist: false,
@@ -525,13 +698,120 @@ func manageStatus(status *datadoghqv1alpha1.ExtendedDaemonSetStatus, upToDate *d
 	default:
 		// Canary deployment is no longer needed because it completed without issue
 		status.Canary = nil
-		status.State = nonCanaryState(daemonset.GetAnnotations())
+		status.State = nonCanaryState(daemonset.GetAnnotations(), upToDate)
 		status.Reason = ""
 	}
 
 	return status
 }
 
+// canaryState summarizes the canary deployment state computed during the reconcile loop.
+type canaryState struct {
+	active       bool
+	failed       bool
+	paused       bool
+	pausedReason datadoghqv1alpha1.ExtendedDaemonSetStatusReason
+}
+
+// manageRolloutStatusConditions maintains the standard Progressing, Available and Degraded conditions,
+// computed from the active and canary ExtendedDaemonSetReplicaSets status, for the tools that only
+// understand these condition types (kubectl wait, GitOps health checks).
+func manageRolloutStatusConditions(status *datadoghqv1alpha1.ExtendedDaemonSetStatus, now metav1.Time, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, current, upToDate *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, canary canaryState, pendingDependencies []string) {
+	updateOptions := &conditions.UpdateConditionOptions{
+		IgnoreFalseConditionIfNotExist: true,
+		UpdateReasonIfNotTrue:          true,
+	}
+
+	progressing, reason, msg := isRolloutProgressing(status, daemonset.GetAnnotations(), canary, pendingDependencies, upToDate.GetName())
+	conditions.UpdateExtendedDaemonSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypeEDSProgressing, conditions.BoolToCondition(progressing), reason, msg, updateOptions)
+
+	available, reason, msg := isRolloutAvailable(status, daemonset)
+	conditions.UpdateExtendedDaemonSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypeEDSAvailable, conditions.BoolToCondition(available), reason, msg, updateOptions)
+
+	degraded, reason, msg := isRolloutDegraded(canary, current, upToDate)
+	conditions.UpdateExtendedDaemonSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypeEDSDegraded, conditions.BoolToCondition(degraded), reason, msg, updateOptions)
+}
+
+func isRolloutProgressing(status *datadoghqv1alpha1.ExtendedDaemonSetStatus, dsAnnotations map[string]string, canary canaryState, pendingDependencies []string, upToDateName string) (bool, string, string) {
+	switch {
+	case len(pendingDependencies) > 0:
+		return false, string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonWaitingOnDependency), waitingOnDependenciesMessage(pendingDependencies)
+	case canary.failed:
+		return false, "CanaryFailed", "canary failed with ers: " + upToDateName
+	case canary.active && canary.paused:
+		return false, "CanaryPaused", "canary paused with ers: " + upToDateName
+	case canary.active:
+		return true, "CanaryRunning", "canary running with ers: " + upToDateName
+	}
+
+	if status.UpToDate >= status.Desired && status.Current <= status.UpToDate {
+		return false, "RolloutComplete", fmt.Sprintf("%d/%d pods up to date", status.UpToDate, status.Desired)
+	}
+
+	msg := fmt.Sprintf("%d/%d pods up to date", status.UpToDate, status.Desired)
+	switch {
+	case IsRolloutFrozen(dsAnnotations):
+		return false, "RolloutFrozen", msg
+	case IsRollingUpdatePaused(dsAnnotations):
+		return false, "RollingUpdatePaused", msg
+	case status.State == datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdatePaused && status.RollingUpdateBatch != nil:
+		return false, string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonWaitingForBatchApproval), fmt.Sprintf("%s, batch %d completed", msg, status.RollingUpdateBatch.Number)
+	case status.State == datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdateFailed:
+		return false, "RollingUpdateFailed", msg
+	case status.State == datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdatePaused:
+		return false, "RollingUpdatePaused", msg
+	}
+
+	return true, "RollingUpdateRunning", msg
+}
+
+func isRolloutAvailable(status *datadoghqv1alpha1.ExtendedDaemonSetStatus, daemonset *datadoghqv1alpha1.ExtendedDaemonSet) (bool, string, string) {
+	maxUnavailableValue := intstrutil.ValueOrDefault(daemonset.Spec.Strategy.RollingUpdate.MaxUnavailable, intstrutil.FromInt(1))
+	maxUnavailable, err := intstrutil.GetScaledValueFromIntOrPercent(maxUnavailableValue, int(status.Desired), true)
+	if err != nil {
+		return false, "InvalidMaxUnavailable", err.Error()
+	}
+
+	msg := fmt.Sprintf("%d/%d pods available, maxUnavailable: %d", status.Available, status.Desired, maxUnavailable)
+	if status.Available < status.Desired-int32(maxUnavailable) {
+		return false, "MinimumPodsUnavailable", msg
+	}
+
+	return true, "MinimumPodsAvailable", msg
+}
+
+func isRolloutDegraded(canary canaryState, current, upToDate *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) (bool, string, string) {
+	if canary.failed {
+		return true, "CanaryFailed", "canary failed with ers: " + upToDate.GetName()
+	}
+
+	if canary.active && canary.paused && ersconditions.IsConditionTrue(&upToDate.Status, datadoghqv1alpha1.ConditionTypeCanaryPaused) {
+		return true, "CanaryAutoPaused", fmt.Sprintf("canary automatically paused with ers: %s, reason: %s", upToDate.GetName(), canary.pausedReason)
+	}
+
+	if !canary.active && current != nil {
+		if cond := ersconditions.GetExtendedDaemonSetReplicaSetStatusCondition(&current.Status, datadoghqv1alpha1.ConditionTypeRollingUpdateFailed); cond != nil && cond.Status == corev1.ConditionTrue {
+			return true, "RollingUpdateFailed", fmt.Sprintf("rolling update failed with ers: %s, %s", current.GetName(), cond.Message)
+		}
+		if IsRollingUpdatePausedForUnhealthyPods(current) {
+			cond := ersconditions.GetExtendedDaemonSetReplicaSetStatusCondition(&current.Status, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused)
+
+			return true, "RollingUpdateAutoPaused", fmt.Sprintf("rolling update automatically paused with ers: %s, %s", current.GetName(), cond.Message)
+		}
+	}
+
+	for _, ers := range []*datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{current, upToDate} {
+		if ers == nil {
+			continue
+		}
+		if cond := ersconditions.GetExtendedDaemonSetReplicaSetStatusCondition(&ers.Status, datadoghqv1alpha1.ConditionTypeReconcileError); cond != nil && cond.Status == corev1.ConditionTrue {
+			return true, "ReplicaSetReconcileError", fmt.Sprintf("ers %s: %s", ers.GetName(), cond.Message)
+		}
+	}
+
+	return false, "AsExpected", ""
+}
+
 func getAntiAffinityKeysValue(node *corev1.Node, daemonsetSpec *datadoghqv1alpha1.ExtendedDaemonSetSpec) string {
 	values := make([]string, 0, len(daemonsetSpec.Strategy.Canary.NodeAntiAffinityKeys))
 	for _, antiAffinityKey := range daemonsetSpec.Strategy.Canary.NodeAntiAffinityKeys {
@@ -554,8 +834,9 @@ func newReplicaSetFromInstance(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) (
 			Annotations:  daemonset.Annotations,
 		},
 		Spec: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetSpec{
-			Selector: daemonset.Spec.Selector.DeepCopy(),
-			Template: *daemonset.Spec.Template.DeepCopy(),
+			Selector:     daemonset.Spec.Selector.DeepCopy(),
+			Template:     *daemonset.Spec.Template.DeepCopy(),
+			NodeMetadata: daemonset.Spec.NodeMetadata,
 		},
 	}
 
@@ -634,4 +915,8 @@ type podsCounterType struct {
 	Current   int32
 	Ready     int32
 	Available int32
+	// NumberMisscheduled is the number of misscheduled pods of all the replicasets.
+	NumberMisscheduled int32
+	// CollisionCount is the number of extra replicasets matching the ExtendedDaemonSet pod template.
+	CollisionCount int32
 }
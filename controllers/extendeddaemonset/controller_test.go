@@ -9,12 +9,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
 	cmp "github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +33,7 @@ import (
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	test "github.com/DataDog/extendeddaemonset/api/v1alpha1/test"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonset/conditions"
 	commontest "github.com/DataDog/extendeddaemonset/pkg/controller/test"
 	"github.com/DataDog/extendeddaemonset/pkg/controller/utils/comparison"
 )
@@ -94,6 +97,36 @@ func TestReconciler_selectNodes(t *testing.T) {
 	}
 	extendeddaemonset2 := test.NewExtendedDaemonSet("bar", "foo", options2)
 
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetList{})
+	groupLabels := map[string]string{datadoghqv1alpha1.ExtendedDaemonSetRolloutGroupLabelKey: "agents"}
+	options3 := &test.NewExtendedDaemonSetOptions{
+		Labels: groupLabels,
+		Canary: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+			Replicas: &intString1,
+		},
+		Status: options1.Status,
+	}
+	extendeddaemonset3 := test.NewExtendedDaemonSet("bar", "foo", options3)
+	groupMember := test.NewExtendedDaemonSet("bar", "foo-security", &test.NewExtendedDaemonSetOptions{
+		Labels: groupLabels,
+		Status: &datadoghqv1alpha1.ExtendedDaemonSetStatus{
+			Canary: &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{
+				ReplicaSet: "foo-security-2",
+				Nodes:      []string{"node3"},
+			},
+		},
+	})
+
+	optInNode := commontest.NewNode("node4", nodeOptions)
+	optInNode.Labels = map[string]string{datadoghqv1alpha1.ExtendedDaemonSetCanaryNodeLabelKey: datadoghqv1alpha1.ValueStringTrue}
+	optInNode2 := commontest.NewNode("node6", nodeOptions)
+	optInNode2.Labels = optInNode.Labels
+	optOutNode := commontest.NewNode("node5", nodeOptions)
+	optOutNode.Labels = map[string]string{
+		"canary": "true",
+		datadoghqv1alpha1.ExtendedDaemonSetCanaryNodeLabelKey: datadoghqv1alpha1.ValueStringFalse,
+	}
+
 	type fields struct {
 		client client.Client
 		scheme *runtime.Scheme
@@ -111,6 +144,26 @@ func TestReconciler_selectNodes(t *testing.T) {
 		wantErr  bool
 		wantFunc func(*datadoghqv1alpha1.ExtendedDaemonSetStatusCanary) bool
 	}{
+		{
+			name: "opt-in nodes selected beyond the replicas and the nodeSelector, opt-out node never selected",
+			fields: fields{
+				scheme: s,
+				client: fake.NewClientBuilder().WithStatusSubresource(&corev1.Node{}).WithObjects(node1, node2, optInNode, optOutNode, optInNode2).Build(),
+			},
+			args: args{
+				daemonset:  extendeddaemonset2,
+				spec:       &extendeddaemonset2.Spec,
+				replicaset: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{},
+				canaryStatus: &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{
+					ReplicaSet: "foo",
+					Nodes:      []string{"node5"},
+				},
+			},
+			wantErr: false,
+			wantFunc: func(canaryStatus *datadoghqv1alpha1.ExtendedDaemonSetStatusCanary) bool {
+				return len(canaryStatus.Nodes) == 2 && slices.Contains(canaryStatus.Nodes, "node4") && slices.Contains(canaryStatus.Nodes, "node6")
+			},
+		},
 		{
 			name: "enough nodes",
 			fields: fields{
@@ -182,6 +235,26 @@ func TestReconciler_selectNodes(t *testing.T) {
 				return len(canaryStatus.Nodes) == 1 && canaryStatus.Nodes[0] == "node2"
 			},
 		},
+		{
+			name: "rollout group canary nodes",
+			fields: fields{
+				scheme: s,
+				client: fake.NewClientBuilder().WithStatusSubresource(&corev1.Node{}).WithObjects(node1, node2, node3, extendeddaemonset3, groupMember).Build(),
+			},
+			args: args{
+				daemonset:  extendeddaemonset3,
+				spec:       &extendeddaemonset3.Spec,
+				replicaset: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{},
+				canaryStatus: &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{
+					ReplicaSet: "foo",
+					Nodes:      []string{},
+				},
+			},
+			wantErr: false,
+			wantFunc: func(canaryStatus *datadoghqv1alpha1.ExtendedDaemonSetStatusCanary) bool {
+				return len(canaryStatus.Nodes) == 1 && canaryStatus.Nodes[0] == "node3"
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -516,6 +589,13 @@ func Test_selectCurrentReplicaSet(t *testing.T) {
 		CreationTime: &creationTimeDaemonset,
 		Labels:       map[string]string{"foo-key": "old-value"},
 	})
+	replicassetUpToDateDoneFailed := replicassetUpToDateDone.DeepCopy()
+	replicassetUpToDateDoneFailed.Status.Conditions = []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+		{
+			Type:   datadoghqv1alpha1.ConditionTypeCanaryFailed,
+			Status: corev1.ConditionTrue,
+		},
+	}
 
 	daemonset := test.NewExtendedDaemonSet("bar", "foo", &test.NewExtendedDaemonSetOptions{Labels: map[string]string{"foo-key": "bar-value"}})
 	intString1 := intstr.FromInt(1)
@@ -656,6 +736,17 @@ func Test_selectCurrentReplicaSet(t *testing.T) {
 			want:  replicassetOld,
 			want1: -time.Minute,
 		},
+		{
+			name: "two RS, update to date, canary set, canary duration done, canary failed",
+			args: args{
+				daemonset:  daemonsetWithCanary,
+				upToDateRS: replicassetUpToDateDoneFailed,
+				activeRS:   replicassetOld,
+				now:        now,
+			},
+			want:  replicassetOld,
+			want1: 0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -862,13 +953,14 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 	{
 		daemonsetWithStatus.ResourceVersion = "1000"
 		daemonsetWithStatus.Status = datadoghqv1alpha1.ExtendedDaemonSetStatus{
-			ActiveReplicaSet: "current",
-			Desired:          4,
-			Current:          3,
-			Ready:            2,
-			Available:        1,
-			UpToDate:         3,
-			State:            "Running",
+			ActiveReplicaSet:  "current",
+			Desired:           4,
+			Current:           3,
+			Ready:             2,
+			Available:         1,
+			UpToDate:          3,
+			NumberUnavailable: 3,
+			State:             "Running",
 		}
 	}
 
@@ -888,6 +980,11 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 		}
 		daemonsetWithCanaryWithStatus.ResourceVersion = "1"
 	}
+	daemonsetWithCanaryWithStatusWanted := daemonsetWithCanaryWithStatus.DeepCopy()
+	{
+		// Only the Progressing, Available and Degraded conditions are added to the status.
+		daemonsetWithCanaryWithStatusWanted.ResourceVersion = "2"
+	}
 
 	daemonsetWithCanaryPaused := test.NewExtendedDaemonSet(
 		"bar",
@@ -903,12 +1000,13 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 				Duration: &metav1.Duration{Duration: 10 * time.Minute},
 			},
 			Status: &datadoghqv1alpha1.ExtendedDaemonSetStatus{
-				ActiveReplicaSet: "current",
-				Desired:          4,
-				Current:          3,
-				Ready:            2,
-				Available:        1,
-				UpToDate:         0, // replicassetUpToDate defined above has no replicas so UpToDate should be 0
+				ActiveReplicaSet:  "current",
+				Desired:           4,
+				Current:           3,
+				Ready:             2,
+				Available:         1,
+				UpToDate:          0, // replicassetUpToDate defined above has no replicas so UpToDate should be 0
+				NumberUnavailable: 3,
 				Canary: &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{
 					Nodes:      []string{"node1"},
 					ReplicaSet: "foo-1",
@@ -989,8 +1087,26 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 		}
 	}
 
+	daemonsetWaitingOnDependency := daemonsetWithCanaryWithStatus.DeepCopy()
+	{
+		daemonsetWaitingOnDependency.Spec.DependsOn = []string{"baz"}
+		daemonsetWaitingOnDependency.Status.Canary = nil
+		daemonsetWaitingOnDependency.Status.State = datadoghqv1alpha1.ExtendedDaemonSetStatusStateRunning
+	}
+	daemonsetWaitingOnDependencyWanted := daemonsetWaitingOnDependency.DeepCopy()
+	{
+		// The canary is not started, and the status still reflects the current replicaset.
+		daemonsetWaitingOnDependencyWanted.ResourceVersion = "2"
+		daemonsetWaitingOnDependencyWanted.Status.UpToDate = replicassetCurrent.Status.Current
+		daemonsetWaitingOnDependencyWanted.Status.State = datadoghqv1alpha1.ExtendedDaemonSetStatusStateWaitingOnDependency
+	}
+
 	daemonsetWithStatusAndAvailable := daemonsetWithStatus.DeepCopy()
 	daemonsetWithStatusAndAvailable.Status.Available = 5
+	daemonsetWithStatusAndAvailable.Status.NumberUnavailable = 0
+
+	daemonsetWithStatusAndMisscheduled := daemonsetWithStatus.DeepCopy()
+	daemonsetWithStatusAndMisscheduled.Status.NumberMisscheduled = 2
 
 	replicassetUpToDateWithFailedCondition := replicassetUpToDate.DeepCopy()
 	{
@@ -1005,6 +1121,25 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 		}
 	}
 
+	replicassetUpToDateWithFailedConditionAndHash := replicassetUpToDateWithFailedCondition.DeepCopy()
+	replicassetUpToDateWithFailedConditionAndHash.Annotations[string(datadoghqv1alpha1.MD5ExtendedDaemonSetAnnotationKey)] = "failed-hash"
+
+	daemonsetWithCanaryFailedNewTemplate := daemonsetWithCanaryFailedOldStatus.DeepCopy()
+	daemonsetWithCanaryFailedNewTemplate.Spec.Template.Spec.Containers = []corev1.Container{{Name: "foo", Image: "foo:new"}}
+	daemonsetWithCanaryFailedRolledBackWanted := daemonsetWithCanaryFailedWithoutAnnotationsWanted.DeepCopy()
+	daemonsetWithCanaryFailedRolledBackWanted.Status.FailedTemplateHash = "failed-hash"
+
+	daemonsetWithCanaryFailedNewNodeMetadata := daemonsetWithCanaryFailedOldStatus.DeepCopy()
+	daemonsetWithCanaryFailedNewNodeMetadata.Spec.NodeMetadata = []datadoghqv1alpha1.ExtendedDaemonSetNodeMetadata{{NodeLabel: "topology.kubernetes.io/zone", Env: "ZONE"}}
+
+	daemonsetWithCanaryFailedNoRollback := daemonsetWithCanaryFailedNewTemplate.DeepCopy()
+	daemonsetWithCanaryFailedNoRollback.Spec.Strategy.Canary.AutoRollback = datadoghqv1alpha1.NewBool(false)
+	daemonsetWithCanaryFailedNoRollbackWanted := daemonsetWithCanaryFailedWithoutAnnotationsWanted.DeepCopy()
+	{
+		daemonsetWithCanaryFailedNoRollbackWanted.ResourceVersion = "2"
+		daemonsetWithCanaryFailedNoRollbackWanted.Spec = *daemonsetWithCanaryFailedNoRollback.Spec.DeepCopy()
+	}
+
 	type fields struct {
 		client client.Client
 		scheme *runtime.Scheme
@@ -1015,6 +1150,8 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 		current     *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet
 		upToDate    *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet
 		podsCounter podsCounterType
+		// pendingDependencies contains the dependencies whose rollout is not completed.
+		pendingDependencies []string
 	}
 	tests := []struct {
 		now        time.Time
@@ -1024,6 +1161,9 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 		want       *datadoghqv1alpha1.ExtendedDaemonSet
 		wantResult reconcile.Result
 		wantErr    bool
+		// wantRolloutReasons contains the reason of the Progressing, Available and Degraded conditions,
+		// they are checked separately from the other conditions.
+		wantRolloutReasons map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string
 	}{
 		{
 			now:  now,
@@ -1065,13 +1205,18 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 			want:       daemonsetWithStatus,
 			wantResult: reconcile.Result{Requeue: false},
 			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "RollingUpdateRunning",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "AsExpected",
+			},
 		},
 		{
 			now:  now,
 			name: "current != upToDate; canary active => update",
 			fields: fields{
 				client: fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
-					WithObjects(daemonset, replicassetCurrent, replicassetUpToDate).Build(),
+					WithObjects(daemonsetWithCanaryWithStatus, replicassetCurrent, replicassetUpToDate).Build(),
 				scheme: s,
 			},
 			args: args{
@@ -1085,9 +1230,14 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 					Available: 1,
 				},
 			},
-			want:       daemonsetWithCanaryWithStatus,
+			want:       daemonsetWithCanaryWithStatusWanted,
 			wantResult: reconcile.Result{Requeue: false},
 			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "CanaryRunning",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "AsExpected",
+			},
 		},
 		{
 			now:  now,
@@ -1111,6 +1261,11 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 			want:       daemonsetWithCanaryPausedWanted,
 			wantResult: reconcile.Result{Requeue: false},
 			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "CanaryPaused",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "AsExpected",
+			},
 		},
 		{
 			now:  now,
@@ -1134,6 +1289,11 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 			want:       daemonsetWithCanaryPausedWithoutAnnotationsWanted,
 			wantResult: reconcile.Result{Requeue: false},
 			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "CanaryPaused",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "CanaryAutoPaused",
+			},
 		},
 		{
 			now:  now,
@@ -1157,6 +1317,124 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 			want:       daemonsetWithCanaryFailedWithoutAnnotationsWanted,
 			wantResult: reconcile.Result{Requeue: false},
 			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "CanaryFailed",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "CanaryFailed",
+			},
+		},
+		{
+			now:  now,
+			name: "canary failed, autoRollback => template rolled back, failed template recorded",
+			fields: fields{
+				client: fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
+					WithObjects(daemonsetWithCanaryFailedNewTemplate, replicassetCurrent, replicassetUpToDateWithFailedConditionAndHash).Build(),
+				scheme: s,
+			},
+			args: args{
+				logger:    log,
+				daemonset: daemonsetWithCanaryFailedNewTemplate,
+				current:   replicassetCurrent,
+				upToDate:  replicassetUpToDateWithFailedConditionAndHash,
+				podsCounter: podsCounterType{
+					Current:   3,
+					Ready:     2,
+					Available: 1,
+				},
+			},
+			want:       daemonsetWithCanaryFailedRolledBackWanted,
+			wantResult: reconcile.Result{Requeue: false},
+			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "CanaryFailed",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "CanaryFailed",
+			},
+		},
+		{
+			now:  now,
+			name: "canary failed on a nodeMetadata change, autoRollback => nodeMetadata rolled back, failed template recorded",
+			fields: fields{
+				client: fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
+					WithObjects(daemonsetWithCanaryFailedNewNodeMetadata, replicassetCurrent, replicassetUpToDateWithFailedConditionAndHash).Build(),
+				scheme: s,
+			},
+			args: args{
+				logger:    log,
+				daemonset: daemonsetWithCanaryFailedNewNodeMetadata,
+				current:   replicassetCurrent,
+				upToDate:  replicassetUpToDateWithFailedConditionAndHash,
+				podsCounter: podsCounterType{
+					Current:   3,
+					Ready:     2,
+					Available: 1,
+				},
+			},
+			want:       daemonsetWithCanaryFailedRolledBackWanted,
+			wantResult: reconcile.Result{Requeue: false},
+			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "CanaryFailed",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "CanaryFailed",
+			},
+		},
+		{
+			now:  now,
+			name: "canary failed, autoRollback disabled => template kept",
+			fields: fields{
+				client: fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
+					WithObjects(daemonsetWithCanaryFailedNoRollback, replicassetCurrent, replicassetUpToDateWithFailedConditionAndHash).Build(),
+				scheme: s,
+			},
+			args: args{
+				logger:    log,
+				daemonset: daemonsetWithCanaryFailedNoRollback,
+				current:   replicassetCurrent,
+				upToDate:  replicassetUpToDateWithFailedConditionAndHash,
+				podsCounter: podsCounterType{
+					Current:   3,
+					Ready:     2,
+					Available: 1,
+				},
+			},
+			want:       daemonsetWithCanaryFailedNoRollbackWanted,
+			wantResult: reconcile.Result{Requeue: false},
+			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "CanaryFailed",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "CanaryFailed",
+			},
+		},
+		{
+			now:  now,
+			name: "current != upToDate; waiting on dependency => canary not started",
+			fields: fields{
+				client: fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
+					WithObjects(daemonsetWaitingOnDependency, replicassetCurrent, replicassetUpToDate).Build(),
+				scheme: s,
+			},
+			args: args{
+				logger:    testLogger,
+				daemonset: daemonsetWaitingOnDependency,
+				current:   replicassetCurrent,
+				upToDate:  replicassetUpToDate,
+				podsCounter: podsCounterType{
+					Current:   3,
+					Ready:     2,
+					Available: 1,
+				},
+				pendingDependencies: []string{"baz"},
+			},
+			want:       daemonsetWaitingOnDependencyWanted,
+			wantResult: reconcile.Result{Requeue: false},
+			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "WaitingOnDependency",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "AsExpected",
+			},
 		},
 		{
 			now:  now,
@@ -1184,6 +1462,42 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 			want:       daemonsetWithStatusAndAvailable, // Its "Available" field equals podsCounter.Available
 			wantResult: reconcile.Result{Requeue: false},
 			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "RollingUpdateRunning",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsAvailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "AsExpected",
+			},
+		},
+		{
+			now:  now,
+			name: "\"numberMisscheduled\" summed over all the replicasets",
+			fields: fields{
+				client: fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
+					WithObjects(daemonset, replicassetCurrent, replicassetUpToDate).Build(),
+				scheme: s,
+			},
+			args: args{
+				logger:    testLogger,
+				daemonset: daemonset,
+				current:   replicassetCurrent,
+				upToDate:  replicassetCurrent,
+				podsCounter: podsCounterType{
+					Current:   3,
+					Ready:     2,
+					Available: 1,
+					// The misscheduled pods of the other replicasets are also counted,
+					// not only the ones of the current replicaset.
+					NumberMisscheduled: 2,
+				},
+			},
+			want:       daemonsetWithStatusAndMisscheduled,
+			wantResult: reconcile.Result{Requeue: false},
+			wantErr:    false,
+			wantRolloutReasons: map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{
+				datadoghqv1alpha1.ConditionTypeEDSProgressing: "RollingUpdateRunning",
+				datadoghqv1alpha1.ConditionTypeEDSAvailable:   "MinimumPodsUnavailable",
+				datadoghqv1alpha1.ConditionTypeEDSDegraded:    "AsExpected",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -1193,13 +1507,29 @@ func TestReconcileExtendedDaemonSet_updateInstanceWithCurrentRS(t *testing.T) {
 				scheme:   tt.fields.scheme,
 				recorder: eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "TestReconciler_cleanupReplicaSet"}),
 			}
-			got, got1, err := r.updateInstanceWithCurrentRS(tt.args.logger, tt.now, tt.args.daemonset, tt.args.current, tt.args.upToDate, tt.args.podsCounter)
+			got, got1, err := r.updateInstanceWithCurrentRS(tt.args.logger, tt.now, tt.args.daemonset, tt.args.current, tt.args.upToDate, tt.args.podsCounter, tt.args.pendingDependencies)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ReconcileExtendedDaemonSet.updateInstanceWithCurrentRS() error = %v, wantErr %v", err, tt.wantErr)
 
 				return
 			}
 
+			gotRolloutReasons := map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{}
+			var otherConditions []datadoghqv1alpha1.ExtendedDaemonSetCondition
+			for _, cond := range got.Status.Conditions {
+				switch cond.Type {
+				case datadoghqv1alpha1.ConditionTypeEDSProgressing, datadoghqv1alpha1.ConditionTypeEDSAvailable, datadoghqv1alpha1.ConditionTypeEDSDegraded:
+					gotRolloutReasons[cond.Type] = cond.Reason
+				default:
+					otherConditions = append(otherConditions, cond)
+				}
+			}
+			if tt.wantRolloutReasons == nil {
+				tt.wantRolloutReasons = map[datadoghqv1alpha1.ExtendedDaemonSetConditionType]string{}
+			}
+			assert.Equal(t, tt.wantRolloutReasons, gotRolloutReasons, "ReconcileExtendedDaemonSet.updateInstanceWithCurrentRS().rolloutConditions")
+			got.Status.Conditions = otherConditions
+
 			if len(tt.want.Status.Conditions) > 0 {
 				// https://github.com/kubernetes-sigs/controller-runtime/blob/735b6073bb253c0449bfcf6641855dcf2118bb15/pkg/client/fake/client.go#L1037-L1053
 				// Some of time.Time info is lost here due to marshaling to json and unmarshaling.
@@ -1392,6 +1722,62 @@ func TestReconciler_Reconcile(t *testing.T) {
 					return errors.New("len(replicasetList.Items) is not equal to 1")
 				}
 
+				return nil
+			},
+		},
+		{
+			name: "ExtendedDaemonset found and defaulted, pod template rolled back after a canary failure => replicaset not created",
+			fields: fields{
+				client:   fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}).Build(),
+				scheme:   s,
+				recorder: recorder,
+			},
+			args: args{
+				request: newRequest("bar", "foo"),
+				loadFunc: func(c client.Client) {
+					dd := test.NewExtendedDaemonSet("bar", "foo", &test.NewExtendedDaemonSetOptions{
+						Labels: map[string]string{"foo-key": "bar-value"},
+						Canary: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanary{},
+						Status: &datadoghqv1alpha1.ExtendedDaemonSetStatus{ActiveReplicaSet: "foo-old"},
+					})
+					dd = datadoghqv1alpha1.DefaultExtendedDaemonSet(dd, datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationModeAuto)
+					dd.Status.FailedTemplateHash, _ = comparison.GenerateMD5PodTemplateSpec(&dd.Spec.Template)
+
+					rsOptions := &test.NewExtendedDaemonSetReplicaSetOptions{
+						Labels:      map[string]string{"foo-key": "old-value", datadoghqv1alpha1.ExtendedDaemonSetNameLabelKey: "foo"},
+						Annotations: map[string]string{string(datadoghqv1alpha1.MD5ExtendedDaemonSetAnnotationKey): "oldhash"},
+					}
+					rs := test.NewExtendedDaemonSetReplicaSet("bar", "foo-old", rsOptions)
+
+					_ = c.Create(t.Context(), dd)
+					_ = c.Create(t.Context(), rs)
+				},
+			},
+			want:    reconcile.Result{},
+			wantErr: false,
+			wantFunc: func(c client.Client) error {
+				replicasetList := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetList{}
+				listOptions := []client.ListOption{
+					client.InNamespace("bar"),
+				}
+				if err := c.List(t.Context(), replicasetList, listOptions...); err != nil {
+					return err
+				}
+				if len(replicasetList.Items) != 1 {
+					return errors.New("len(replicasetList.Items) is not equal to 1")
+				}
+
+				eds := &datadoghqv1alpha1.ExtendedDaemonSet{}
+				if err := c.Get(t.Context(), types.NamespacedName{Namespace: "bar", Name: "foo"}, eds); err != nil {
+					return err
+				}
+				if eds.Status.ActiveReplicaSet != "foo-old" {
+					return fmt.Errorf("eds.Status.ActiveReplicaSet should be 'foo-old', current: %s", eds.Status.ActiveReplicaSet)
+				}
+				if !conditions.IsConditionTrue(&eds.Status, datadoghqv1alpha1.ConditionTypeEDSCanaryRolledBack) {
+					return errors.New("eds.Status Canary-RolledBack condition should be true")
+				}
+
 				return nil
 			},
 		},
@@ -1534,6 +1920,62 @@ func Test_isCanaryActive(t *testing.T) {
 	}
 }
 
+func TestReconciler_manageCanaryRollbackCondition(t *testing.T) {
+	now := metav1.Now()
+	rolledBackStatus := &datadoghqv1alpha1.ExtendedDaemonSetStatus{FailedTemplateHash: "foo-hash"}
+	conditions.UpdateExtendedDaemonSetStatusCondition(rolledBackStatus, now, datadoghqv1alpha1.ConditionTypeEDSCanaryRolledBack, corev1.ConditionTrue, "TemplateRolledBack", "", nil)
+
+	tests := []struct {
+		name               string
+		status             *datadoghqv1alpha1.ExtendedDaemonSetStatus
+		templateRolledBack bool
+		wantCondition      corev1.ConditionStatus
+		wantEvent          bool
+	}{
+		{
+			name:   "template not rolled back, condition not set",
+			status: &datadoghqv1alpha1.ExtendedDaemonSetStatus{},
+		},
+		{
+			name:               "template rolled back => condition set and event sent",
+			status:             &datadoghqv1alpha1.ExtendedDaemonSetStatus{FailedTemplateHash: "foo-hash"},
+			templateRolledBack: true,
+			wantCondition:      corev1.ConditionTrue,
+			wantEvent:          true,
+		},
+		{
+			name:               "template still rolled back => no new event",
+			status:             rolledBackStatus,
+			templateRolledBack: true,
+			wantCondition:      corev1.ConditionTrue,
+		},
+		{
+			name:          "template changed => condition cleared",
+			status:        rolledBackStatus,
+			wantCondition: corev1.ConditionFalse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{recorder: recorder, log: testLogger}
+			daemonset := test.NewExtendedDaemonSet("bar", "foo", &test.NewExtendedDaemonSetOptions{Status: tt.status.DeepCopy()})
+			newStatus := daemonset.Status.DeepCopy()
+
+			r.manageCanaryRollbackCondition(daemonset, newStatus, now, tt.templateRolledBack)
+
+			cond := conditions.GetExtendedDaemonSetStatusCondition(newStatus, datadoghqv1alpha1.ConditionTypeEDSCanaryRolledBack)
+			if tt.wantCondition == "" {
+				assert.Nil(t, cond)
+			} else {
+				require.NotNil(t, cond)
+				assert.Equal(t, tt.wantCondition, cond.Status)
+			}
+			assert.Equal(t, tt.wantEvent, len(recorder.Events) == 1)
+		})
+	}
+}
+
 func Test_manageStatus(t *testing.T) {
 	ns := "bar"
 	edsName := "foo"
@@ -1674,3 +2116,108 @@ func Test_manageStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_isRolloutProgressing(t *testing.T) {
+	rollingStatus := &datadoghqv1alpha1.ExtendedDaemonSetStatus{Desired: 4, Current: 4, UpToDate: 2}
+	completeStatus := &datadoghqv1alpha1.ExtendedDaemonSetStatus{Desired: 4, Current: 4, UpToDate: 4}
+
+	tests := []struct {
+		name        string
+		status      *datadoghqv1alpha1.ExtendedDaemonSetStatus
+		annotations map[string]string
+		canary      canaryState
+		// pendingDependencies contains the dependencies whose rollout is not completed.
+		pendingDependencies []string
+		want                bool
+		wantReason          string
+	}{
+		{
+			name:       "rolling update running",
+			status:     rollingStatus,
+			want:       true,
+			wantReason: "RollingUpdateRunning",
+		},
+		{
+			name:       "rollout complete",
+			status:     completeStatus,
+			want:       false,
+			wantReason: "RolloutComplete",
+		},
+		{
+			name:        "rollout complete, paused annotation ignored",
+			status:      completeStatus,
+			annotations: map[string]string{datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: "true"},
+			want:        false,
+			wantReason:  "RolloutComplete",
+		},
+		{
+			name:        "rolling update paused",
+			status:      rollingStatus,
+			annotations: map[string]string{datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: "true"},
+			want:        false,
+			wantReason:  "RollingUpdatePaused",
+		},
+		{
+			name: "rolling update waiting for batch approval",
+			status: &datadoghqv1alpha1.ExtendedDaemonSetStatus{
+				Desired:            4,
+				Current:            4,
+				UpToDate:           2,
+				State:              datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdatePaused,
+				RollingUpdateBatch: &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Completed: true},
+			},
+			want:       false,
+			wantReason: "WaitingForBatchApproval",
+		},
+		{
+			name:       "rolling update paused by the health gate",
+			status:     &datadoghqv1alpha1.ExtendedDaemonSetStatus{Desired: 4, Current: 4, UpToDate: 2, State: datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdatePaused},
+			want:       false,
+			wantReason: "RollingUpdatePaused",
+		},
+		{
+			name:       "rolling update failed by the health gate",
+			status:     &datadoghqv1alpha1.ExtendedDaemonSetStatus{Desired: 4, Current: 4, UpToDate: 2, State: datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdateFailed},
+			want:       false,
+			wantReason: "RollingUpdateFailed",
+		},
+		{
+			name: "rollout frozen and paused",
+			annotations: map[string]string{
+				datadoghqv1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey:       "true",
+				datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: "true",
+			},
+			status:     rollingStatus,
+			want:       false,
+			wantReason: "RolloutFrozen",
+		},
+		{
+			name:       "canary running",
+			status:     rollingStatus,
+			canary:     canaryState{active: true},
+			want:       true,
+			wantReason: "CanaryRunning",
+		},
+		{
+			name:       "canary failed",
+			status:     completeStatus,
+			canary:     canaryState{failed: true},
+			want:       false,
+			wantReason: "CanaryFailed",
+		},
+		{
+			name:                "waiting on dependency",
+			status:              completeStatus,
+			pendingDependencies: []string{"baz"},
+			want:                false,
+			wantReason:          "WaitingOnDependency",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotReason, _ := isRolloutProgressing(tt.status, tt.annotations, tt.canary, tt.pendingDependencies, "foo-1")
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantReason, gotReason)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// shouldWaitOnDependencies returns true if the rollout of the upToDate replicaset has not started yet, and should
+// only start once the rollout of the ExtendedDaemonSets listed in spec.dependsOn is completed.
+// A rollout already started (canary or rolling update) is never held back.
+func shouldWaitOnDependencies(daemonset *datadoghqv1alpha1.ExtendedDaemonSet, activeRS, upToDateRS *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) bool {
+	// The replicasets are compared by name: the upToDate replicaset is a copy of the listed one.
+	if len(daemonset.Spec.DependsOn) == 0 || activeRS == nil || activeRS.Name == upToDateRS.Name {
+		return false
+	}
+
+	return daemonset.Status.Canary == nil || daemonset.Status.Canary.ReplicaSet != upToDateRS.Name
+}
+
+// getPendingDependencies returns the ExtendedDaemonSets listed in spec.dependsOn whose rollout is not completed yet.
+func (r *Reconciler) getPendingDependencies(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) ([]string, error) {
+	var pending []string
+	for _, name := range daemonset.Spec.DependsOn {
+		if name == daemonset.Name {
+			continue
+		}
+
+		dependency := &datadoghqv1alpha1.ExtendedDaemonSet{}
+		err := r.client.Get(context.TODO(), client.ObjectKey{Namespace: daemonset.Namespace, Name: name}, dependency)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				pending = append(pending, name+" (not found)")
+
+				continue
+			}
+
+			return nil, fmt.Errorf("unable to get the ExtendedDaemonSet dependency %s, err: %w", name, err)
+		}
+
+		if !isRolloutCompleted(dependency) {
+			pending = append(pending, name)
+		}
+	}
+
+	return pending, nil
+}
+
+// isRolloutCompleted returns true if the last generation of the ExtendedDaemonSet is observed, and all its pods
+// run the active replicaset template, without any canary deployment in progress or waiting on its own dependencies.
+func isRolloutCompleted(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) bool {
+	status := &daemonset.Status
+	if status.ObservedGeneration < daemonset.Generation || status.ActiveReplicaSet == "" || status.Canary != nil {
+		return false
+	}
+
+	if status.State == datadoghqv1alpha1.ExtendedDaemonSetStatusStateWaitingOnDependency {
+		return false
+	}
+
+	return status.UpToDate >= status.Desired && status.Current <= status.UpToDate
+}
+
+func waitingOnDependenciesMessage(pendingDependencies []string) string {
+	return "waiting on dependency " + strings.Join(pendingDependencies, ", ")
+}
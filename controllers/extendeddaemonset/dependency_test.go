@@ -0,0 +1,168 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func Test_getPendingDependencies(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSet{})
+
+	newDependency := func(name string, status datadoghqv1alpha1.ExtendedDaemonSetStatus) *datadoghqv1alpha1.ExtendedDaemonSet {
+		return &datadoghqv1alpha1.ExtendedDaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: name, Generation: 2},
+			Status:     status,
+		}
+	}
+	completed := datadoghqv1alpha1.ExtendedDaemonSetStatus{ObservedGeneration: 2, ActiveReplicaSet: "rs-1", Desired: 3, Current: 3, UpToDate: 3}
+	rollingUpdate := completed
+	rollingUpdate.UpToDate = 1
+	canary := completed
+	canary.Canary = &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{ReplicaSet: "rs-2"}
+	notObserved := completed
+	notObserved.ObservedGeneration = 1
+	waiting := completed
+	waiting.State = datadoghqv1alpha1.ExtendedDaemonSetStatusStateWaitingOnDependency
+
+	tests := []struct {
+		name      string
+		dependsOn []string
+		objects   []*datadoghqv1alpha1.ExtendedDaemonSet
+		want      []string
+	}{
+		{
+			name:      "dependency rollout completed",
+			dependsOn: []string{"dep"},
+			objects:   []*datadoghqv1alpha1.ExtendedDaemonSet{newDependency("dep", completed)},
+		},
+		{
+			name:      "dependency rolling update in progress",
+			dependsOn: []string{"dep"},
+			objects:   []*datadoghqv1alpha1.ExtendedDaemonSet{newDependency("dep", rollingUpdate)},
+			want:      []string{"dep"},
+		},
+		{
+			name:      "dependency canary in progress",
+			dependsOn: []string{"dep"},
+			objects:   []*datadoghqv1alpha1.ExtendedDaemonSet{newDependency("dep", canary)},
+			want:      []string{"dep"},
+		},
+		{
+			name:      "dependency spec update not observed yet",
+			dependsOn: []string{"dep"},
+			objects:   []*datadoghqv1alpha1.ExtendedDaemonSet{newDependency("dep", notObserved)},
+			want:      []string{"dep"},
+		},
+		{
+			name:      "dependency waiting on its own dependencies",
+			dependsOn: []string{"dep"},
+			objects:   []*datadoghqv1alpha1.ExtendedDaemonSet{newDependency("dep", waiting)},
+			want:      []string{"dep"},
+		},
+		{
+			name:      "dependency not found",
+			dependsOn: []string{"dep", "other"},
+			objects:   []*datadoghqv1alpha1.ExtendedDaemonSet{newDependency("dep", completed)},
+			want:      []string{"other (not found)"},
+		},
+		{
+			name:      "dependency on itself is ignored",
+			dependsOn: []string{"foo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(s)
+			for _, obj := range tt.objects {
+				builder = builder.WithObjects(obj)
+			}
+			r := &Reconciler{client: builder.Build()}
+
+			daemonset := &datadoghqv1alpha1.ExtendedDaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"},
+				Spec:       datadoghqv1alpha1.ExtendedDaemonSetSpec{DependsOn: tt.dependsOn},
+			}
+			got, err := r.getPendingDependencies(daemonset)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_shouldWaitOnDependencies(t *testing.T) {
+	activeRS := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "foo-1"}}
+	upToDateRS := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "foo-2"}}
+
+	tests := []struct {
+		name      string
+		dependsOn []string
+		canary    *datadoghqv1alpha1.ExtendedDaemonSetStatusCanary
+		activeRS  *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet
+		want      bool
+	}{
+		{
+			name:     "no dependency",
+			activeRS: activeRS,
+			want:     false,
+		},
+		{
+			name:      "new replicaset not started",
+			dependsOn: []string{"dep"},
+			activeRS:  activeRS,
+			want:      true,
+		},
+		{
+			name:      "first deployment",
+			dependsOn: []string{"dep"},
+			want:      false,
+		},
+		{
+			name:      "rollout completed",
+			dependsOn: []string{"dep"},
+			activeRS:  upToDateRS,
+			want:      false,
+		},
+		{
+			name:      "rollout completed, distinct copies of the replicaset",
+			dependsOn: []string{"dep"},
+			activeRS:  upToDateRS.DeepCopy(),
+			want:      false,
+		},
+		{
+			name:      "canary already started",
+			dependsOn: []string{"dep"},
+			canary:    &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{ReplicaSet: "foo-2"},
+			activeRS:  activeRS,
+			want:      false,
+		},
+		{
+			name:      "canary of a previous replicaset",
+			dependsOn: []string{"dep"},
+			canary:    &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{ReplicaSet: "foo-0"},
+			activeRS:  activeRS,
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemonset := &datadoghqv1alpha1.ExtendedDaemonSet{
+				Spec:   datadoghqv1alpha1.ExtendedDaemonSetSpec{DependsOn: tt.dependsOn},
+				Status: datadoghqv1alpha1.ExtendedDaemonSetStatus{Canary: tt.canary},
+			}
+			assert.Equal(t, tt.want, shouldWaitOnDependencies(daemonset, tt.activeRS, upToDateRS))
+		})
+	}
+}
@@ -24,10 +24,15 @@ const (
 	extendeddaemonsetStatusAvailable                = "eds_status_available"
 	extendeddaemonsetStatusUpToDate                 = "eds_status_uptodate"
 	extendeddaemonsetStatusIgnoredUnresponsiveNodes = "eds_status_ignored_unresponsive_nodes"
+	extendeddaemonsetStatusNumberMisscheduled       = "eds_status_number_misscheduled"
+	extendeddaemonsetStatusUpdatedNumberScheduled   = "eds_status_updated_number_scheduled"
+	extendeddaemonsetStatusNumberUnavailable        = "eds_status_number_unavailable"
+	extendeddaemonsetStatusCollisionCount           = "eds_status_collision_count"
 	extendeddaemonsetStatusCanaryActivated          = "eds_status_canary_activated"
 	extendeddaemonsetStatusCanaryNumberOfNodes      = "eds_status_canary_node_number"
 	extendeddaemonsetStatusCanaryPaused             = "eds_status_canary_paused"
 	extendeddaemonsetStatusRollingUpdatePaused      = "eds_status_rolling_update_paused"
+	extendeddaemonsetStatusRollingUpdateFailed      = "eds_status_rolling_update_failed"
 	extendeddaemonsetStatusRolloutFrozen            = "eds_status_rollout_frozen"
 	extendeddaemonsetLabels                         = "eds_labels"
 )
@@ -197,6 +202,82 @@ func generateMetricFamilies() []generator.FamilyGenerator {
 				}
 			},
 		},
+		{
+			Name: extendeddaemonsetStatusNumberMisscheduled,
+			Type: ksmetric.Gauge,
+			Help: "The number of nodes running a daemon pod but are not supposed to.",
+			GenerateFunc: func(obj any) *ksmetric.Family {
+				eds := obj.(*datadoghqv1alpha1.ExtendedDaemonSet)
+				labelKeys, labelValues := utils.GetLabelsValues(&eds.ObjectMeta)
+
+				return &ksmetric.Family{
+					Metrics: []*ksmetric.Metric{
+						{
+							Value:       float64(eds.Status.NumberMisscheduled),
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: extendeddaemonsetStatusUpdatedNumberScheduled,
+			Type: ksmetric.Gauge,
+			Help: "The total number of nodes that are running updated daemon pod",
+			GenerateFunc: func(obj any) *ksmetric.Family {
+				eds := obj.(*datadoghqv1alpha1.ExtendedDaemonSet)
+				labelKeys, labelValues := utils.GetLabelsValues(&eds.ObjectMeta)
+
+				return &ksmetric.Family{
+					Metrics: []*ksmetric.Metric{
+						{
+							Value:       float64(eds.Status.UpdatedNumberScheduled),
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: extendeddaemonsetStatusNumberUnavailable,
+			Type: ksmetric.Gauge,
+			Help: "The number of nodes that should be running the daemon pod and have none of the daemon pod running and available",
+			GenerateFunc: func(obj any) *ksmetric.Family {
+				eds := obj.(*datadoghqv1alpha1.ExtendedDaemonSet)
+				labelKeys, labelValues := utils.GetLabelsValues(&eds.ObjectMeta)
+
+				return &ksmetric.Family{
+					Metrics: []*ksmetric.Metric{
+						{
+							Value:       float64(eds.Status.NumberUnavailable),
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: extendeddaemonsetStatusCollisionCount,
+			Type: ksmetric.Gauge,
+			Help: "The number of extra replicasets whose pod template hash collides with the up-to-date one",
+			GenerateFunc: func(obj any) *ksmetric.Family {
+				eds := obj.(*datadoghqv1alpha1.ExtendedDaemonSet)
+				labelKeys, labelValues := utils.GetLabelsValues(&eds.ObjectMeta)
+
+				return &ksmetric.Family{
+					Metrics: []*ksmetric.Metric{
+						{
+							Value:       float64(eds.Status.CollisionCount),
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+						},
+					},
+				}
+			},
+		},
 		{
 			Name: extendeddaemonsetStatusCanaryActivated,
 			Type: ksmetric.Gauge,
@@ -304,6 +385,30 @@ func generateMetricFamilies() []generator.FamilyGenerator {
 				}
 			},
 		},
+		{
+			Name: extendeddaemonsetStatusRollingUpdateFailed,
+			Type: ksmetric.Gauge,
+			Help: "The failed state of a rolling update, 1 if failed by its health gate, 0 otherwise",
+			GenerateFunc: func(obj any) *ksmetric.Family {
+				eds := obj.(*datadoghqv1alpha1.ExtendedDaemonSet)
+				labelKeys, labelValues := utils.GetLabelsValues(&eds.ObjectMeta)
+				val := float64(0)
+
+				if eds.Status.State == datadoghqv1alpha1.ExtendedDaemonSetStatusStateRollingUpdateFailed {
+					val = 1
+				}
+
+				return &ksmetric.Family{
+					Metrics: []*ksmetric.Metric{
+						{
+							Value:       val,
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+						},
+					},
+				}
+			},
+		},
 		{
 			Name: extendeddaemonsetStatusRolloutFrozen,
 			Type: ksmetric.Gauge,
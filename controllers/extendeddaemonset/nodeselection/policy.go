@@ -0,0 +1,150 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// Package nodeselection contains the policies used to select the canary nodes of an ExtendedDaemonSet.
+package nodeselection
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// Policy orders the candidate canary nodes.
+type Policy interface {
+	// Order returns the candidate nodes by order of preference: the first nodes are selected first.
+	// The nodes that must not be selected are removed. The nodes slice is not modified.
+	Order(nodes []corev1.Node, nodeRestarts map[string]int) []corev1.Node
+}
+
+// NewPolicy returns the Policy of the canary nodeSelectionPolicy. The leastRestarts policy is used if it is not set.
+func NewPolicy(policy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy) Policy {
+	if policy == nil {
+		return &leastRestarts{}
+	}
+
+	switch policy.Type {
+	case datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyRandom:
+		seed := time.Now().UnixNano()
+		if policy.Seed != nil {
+			seed = *policy.Seed
+		}
+
+		return &random{seed: seed}
+	case datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyOldestNodes:
+		return &creationTime{newestFirst: false}
+	case datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyNewestNodes:
+		return &creationTime{newestFirst: true}
+	case datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyExplicit:
+		return &explicit{nodeNames: policy.NodeNames}
+	case datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel:
+		return &preferLabel{labels: policy.PreferredLabels}
+	default:
+		return &leastRestarts{}
+	}
+}
+
+// leastRestarts selects first the nodes where the ExtendedDaemonSet pods restarted the least.
+type leastRestarts struct{}
+
+func (p *leastRestarts) Order(nodes []corev1.Node, nodeRestarts map[string]int) []corev1.Node {
+	ordered := append([]corev1.Node{}, nodes...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return nodeRestarts[ordered[i].Name] < nodeRestarts[ordered[j].Name]
+	})
+
+	return ordered
+}
+
+// random shuffles the nodes. The nodes are first sorted by name, so the same seed always gives the same order.
+type random struct {
+	seed int64
+}
+
+func (p *random) Order(nodes []corev1.Node, _ map[string]int) []corev1.Node {
+	ordered := sortByName(nodes)
+	r := rand.New(rand.NewSource(p.seed))
+	r.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+
+	return ordered
+}
+
+// creationTime selects first the oldest nodes, or the newest ones if newestFirst is true.
+type creationTime struct {
+	newestFirst bool
+}
+
+func (p *creationTime) Order(nodes []corev1.Node, _ map[string]int) []corev1.Node {
+	ordered := sortByName(nodes)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ti, tj := ordered[i].CreationTimestamp.Time, ordered[j].CreationTimestamp.Time
+		if p.newestFirst {
+			return ti.After(tj)
+		}
+
+		return ti.Before(tj)
+	})
+
+	return ordered
+}
+
+// explicit selects only the listed nodes, in the order of the list.
+type explicit struct {
+	nodeNames []string
+}
+
+func (p *explicit) Order(nodes []corev1.Node, _ map[string]int) []corev1.Node {
+	nodeByName := make(map[string]corev1.Node, len(nodes))
+	for _, node := range nodes {
+		nodeByName[node.Name] = node
+	}
+
+	ordered := []corev1.Node{}
+	for _, name := range p.nodeNames {
+		if node, found := nodeByName[name]; found {
+			ordered = append(ordered, node)
+		}
+	}
+
+	return ordered
+}
+
+// preferLabel selects first the nodes with the highest sum of the weights of their preferred labels.
+// The nodes with the same weight are ordered by number of restarts.
+type preferLabel struct {
+	labels []datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryPreferredLabel
+}
+
+func (p *preferLabel) Order(nodes []corev1.Node, nodeRestarts map[string]int) []corev1.Node {
+	ordered := (&leastRestarts{}).Order(nodes, nodeRestarts)
+	weights := make(map[string]int32, len(ordered))
+	for _, node := range ordered {
+		for _, label := range p.labels {
+			if value, found := node.Labels[label.Key]; found && (label.Value == "" || label.Value == value) {
+				weights[node.Name] += label.Weight
+			}
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return weights[ordered[i].Name] > weights[ordered[j].Name]
+	})
+
+	return ordered
+}
+
+func sortByName(nodes []corev1.Node) []corev1.Node {
+	ordered := append([]corev1.Node{}, nodes...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	return ordered
+}
@@ -0,0 +1,120 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package nodeselection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func TestPolicy_Order(t *testing.T) {
+	now := time.Now()
+	newNode := func(name string, age time.Duration, labels map[string]string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				Labels:            labels,
+			},
+		}
+	}
+	nodes := []corev1.Node{
+		newNode("a", time.Hour, nil),
+		newNode("b", 3*time.Hour, map[string]string{"pool": "canary"}),
+		newNode("c", 2*time.Hour, map[string]string{"pool": "default", "tier": "low"}),
+		newNode("d", 4*time.Hour, map[string]string{"tier": "low"}),
+	}
+	nodeRestarts := map[string]int{"a": 3, "b": 1, "d": 2}
+
+	tests := []struct {
+		name   string
+		policy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy
+		want   []string
+	}{
+		{
+			name: "default policy is leastRestarts",
+			want: []string{"c", "b", "d", "a"},
+		},
+		{
+			name:   "leastRestarts",
+			policy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{Type: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyLeastRestarts},
+			want:   []string{"c", "b", "d", "a"},
+		},
+		{
+			name:   "oldestNodes",
+			policy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{Type: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyOldestNodes},
+			want:   []string{"d", "b", "c", "a"},
+		},
+		{
+			name:   "newestNodes",
+			policy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{Type: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyNewestNodes},
+			want:   []string{"a", "c", "b", "d"},
+		},
+		{
+			name: "explicit",
+			policy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+				Type:      datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyExplicit,
+				NodeNames: []string{"d", "unknown", "a"},
+			},
+			want: []string{"d", "a"},
+		},
+		{
+			name: "preferLabel",
+			policy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+				Type: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyPreferLabel,
+				PreferredLabels: []datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryPreferredLabel{
+					{Key: "pool", Value: "canary", Weight: 10},
+					{Key: "tier", Weight: 5},
+				},
+			},
+			want: []string{"b", "c", "d", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered := NewPolicy(tt.policy).Order(nodes, nodeRestarts)
+			assert.Equal(t, tt.want, nodeNames(ordered))
+		})
+	}
+}
+
+func TestPolicy_OrderRandom(t *testing.T) {
+	var nodes []corev1.Node
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		nodes = append(nodes, corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	seed := int64(42)
+	policy := &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicy{
+		Type: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryNodeSelectionPolicyRandom,
+		Seed: &seed,
+	}
+
+	ordered := NewPolicy(policy).Order(nodes, nil)
+	assert.ElementsMatch(t, nodeNames(nodes), nodeNames(ordered))
+
+	// The same seed gives the same order, whatever the order of the input nodes.
+	reversed := make([]corev1.Node, 0, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		reversed = append(reversed, nodes[i])
+	}
+	assert.Equal(t, nodeNames(ordered), nodeNames(NewPolicy(policy).Order(reversed, nil)))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, nodeNames(nodes), "the input nodes must not be modified")
+}
+
+func nodeNames(nodes []corev1.Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,147 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	ersconditions "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+)
+
+// getRolloutGroupMembers returns the other ExtendedDaemonSets of the rollout group of the daemonset,
+// or nil if the daemonset doesn't have the rollout-group label.
+func (r *Reconciler) getRolloutGroupMembers(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) ([]datadoghqv1alpha1.ExtendedDaemonSet, error) {
+	group := daemonset.GetLabels()[datadoghqv1alpha1.ExtendedDaemonSetRolloutGroupLabelKey]
+	if group == "" {
+		return nil, nil
+	}
+
+	edsList := &datadoghqv1alpha1.ExtendedDaemonSetList{}
+	selector := labels.Set{datadoghqv1alpha1.ExtendedDaemonSetRolloutGroupLabelKey: group}
+	listOpts := []client.ListOption{
+		client.InNamespace(daemonset.Namespace),
+		&client.MatchingLabelsSelector{Selector: selector.AsSelectorPreValidated()},
+	}
+	if err := r.client.List(context.TODO(), edsList, listOpts...); err != nil {
+		return nil, fmt.Errorf("unable to list the rollout group %s members, err: %w", group, err)
+	}
+
+	var members []datadoghqv1alpha1.ExtendedDaemonSet
+	for _, eds := range edsList.Items {
+		if eds.Name != daemonset.Name {
+			members = append(members, eds)
+		}
+	}
+
+	return members, nil
+}
+
+// getRolloutGroupCanaryNodes returns the nodes selected for the canary deployments of the rollout group members.
+func (r *Reconciler) getRolloutGroupCanaryNodes(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) (map[string]bool, error) {
+	members, err := r.getRolloutGroupMembers(daemonset)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[string]bool{}
+	for _, member := range members {
+		if member.Status.Canary == nil {
+			continue
+		}
+		for _, nodeName := range member.Status.Canary.Nodes {
+			nodes[nodeName] = true
+		}
+	}
+
+	return nodes, nil
+}
+
+// getRolloutGroupCanaryReplicaSet returns the replicaset of the running canary deployment of a rollout group member,
+// or nil if the member doesn't run a canary deployment.
+func (r *Reconciler) getRolloutGroupCanaryReplicaSet(member *datadoghqv1alpha1.ExtendedDaemonSet) (*datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, error) {
+	if member.Status.Canary == nil || member.Status.Canary.ReplicaSet == "" || member.Spec.Strategy.Canary == nil {
+		return nil, nil
+	}
+
+	ers := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}
+	if err := r.client.Get(context.TODO(), client.ObjectKey{Namespace: member.Namespace, Name: member.Status.Canary.ReplicaSet}, ers); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to get the canary replicaset of %s, err: %w", member.Name, err)
+	}
+
+	return ers, nil
+}
+
+// getPendingRolloutGroupMember returns the name of the first rollout group member whose canary deployment is not
+// completed (ended or validated) yet, or an empty string if all the canary deployments of the group are completed.
+// A canary deployment is only promoted once the canary deployments of all the group members are completed.
+func (r *Reconciler) getPendingRolloutGroupMember(daemonset *datadoghqv1alpha1.ExtendedDaemonSet, now time.Time) (string, error) {
+	members, err := r.getRolloutGroupMembers(daemonset)
+	if err != nil {
+		return "", err
+	}
+
+	for id := range members {
+		member := &members[id]
+		ers, err := r.getRolloutGroupCanaryReplicaSet(member)
+		if err != nil {
+			return "", err
+		}
+		if ers == nil || IsCanaryDeploymentFailed(ers) {
+			continue
+		}
+
+		if completed, _ := isCanaryDeploymentCompleted(member, ers, now); !completed {
+			return member.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// failRolloutGroupCanaries fails the running canary deployments of the rollout group members, after the failure of
+// the daemonset canary deployment.
+func (r *Reconciler) failRolloutGroupCanaries(logger logr.Logger, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, now time.Time) error {
+	members, err := r.getRolloutGroupMembers(daemonset)
+	if err != nil {
+		return err
+	}
+
+	for id := range members {
+		member := &members[id]
+		ers, err := r.getRolloutGroupCanaryReplicaSet(member)
+		if err != nil {
+			return err
+		}
+		if ers == nil || IsCanaryDeploymentFailed(ers) {
+			continue
+		}
+
+		logger.Info("Failing the canary deployment of a rollout group member", "member", member.Name, "replicaSet", ers.Name)
+		newERS := ers.DeepCopy()
+		msg := fmt.Sprintf("canary of %s failed in the same rollout group", daemonset.Name)
+		ersconditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(&newERS.Status, metav1.NewTime(now), datadoghqv1alpha1.ConditionTypeCanaryFailed, corev1.ConditionTrue, string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonRolloutGroupFailed), msg, true, true)
+		if err = r.client.Status().Update(context.TODO(), newERS); err != nil {
+			return fmt.Errorf("unable to fail the canary replicaset of %s, err: %w", member.Name, err)
+		}
+		r.recorder.Event(member, corev1.EventTypeWarning, "Canary failed", msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	ersconditions "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+)
+
+func newRolloutGroupMember(name, group, canaryRS string, annotations map[string]string) *datadoghqv1alpha1.ExtendedDaemonSet {
+	eds := &datadoghqv1alpha1.ExtendedDaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "bar",
+			Name:        name,
+			Labels:      map[string]string{datadoghqv1alpha1.ExtendedDaemonSetRolloutGroupLabelKey: group},
+			Annotations: annotations,
+		},
+		Spec: datadoghqv1alpha1.ExtendedDaemonSetSpec{
+			Strategy: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+				Canary: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+					Duration: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		},
+	}
+	if canaryRS != "" {
+		eds.Status.Canary = &datadoghqv1alpha1.ExtendedDaemonSetStatusCanary{ReplicaSet: canaryRS}
+	}
+
+	return eds
+}
+
+func newRolloutGroupCanaryReplicaSet(name string, created time.Time) *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet {
+	return &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: name, CreationTimestamp: metav1.NewTime(created)},
+	}
+}
+
+func Test_getPendingRolloutGroupMember(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{})
+	now := time.Now()
+
+	daemonset := newRolloutGroupMember("foo", "agents", "foo-2", nil)
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    string
+	}{
+		{
+			name:    "no other member",
+			objects: []client.Object{daemonset},
+			want:    "",
+		},
+		{
+			name: "member canary running",
+			objects: []client.Object{
+				daemonset,
+				newRolloutGroupMember("baz", "agents", "baz-2", nil),
+				newRolloutGroupCanaryReplicaSet("baz-2", now.Add(-time.Minute)),
+			},
+			want: "baz",
+		},
+		{
+			name: "member canary ended",
+			objects: []client.Object{
+				daemonset,
+				newRolloutGroupMember("baz", "agents", "baz-2", nil),
+				newRolloutGroupCanaryReplicaSet("baz-2", now.Add(-time.Hour)),
+			},
+			want: "",
+		},
+		{
+			name: "member canary validated",
+			objects: []client.Object{
+				daemonset,
+				newRolloutGroupMember("baz", "agents", "baz-2", map[string]string{datadoghqv1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey: "baz-2"}),
+				newRolloutGroupCanaryReplicaSet("baz-2", now.Add(-time.Minute)),
+			},
+			want: "",
+		},
+		{
+			name: "member canary running in another group",
+			objects: []client.Object{
+				daemonset,
+				newRolloutGroupMember("baz", "other", "baz-2", nil),
+				newRolloutGroupCanaryReplicaSet("baz-2", now.Add(-time.Minute)),
+			},
+			want: "",
+		},
+		{
+			name: "member without canary",
+			objects: []client.Object{
+				daemonset,
+				newRolloutGroupMember("baz", "agents", "", nil),
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{client: fake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).Build()}
+			got, err := r.getPendingRolloutGroupMember(daemonset, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_failRolloutGroupCanaries(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{})
+	now := time.Now()
+
+	daemonset := newRolloutGroupMember("foo", "agents", "foo-2", nil)
+	c := fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).WithObjects(
+		daemonset,
+		newRolloutGroupMember("baz", "agents", "baz-2", nil),
+		newRolloutGroupCanaryReplicaSet("baz-2", now.Add(-time.Minute)),
+		newRolloutGroupMember("qux", "other", "qux-2", nil),
+		newRolloutGroupCanaryReplicaSet("qux-2", now.Add(-time.Minute)),
+	).Build()
+	r := &Reconciler{
+		client:   c,
+		recorder: record.NewFakeRecorder(10),
+	}
+	require.NoError(t, r.failRolloutGroupCanaries(testLogger, daemonset, now))
+
+	ers := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "bar", Name: "baz-2"}, ers))
+	assert.True(t, IsCanaryDeploymentFailed(ers), "the canary of the group member should be failed")
+	cond := ersconditions.GetExtendedDaemonSetReplicaSetStatusCondition(&ers.Status, datadoghqv1alpha1.ConditionTypeCanaryFailed)
+	assert.Equal(t, string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonRolloutGroupFailed), cond.Reason)
+
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "bar", Name: "qux-2"}, ers))
+	assert.False(t, IsCanaryDeploymentFailed(ers), "the canary of another group should not be failed")
+}
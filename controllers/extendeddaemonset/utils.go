@@ -8,8 +8,11 @@ package extendeddaemonset
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+	"github.com/DataDog/extendeddaemonset/pkg/controller/utils/comparison"
 )
 
 // IsRollingUpdatePaused checks if a rolling update has been paused.
@@ -17,6 +20,38 @@ func IsRollingUpdatePaused(dsAnnotations map[string]string) bool {
 	return dsAnnotations[datadoghqv1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] == datadoghqv1alpha1.ValueStringTrue
 }
 
+// IsRollingUpdateBatchApproved checks if the next batch of a rolling update with manual batch approval
+// has been approved, after the completion of the batch number `completedBatch` of the replicaset `ersName`.
+func IsRollingUpdateBatchApproved(dsAnnotations map[string]string, ersName string, completedBatch int32) bool {
+	return dsAnnotations[datadoghqv1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey] == datadoghqv1alpha1.RollingUpdateApprovedBatchValue(ersName, completedBatch)
+}
+
+// IsRollingUpdateWaitingForBatchApproval checks if the rolling update of the replicaset is paused until
+// the approval of its next batch.
+func IsRollingUpdateWaitingForBatchApproval(ers *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) bool {
+	return isRollingUpdatePausedWithReason(ers, datadoghqv1alpha1.ExtendedDaemonSetStatusReasonWaitingForBatchApproval)
+}
+
+// IsRollingUpdatePausedForUnhealthyPods checks if the rolling update of the replicaset has been paused
+// by its health gate, because of too many unhealthy up-to-date pods.
+func IsRollingUpdatePausedForUnhealthyPods(ers *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) bool {
+	return isRollingUpdatePausedWithReason(ers, datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods)
+}
+
+func isRollingUpdatePausedWithReason(ers *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, reason datadoghqv1alpha1.ExtendedDaemonSetStatusReason) bool {
+	if ers == nil {
+		return false
+	}
+	cond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&ers.Status, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused)
+
+	return cond != nil && cond.Status == corev1.ConditionTrue && cond.Reason == string(reason)
+}
+
+// IsRollingUpdateFailed checks if the rolling update of the replicaset has been failed by its health gate.
+func IsRollingUpdateFailed(ers *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) bool {
+	return ers != nil && conditions.IsConditionTrue(&ers.Status, datadoghqv1alpha1.ConditionTypeRollingUpdateFailed)
+}
+
 // IsRolloutFrozen checks if a rollout has been freezed.
 func IsRolloutFrozen(dsAnnotations map[string]string) bool {
 	return dsAnnotations[datadoghqv1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey] == datadoghqv1alpha1.ValueStringTrue
@@ -99,6 +134,26 @@ func IsCanaryDeploymentValid(dsAnnotations map[string]string, rsName string) boo
 	return false
 }
 
+// IsCanaryAutoRollbackEnabled checks if the pod template of a failed Canary deployment must be rolled back.
+// AutoRollback is enabled by default.
+func IsCanaryAutoRollbackEnabled(canary *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanary) bool {
+	return canary != nil && (canary.AutoRollback == nil || *canary.AutoRollback)
+}
+
+// isTemplateRolledBack returns true if the ExtendedDaemonSet pod template has already been rolled back
+// after the failure of its Canary deployment.
+func isTemplateRolledBack(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) bool {
+	if daemonset.Status.FailedTemplateHash == "" || !IsCanaryAutoRollbackEnabled(daemonset.Spec.Strategy.Canary) {
+		return false
+	}
+	hash, err := comparison.GenerateMD5ReplicaSetSpec(daemonset)
+	if err != nil {
+		return false
+	}
+
+	return hash == daemonset.Status.FailedTemplateHash
+}
+
 // IsCanaryDeploymentFailed checks if the Canary deployment has been failed.
 func IsCanaryDeploymentFailed(ers *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) bool {
 	// Check ERS status to detect if a Canary failed
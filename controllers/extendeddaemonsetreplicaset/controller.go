@@ -110,9 +110,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
+	// In dry-run mode, the strategy writes (pods cleanup, canary labels) are sent to the API server
+	// as dry-run requests, and the pods creations and deletions are only recorded in the status.
+	dryRun := isDryRun(daemonsetInstance)
+	strategyClient := r.client
+	if dryRun {
+		strategyClient = client.NewDryRunClient(r.client)
+	}
+
 	// now apply the strategy depending on the ReplicaSet state
-	strategyResult, err := r.applyStrategy(reqLogger, daemonsetInstance, now, strategyParams)
+	strategyResult, err := r.applyStrategy(reqLogger, strategyClient, daemonsetInstance, now, strategyParams)
 	newStatus := strategyResult.NewStatus
+	newStatus.ObservedGeneration = replicaSetInstance.Generation
 	result := strategyResult.Result
 
 	// for the reste of the actions we will try to execute as many actions as we can so we will store possible errors in a list
@@ -132,48 +141,80 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(newStatus, now, datadoghqv1alpha1.ConditionTypeUnschedule, status, "", desc, false, false)
 
 	// start actions on pods
+	if dryRun {
+		newStatus.DryRunPlan = planPodActions(strategyParams, strategyResult)
+		r.recordDryRunEvents(replicaSetInstance, newStatus.DryRunPlan)
+		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(newStatus, now, datadoghqv1alpha1.ConditionTypeDryRun, corev1.ConditionTrue, "", "pods creations and deletions are planned but not executed", false, false)
+	} else {
+		newStatus.DryRunPlan = nil
+		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(newStatus, now, datadoghqv1alpha1.ConditionTypeDryRun, corev1.ConditionFalse, "", "", false, false)
+		errs = append(errs, r.applyPodActions(reqLogger, daemonsetInstance, replicaSetInstance, now, strategyParams, strategyResult, &result)...)
+		errs = append(errs, manageStartupTaint(reqLogger, r.client, daemonsetInstance, strategyParams, now)...)
+	}
+
+	updateEvictionBlockedCondition(newStatus, now, strategyResult.EvictionBlockedNodes)
+
+	err = utilserrors.NewAggregate(errs)
+	conditions.UpdateErrorCondition(newStatus, now, err, "")
+	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(newStatus, now, datadoghqv1alpha1.ConditionTypeLastFullSync, corev1.ConditionTrue, "", "full sync", true, true)
+
+	reqLogger.V(1).Info("Updating ExtendedDaemonSetReplicaSet status")
+	err = r.updateReplicaSet(replicaSetInstance, newStatus)
+
+	// Garbage collect the failedPodsBackOff map once per minute,
+	// i.e. whenever the seconds [0,59] is less than the reconcile frequency
+	if now.Second() < int(daemonsetInstance.Spec.Strategy.ReconcileFrequency.Seconds()) {
+		// Garbage collect records that have aged past the maxDuration (here: 15min)
+		r.failedPodsBackOff.GC()
+	}
+
+	return result, err
+}
+
+// applyPodActions deletes and creates the pods returned by the strategy, unless the previous actions are too recent.
+func (r *Reconciler) applyPodActions(reqLogger logr.Logger, daemonsetInstance *datadoghqv1alpha1.ExtendedDaemonSet, replicaSetInstance *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, now metav1.Time,
+	strategyParams *strategy.Parameters, strategyResult *strategy.Result, result *reconcile.Result,
+) []error {
+	var errs []error
 	requeueAfter := 5 * time.Second
 	if daemonsetInstance.Spec.Strategy.ReconcileFrequency != nil {
 		requeueAfter = daemonsetInstance.Spec.Strategy.ReconcileFrequency.Duration
 	}
 
-	lastPodDeletionCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(newStatus, datadoghqv1alpha1.ConditionTypePodDeletion)
+	lastPodDeletionCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(strategyResult.NewStatus, datadoghqv1alpha1.ConditionTypePodDeletion)
 	if lastPodDeletionCondition != nil && now.Sub(lastPodDeletionCondition.LastUpdateTime.Time) < requeueAfter {
 		reqLogger.V(1).Info("Delay pods deletion", "deplay", requeueAfter, "since", now.Sub(lastPodDeletionCondition.LastUpdateTime.Time))
 		result.RequeueAfter = requeueAfter
 	} else {
-		errs = append(errs, deletePods(reqLogger, r.client, strategyParams.PodByNodeName, strategyResult.PodsToDelete)...)
+		blockedNodes, deleteErrs := deletePods(reqLogger, r.client, strategyParams.PodByNodeName, strategyResult.PodsToDelete, strategy.UseEvictionAPI(&daemonsetInstance.Spec.Strategy))
+		errs = append(errs, deleteErrs...)
+		errs = append(errs, requestPodsDrain(reqLogger, r.client, strategyParams.PodByNodeName, strategyResult.PodsToDrain, now)...)
+		if len(blockedNodes) > 0 {
+			strategyResult.EvictionBlockedNodes = append(strategyResult.EvictionBlockedNodes, blockedNodes...)
+			result.RequeueAfter = requeueAfter
+		}
 		if len(strategyResult.PodsToDelete) > 0 {
-			conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(newStatus, now, datadoghqv1alpha1.ConditionTypePodDeletion, corev1.ConditionTrue, "", "pods deleted", false, true)
+			conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyResult.NewStatus, now, datadoghqv1alpha1.ConditionTypePodDeletion, corev1.ConditionTrue, "", "pods deleted", false, true)
 		}
 	}
 
-	lastPodCreationCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(newStatus, datadoghqv1alpha1.ConditionTypePodCreation)
+	lastPodCreationCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(strategyResult.NewStatus, datadoghqv1alpha1.ConditionTypePodCreation)
 	if lastPodCreationCondition != nil && now.Sub(lastPodCreationCondition.LastUpdateTime.Time) < daemonsetInstance.Spec.Strategy.ReconcileFrequency.Duration {
 		reqLogger.V(1).Info("Delay pods creation", "deplay:", requeueAfter, "since", now.Sub(lastPodDeletionCondition.LastUpdateTime.Time))
 		result.RequeueAfter = requeueAfter
 	} else {
 		errs = append(errs, createPods(reqLogger, r.client, r.scheme, r.options.IsNodeAffinitySupported, replicaSetInstance, strategyResult.PodsToCreate)...)
-		if len(strategyResult.PodsToCreate) > 0 {
-			conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(newStatus, now, datadoghqv1alpha1.ConditionTypePodCreation, corev1.ConditionTrue, "", "pods created", false, true)
+		// The pre-pull pods creations are delayed like the pods creations, so a stale cache doesn't create
+		// another pre-pull pod on the same node.
+		errs = append(errs, createPrePullPods(reqLogger, r.client, r.scheme, replicaSetInstance, strategyResult.PrePullPodsToCreate)...)
+		if len(strategyResult.PodsToCreate) > 0 || len(strategyResult.PrePullPodsToCreate) > 0 {
+			conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyResult.NewStatus, now, datadoghqv1alpha1.ConditionTypePodCreation, corev1.ConditionTrue, "", "pods created", false, true)
 		}
 	}
 
-	err = utilserrors.NewAggregate(errs)
-	conditions.UpdateErrorCondition(newStatus, now, err, "")
-	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(newStatus, now, datadoghqv1alpha1.ConditionTypeLastFullSync, corev1.ConditionTrue, "", "full sync", true, true)
-
-	reqLogger.V(1).Info("Updating ExtendedDaemonSetReplicaSet status")
-	err = r.updateReplicaSet(replicaSetInstance, newStatus)
-
-	// Garbage collect the failedPodsBackOff map once per minute,
-	// i.e. whenever the seconds [0,59] is less than the reconcile frequency
-	if now.Second() < int(daemonsetInstance.Spec.Strategy.ReconcileFrequency.Seconds()) {
-		// Garbage collect records that have aged past the maxDuration (here: 15min)
-		r.failedPodsBackOff.GC()
-	}
+	errs = append(errs, deletePrePullPods(reqLogger, r.client, strategyResult.PrePullPodsToDelete)...)
 
-	return result, err
+	return errs
 }
 
 func (r *Reconciler) buildStrategyParams(logger logr.Logger, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) (*strategy.Parameters, error) {
@@ -192,6 +233,7 @@ func (r *Reconciler) buildStrategyParams(logger logr.Logger, daemonset *datadogh
 		ReplicaSetStatus: string(rsStatus),
 		Logger:           logger.WithValues("strategy", rsStatus),
 		NewStatus:        replicaset.Status.DeepCopy(),
+		MinReadySeconds:  daemonset.Spec.MinReadySeconds,
 	}
 	var nodesFilter []string
 	if daemonset.Status.Canary != nil {
@@ -202,12 +244,21 @@ func (r *Reconciler) buildStrategyParams(logger logr.Logger, daemonset *datadogh
 	}
 
 	// Associate Pods to Nodes
-	strategyParams.NodeByName, strategyParams.PodByNodeName, strategyParams.PodToCleanUp, strategyParams.UnscheduledPods = r.FilterAndMapPodsByNode(logger.WithValues("status", string(rsStatus)), replicaset, nodeList, podList, nodesFilter)
+	strategyParams.NodeByName, strategyParams.PodByNodeName, strategyParams.PodToCleanUp, strategyParams.UnscheduledPods, strategyParams.NewStatus.UnfitNodes = r.FilterAndMapPodsByNode(logger.WithValues("status", string(rsStatus)), replicaset, nodeList, podList, nodesFilter)
+	strategyParams.NewStatus.NumberMisscheduled = countMisscheduledPods(strategyParams.PodToCleanUp, strategyParams.NodeByName, strategyParams.PodByNodeName)
+
+	// The pre-pull pods are also listed when the pre-pull phase doesn't run, to delete the remaining ones.
+	strategyParams.PrePullPodByNodeName, err = r.getPrePullPodByNodeName(replicaset)
+	if err != nil {
+		logger.Error(err, "unable to list the image pre-pull pods")
+
+		return nil, err
+	}
 
 	return strategyParams, nil
 }
 
-func (r *Reconciler) applyStrategy(logger logr.Logger, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, now metav1.Time, strategyParams *strategy.Parameters) (*strategy.Result, error) {
+func (r *Reconciler) applyStrategy(logger logr.Logger, c client.Client, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, now metav1.Time, strategyParams *strategy.Parameters) (*strategy.Result, error) {
 	var strategyResult *strategy.Result
 	var err error
 	logger.V(1).Info("DaemonsetStatus: ", "status", daemonset.Status)
@@ -217,17 +268,17 @@ func (r *Reconciler) applyStrategy(logger logr.Logger, daemonset *datadoghqv1alp
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyParams.NewStatus, now, datadoghqv1alpha1.ConditionTypeCanary, corev1.ConditionFalse, "", "", false, false)
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyParams.NewStatus, now, datadoghqv1alpha1.ConditionTypeCanaryPaused, corev1.ConditionFalse, "", "", false, false)
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyParams.NewStatus, now, datadoghqv1alpha1.ConditionTypeCanaryFailed, corev1.ConditionFalse, "", "", false, false)
-		strategyResult, err = strategy.ManageDeployment(r.client, daemonset, strategyParams, now)
+		strategyResult, err = strategy.ManageDeployment(c, daemonset, strategyParams, now)
 	case strategy.ReplicaSetStatusCanary:
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyParams.NewStatus, now, datadoghqv1alpha1.ConditionTypeCanary, corev1.ConditionTrue, "", "", false, false)
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyParams.NewStatus, now, datadoghqv1alpha1.ConditionTypeActive, corev1.ConditionFalse, "", "", false, false)
 		logger.Info("manage canary deployment")
-		strategyResult, err = strategy.ManageCanaryDeployment(r.client, daemonset, strategyParams)
+		strategyResult, err = strategy.ManageCanaryDeployment(c, daemonset, strategyParams, now)
 	case strategy.ReplicaSetStatusUnknown:
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyParams.NewStatus, now, datadoghqv1alpha1.ConditionTypeCanary, corev1.ConditionFalse, "", "", false, false)
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(strategyParams.NewStatus, now, datadoghqv1alpha1.ConditionTypeActive, corev1.ConditionFalse, "", "", false, false)
 		logger.Info("ignore this replicaset, since it's not the replicas active or canary")
-		strategyResult, err = strategy.ManageUnknown(r.client, strategyParams)
+		strategyResult, err = strategy.ManageUnknown(c, strategyParams, now)
 	}
 
 	return strategyResult, err
@@ -341,6 +392,25 @@ func (r *Reconciler) getPodList(ds *datadoghqv1alpha1.ExtendedDaemonSet) (*corev
 	return podList, nil
 }
 
+// getPrePullPodByNodeName returns the image pre-pull pods of the replicaset, by node name.
+func (r *Reconciler) getPrePullPodByNodeName(replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) (map[string]*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	podListOptions := []client.ListOption{
+		client.InNamespace(replicaset.Namespace),
+		client.MatchingLabels{datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPrePullLabelKey: replicaset.Name},
+	}
+	if err := r.client.List(context.TODO(), podList, podListOptions...); err != nil {
+		return nil, err
+	}
+
+	prePullPodByNodeName := make(map[string]*corev1.Pod, len(podList.Items))
+	for id, pod := range podList.Items {
+		prePullPodByNodeName[pod.Spec.NodeName] = &podList.Items[id]
+	}
+
+	return prePullPodByNodeName, nil
+}
+
 func (r *Reconciler) getNodeList(eds *datadoghqv1alpha1.ExtendedDaemonSet, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) (*strategy.NodeList, error) {
 	nodeItemList := &strategy.NodeList{}
 
@@ -13,6 +13,7 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -182,6 +183,139 @@ func TestReconcileExtendedDaemonSetReplicaSet_Reconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileExtendedDaemonSetReplicaSet_ReconcileDryRun(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSet{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonsetSettingList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonsetSetting{})
+
+	maxUnavailable := intstr.FromInt(2)
+	slowStartAdditiveIncrease := intstr.FromInt(2)
+	rollingUpdate := &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+		MaxUnavailable:            &maxUnavailable,
+		SlowStartAdditiveIncrease: &slowStartAdditiveIncrease,
+		MaxParallelPodCreation:    datadoghqv1alpha1.NewInt32(2),
+	}
+	daemonset := datadoghqv1alpha1.DefaultExtendedDaemonSet(test.NewExtendedDaemonSet("bar", "foo", &test.NewExtendedDaemonSetOptions{
+		Annotations:   map[string]string{datadoghqv1alpha1.ExtendedDaemonSetDryRunAnnotationKey: datadoghqv1alpha1.ValueStringTrue},
+		RollingUpdate: rollingUpdate,
+		Status:        &datadoghqv1alpha1.ExtendedDaemonSetStatus{ActiveReplicaSet: "foo-1"},
+	}), datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationModeAuto)
+	replicaset := test.NewExtendedDaemonSetReplicaSet("bar", "foo-1", &test.NewExtendedDaemonSetReplicaSetOptions{OwnerRefName: "foo"})
+	replicaset.Spec.TemplateGeneration = "new-hash"
+
+	node1 := ctrltest.NewNode("node1", nil)
+	node2 := ctrltest.NewNode("node2", nil)
+	oldPod := ctrltest.NewPod("bar", "foo-old-node2", "node2", &ctrltest.NewPodOptions{
+		Labels:      map[string]string{datadoghqv1alpha1.ExtendedDaemonSetNameLabelKey: "foo"},
+		Annotations: map[string]string{datadoghqv1alpha1.MD5ExtendedDaemonSetAnnotationKey: "old-hash"},
+	})
+
+	c := fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
+		WithObjects(daemonset, replicaset, node1, node2, oldPod).Build()
+	r := &Reconciler{
+		client:            c,
+		scheme:            s,
+		recorder:          recorder,
+		failedPodsBackOff: flowcontrol.NewFakeBackOff(30*time.Second, 15*time.Minute, clock.NewFakeClock(time.Now())),
+		log:               testLogger,
+	}
+	_, err := r.Reconcile(t.Context(), newRequest("bar", "foo-1"))
+	if err != nil {
+		t.Fatalf("ReconcileExtendedDaemonSetReplicaSet.Reconcile() error = %v", err)
+	}
+
+	podList := &corev1.PodList{}
+	if err = c.List(t.Context(), podList); err != nil {
+		t.Fatalf("unable to list pods, err: %v", err)
+	}
+	if len(podList.Items) != 1 || podList.Items[0].Name != oldPod.Name {
+		t.Errorf("pods should not be created or deleted in dry-run mode, got %d pods", len(podList.Items))
+	}
+
+	gotRS := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}
+	if err = c.Get(t.Context(), types.NamespacedName{Namespace: "bar", Name: "foo-1"}, gotRS); err != nil {
+		t.Fatalf("unable to get replicaset, err: %v", err)
+	}
+	wantPlan := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetDryRunPlan{
+		PodsToCreate: 1,
+		PodsToDelete: 1,
+		Actions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPlannedAction{
+			{
+				Type:   datadoghqv1alpha1.PlannedActionTypeCreate,
+				Node:   "node1",
+				Reason: "no pod of the replicaset is running on the node",
+			},
+			{
+				Type:   datadoghqv1alpha1.PlannedActionTypeDelete,
+				Node:   "node2",
+				Pod:    "foo-old-node2",
+				Reason: `pod template hash "old-hash" doesn't match the replicaset template hash "new-hash"`,
+			},
+		},
+	}
+	if !apiequality.Semantic.DeepEqual(gotRS.Status.DryRunPlan, wantPlan) {
+		t.Errorf("status.dryRunPlan = %#v, want %#v", gotRS.Status.DryRunPlan, wantPlan)
+	}
+
+	wantEvents := []string{
+		"Normal DryRun Create Pods 1 pod(s) would be created on nodes: node1",
+		"Normal DryRun Delete Pods 1 pod(s) would be deleted on nodes: node2",
+	}
+	for _, want := range wantEvents {
+		select {
+		case got := <-recorder.Events:
+			if got != want {
+				t.Errorf("event = %q, want %q", got, want)
+			}
+		default:
+			t.Errorf("missing event %q", want)
+		}
+	}
+}
+
+func TestReconcileExtendedDaemonSetReplicaSet_ReconcilePrePullCleanup(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSetList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonSet{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonsetSettingList{})
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.ExtendedDaemonsetSetting{})
+
+	// The replicaset foo-1 is not active anymore: its remaining pre-pull pods are deleted.
+	daemonset := datadoghqv1alpha1.DefaultExtendedDaemonSet(test.NewExtendedDaemonSet("bar", "foo", &test.NewExtendedDaemonSetOptions{
+		Status: &datadoghqv1alpha1.ExtendedDaemonSetStatus{ActiveReplicaSet: "foo-2"},
+	}), datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationModeAuto)
+	replicaset := test.NewExtendedDaemonSetReplicaSet("bar", "foo-1", &test.NewExtendedDaemonSetReplicaSetOptions{OwnerRefName: "foo"})
+	node1 := ctrltest.NewNode("node1", nil)
+	prePullPod := ctrltest.NewPod("bar", "foo-1-pre-pull-node1", "node1", &ctrltest.NewPodOptions{
+		Labels: map[string]string{datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPrePullLabelKey: "foo-1"},
+	})
+
+	c := fake.NewClientBuilder().WithStatusSubresource(&datadoghqv1alpha1.ExtendedDaemonSet{}, &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{}).
+		WithObjects(daemonset, replicaset, node1, prePullPod).Build()
+	r := &Reconciler{
+		client:            c,
+		scheme:            s,
+		recorder:          record.NewFakeRecorder(10),
+		failedPodsBackOff: flowcontrol.NewFakeBackOff(30*time.Second, 15*time.Minute, clock.NewFakeClock(time.Now())),
+		log:               testLogger,
+	}
+	if _, err := r.Reconcile(t.Context(), newRequest("bar", "foo-1")); err != nil {
+		t.Fatalf("ReconcileExtendedDaemonSetReplicaSet.Reconcile() error = %v", err)
+	}
+
+	err := c.Get(t.Context(), types.NamespacedName{Namespace: "bar", Name: prePullPod.Name}, &corev1.Pod{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("the pre-pull pod of an inactive replicaset should be deleted, err: %v", err)
+	}
+}
+
 func Test_retrieveReplicaSetStatus(t *testing.T) {
 	status := &datadoghqv1alpha1.ExtendedDaemonSetStatus{
 		ActiveReplicaSet: "rs-active",
@@ -22,16 +22,22 @@ import (
 // Deprecated: This flag is deprecated and will be removed in a subsequent version.
 var ignoreEvictedPods = false
 
+// maxUnfitNodesInStatus is the maximum number of nodes reported in the ExtendedDaemonSetReplicaSet
+// status.unfitNodes, to keep the status size independent of the cluster size.
+const maxUnfitNodesInStatus = 10
+
 func init() {
 	pflag.BoolVarP(&ignoreEvictedPods, "ignoreEvictedPods", "i", ignoreEvictedPods, "Enabling this will force new pods creation on nodes where pods are evicted")
 }
 
 // FilterAndMapPodsByNode is used to map pods by associated node. It also returns the list of pods that
-// should be deleted (not needed anymore), and pods that are not scheduled yet (created but not scheduled).
+// should be deleted (not needed anymore), pods that are not scheduled yet (created but not scheduled),
+// and a bounded sample of the nodes that don't fit the pod template with the reasons why.
 func (r *Reconciler) FilterAndMapPodsByNode(
 	logger logr.Logger, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, nodeList *strategy.NodeList, podList *corev1.PodList, ignoreNodes []string,
 ) (
 	nodesByName map[string]*strategy.NodeItem, podsByNode map[*strategy.NodeItem]*corev1.Pod, podsToDelete, unscheduledPods []*corev1.Pod,
+	unfitNodes []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitNode,
 ) {
 	// For faster search convert nodes to ignore from a slice to a map
 	ignoreMapNode := make(map[string]bool)
@@ -51,12 +57,15 @@ func (r *Reconciler) FilterAndMapPodsByNode(
 			continue
 		}
 		// Populate podsByNodeName with nodes that are deemed schedulable
-		if scheduler.CheckNodeFitness(logger.WithValues("filter", "FilterAndMapPodsByNode"), newPod, nodeItem.Node) {
+		fitness := scheduler.CheckNodeFitness(logger.WithValues("filter", "FilterAndMapPodsByNode"), newPod, nodeItem.Node)
+		if fitness.Fit() {
 			podsByNodeName[nodeItem.Node.Name] = nil
 		} else {
-			logger.V(1).Info("CheckNodeFitness not ok", "reason", "DeletionTimestamp==nil", "node.Name", nodeItem.Node.Name)
+			logger.V(1).Info("CheckNodeFitness not ok", "reason", fitness.String(), "node.Name", nodeItem.Node.Name)
+			unfitNodes = append(unfitNodes, newUnfitNode(nodeItem.Node.Name, fitness))
 		}
 	}
+	unfitNodes = sampleUnfitNodes(unfitNodes)
 
 	// Associate Pods to Nodes
 	for id, pod := range podList.Items {
@@ -114,7 +123,55 @@ func (r *Reconciler) FilterAndMapPodsByNode(
 	podsToDelete = append(podsToDelete, duplicatedPods...)
 
 	// Filter Pods in Terminated state
-	return nodesByName, podsByNode, podsToDelete, unscheduledPods
+	return nodesByName, podsByNode, podsToDelete, unscheduledPods, unfitNodes
+}
+
+// countMisscheduledPods returns the number of pods to delete that are running on a node that
+// doesn't fit the pod template. The failed and duplicated pods, that are also deleted, are on
+// a node that should run the pod, so they are not counted.
+func countMisscheduledPods(podsToDelete []*corev1.Pod, nodesByName map[string]*strategy.NodeItem, podsByNode map[*strategy.NodeItem]*corev1.Pod) int32 {
+	var nbMisscheduled int32
+	for _, pod := range podsToDelete {
+		nodeName, err := podutils.GetNodeNameFromPod(pod)
+		if err != nil {
+			continue
+		}
+		if node, found := nodesByName[nodeName]; found {
+			if _, shouldRun := podsByNode[node]; shouldRun {
+				continue
+			}
+		}
+		nbMisscheduled++
+	}
+
+	return nbMisscheduled
+}
+
+func newUnfitNode(nodeName string, fitness scheduler.FitnessResult) datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitNode {
+	unfitNode := datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitNode{
+		Node: nodeName,
+	}
+	for _, failure := range fitness.Failures {
+		unfitNode.Reasons = append(unfitNode.Reasons, datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitReason{
+			Predicate: string(failure.Predicate),
+			Message:   failure.Message,
+		})
+	}
+
+	return unfitNode
+}
+
+// sampleUnfitNodes sorts the unfit nodes by name and keeps at most maxUnfitNodesInStatus of them,
+// so the sample stays stable between reconcile loops.
+func sampleUnfitNodes(unfitNodes []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitNode) []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitNode {
+	sort.Slice(unfitNodes, func(i, j int) bool {
+		return unfitNodes[i].Node < unfitNodes[j].Node
+	})
+	if len(unfitNodes) > maxUnfitNodesInStatus {
+		unfitNodes = unfitNodes[:maxUnfitNodesInStatus]
+	}
+
+	return unfitNodes
 }
 
 func (r *Reconciler) shouldDeleteFailedPod(replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, nodeName string) bool {
@@ -139,6 +139,16 @@ func TestFilterAndMapPodsByNode(t *testing.T) {
 	node2 := ctrltest.NewNode("node2", nodeReadyOptions)
 	node3 := ctrltest.NewNode("node3", nodeReadyOptions)
 	node4 := ctrltest.NewNode("node4", nodeKOOptions)
+	node5 := ctrltest.NewNode("node5", &ctrltest.NewNodeOptions{
+		Conditions: nodeReadyOptions.Conditions,
+		Taints: []corev1.Taint{
+			{
+				Key:    "mytaint",
+				Value:  "foo",
+				Effect: corev1.TaintEffectNoSchedule,
+			},
+		},
+	})
 
 	pod1Node1 := ctrltest.NewPod(ns, "pod1", node1.Name, &ctrltest.NewPodOptions{
 		CreationTimestamp: metav1.NewTime(now),
@@ -190,6 +200,7 @@ func TestFilterAndMapPodsByNode(t *testing.T) {
 		wantPodByNode       map[string]*corev1.Pod
 		wantPodToDelete     []*corev1.Pod
 		wantUnscheduledPods []*corev1.Pod
+		wantUnfitNodes      []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitNode
 	}{
 		{
 			name: "one pod, one filtered node",
@@ -470,6 +481,44 @@ func TestFilterAndMapPodsByNode(t *testing.T) {
 			wantPodToDelete:     []*corev1.Pod{pod5Node1},
 			wantUnscheduledPods: nil,
 		},
+		{
+			name: "tainted node reported as unfit",
+			args: args{
+				replicaset: datadoghqv1alpha1test.NewExtendedDaemonSetReplicaSet("foo", "bar", nil),
+				nodeList: &strategy.NodeList{
+					Items: []*strategy.NodeItem{
+						strategy.NewNodeItem(node1, nil),
+						strategy.NewNodeItem(node5, nil),
+					},
+				},
+				podList: &corev1.PodList{
+					Items: []corev1.Pod{
+						*pod1Node1,
+					},
+				},
+				ignoreNodes: []string{},
+			},
+			wantNodeByName: map[string]*strategy.NodeItem{
+				"node1": strategy.NewNodeItem(node1, nil),
+				"node5": strategy.NewNodeItem(node5, nil),
+			},
+			wantPodByNode: map[string]*corev1.Pod{
+				"node1": pod1Node1,
+			},
+			wantPodToDelete:     nil,
+			wantUnscheduledPods: nil,
+			wantUnfitNodes: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitNode{
+				{
+					Node: "node5",
+					Reasons: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetUnfitReason{
+						{
+							Predicate: "PodToleratesNodeTaints",
+							Message:   "taint mytaint=foo:NoSchedule is not tolerated",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -483,7 +532,7 @@ func TestFilterAndMapPodsByNode(t *testing.T) {
 			}
 			reqLogger := log.WithValues("test:", tt.name)
 
-			gotNodeByName, gotPodByNode, gotPodToDelete, gotUnscheduledPods := r.FilterAndMapPodsByNode(reqLogger, tt.args.replicaset, tt.args.nodeList, tt.args.podList, tt.args.ignoreNodes)
+			gotNodeByName, gotPodByNode, gotPodToDelete, gotUnscheduledPods, gotUnfitNodes := r.FilterAndMapPodsByNode(reqLogger, tt.args.replicaset, tt.args.nodeList, tt.args.podList, tt.args.ignoreNodes)
 			if diff := cmp.Diff(tt.wantNodeByName, gotNodeByName); diff != "" {
 				t.Errorf("FilterAndMapPodsByNode() gotNodeByName mismatch (-want +got):\n%s", diff)
 			}
@@ -500,6 +549,9 @@ func TestFilterAndMapPodsByNode(t *testing.T) {
 			if diff := cmp.Diff(tt.wantUnscheduledPods, gotUnscheduledPods); diff != "" {
 				t.Errorf("FilterAndMapPodsByNode() gotUnscheduledPods mismatch (-want +got):\n%s", diff)
 			}
+			if diff := cmp.Diff(tt.wantUnfitNodes, gotUnfitNodes); diff != "" {
+				t.Errorf("FilterAndMapPodsByNode() gotUnfitNodes mismatch (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -540,3 +592,28 @@ func Test_shouldDeleteFailedPod(t *testing.T) {
 	result = r.shouldDeleteFailedPod(rs, "node2")
 	assert.True(t, result)
 }
+
+func Test_countMisscheduledPods(t *testing.T) {
+	ns := "foo"
+	nodeA := &strategy.NodeItem{Node: ctrltest.NewNode("nodeA", nil)}
+	unfitNode := &strategy.NodeItem{Node: ctrltest.NewNode("unfit", nil)}
+	nodesByName := map[string]*strategy.NodeItem{
+		"nodeA": nodeA,
+		"unfit": unfitNode,
+	}
+	podsByNode := map[*strategy.NodeItem]*corev1.Pod{
+		nodeA: ctrltest.NewPod(ns, "pod1", "nodeA", nil),
+	}
+
+	podsToDelete := []*corev1.Pod{
+		// duplicated pod on a node that should run the pod
+		ctrltest.NewPod(ns, "pod2", "nodeA", nil),
+		// pod on a node that doesn't fit the pod template
+		ctrltest.NewPod(ns, "pod3", "unfit", nil),
+		// pod on a node that doesn't exist anymore
+		ctrltest.NewPod(ns, "pod4", "deleted", nil),
+	}
+
+	assert.Equal(t, int32(2), countMisscheduledPods(podsToDelete, nodesByName, podsByNode))
+	assert.Equal(t, int32(0), countMisscheduledPods(nil, nodesByName, podsByNode))
+}
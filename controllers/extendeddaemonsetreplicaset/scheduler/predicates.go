@@ -8,6 +8,8 @@ package scheduler
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -18,61 +20,192 @@ import (
 	podaffinity "github.com/DataDog/extendeddaemonset/pkg/controller/utils/affinity"
 )
 
+// PredicateName is the name of a predicate evaluated by CheckNodeFitness.
+type PredicateName string
+
+const (
+	// PredicateNodeSelector checks the pod's NodeSelector against the node labels.
+	PredicateNodeSelector PredicateName = "NodeSelector"
+	// PredicateNodeAffinity checks the pod's required NodeAffinity against the node.
+	PredicateNodeAffinity PredicateName = "NodeAffinity"
+	// PredicateTaints checks that the pod tolerates the node taints.
+	PredicateTaints PredicateName = "PodToleratesNodeTaints"
+)
+
+// PredicateFailure describes why a predicate rejected a node.
+type PredicateFailure struct {
+	Predicate PredicateName
+	Message   string
+}
+
+// String implements the fmt.Stringer interface.
+func (f PredicateFailure) String() string {
+	return fmt.Sprintf("%s: %s", f.Predicate, f.Message)
+}
+
+// FitnessResult is the result returned by CheckNodeFitness.
+type FitnessResult struct {
+	// Failures contains a PredicateFailure for each selector term, affinity requirement or taint
+	// that prevents the pod from being scheduled on the node.
+	Failures []PredicateFailure
+}
+
+// Fit returns true if the pod can be scheduled on the node.
+func (r FitnessResult) Fit() bool {
+	return len(r.Failures) == 0
+}
+
+// String implements the fmt.Stringer interface.
+func (r FitnessResult) String() string {
+	if r.Fit() {
+		return "fit"
+	}
+
+	messages := make([]string, 0, len(r.Failures))
+	for _, failure := range r.Failures {
+		messages = append(messages, failure.String())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
 // CheckNodeFitness runs a set of predicates that select candidate nodes for the DaemonSet;
 // the predicates include:
 //   - PodMatchNodeSelector: checks pod's NodeSelector and NodeAffinity against node
 //   - PodToleratesNodeTaints: exclude tainted node unless pod has specific toleration
-func CheckNodeFitness(logger logr.Logger, pod *corev1.Pod, node *corev1.Node) bool {
+//
+// All predicates are evaluated so the returned FitnessResult lists every reason why the node was rejected.
+func CheckNodeFitness(logger logr.Logger, pod *corev1.Pod, node *corev1.Node) FitnessResult {
+	result := FitnessResult{}
 	// Check pod node selector
 	// Check if node.Labels match pod.Spec.NodeSelector.
-	if !checkNodeSelector(pod, node) {
-		logger.V(1).Info("CheckNodeFitness return false", "reason", "node selector missmatch")
+	result.Failures = append(result.Failures, checkNodeSelector(pod, node)...)
+	result.Failures = append(result.Failures, checkPodToleratesNodeTaints(pod, node)...)
 
-		return false
+	if !result.Fit() {
+		logger.V(1).Info("CheckNodeFitness return false", "node.Name", node.Name, "reason", result.String())
 	}
 
-	if !checkPodToleratesNodeTaints(pod, node) {
-		logger.V(1).Info("CheckNodeFitness return false", "reason", "node taints")
-
-		return false
-	}
-
-	return true
+	return result
 }
 
-func checkNodeSelector(pod *corev1.Pod, node *corev1.Node) bool {
+func checkNodeSelector(pod *corev1.Pod, node *corev1.Node) []PredicateFailure {
+	var failures []PredicateFailure
 	if len(pod.Spec.NodeSelector) > 0 {
-		selector := labels.SelectorFromSet(pod.Spec.NodeSelector)
-		if !selector.Matches(labels.Set(node.Labels)) {
-			return false
+		keys := make([]string, 0, len(pod.Spec.NodeSelector))
+		for key := range pod.Spec.NodeSelector {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value := pod.Spec.NodeSelector[key]
+			nodeValue, found := node.Labels[key]
+			switch {
+			case !found:
+				failures = append(failures, PredicateFailure{
+					Predicate: PredicateNodeSelector,
+					Message:   fmt.Sprintf("node selector %s=%s not matched: label %s is missing", key, value, key),
+				})
+			case nodeValue != value:
+				failures = append(failures, PredicateFailure{
+					Predicate: PredicateNodeSelector,
+					Message:   fmt.Sprintf("node selector %s=%s not matched: node has %s=%s", key, value, key, nodeValue),
+				})
+			}
 		}
 	}
 
 	// check node affinity
-	nodeAffinityMatches := true
-
 	if affinity := pod.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil {
 		nodeAffinity := affinity.NodeAffinity
-		if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
-			return true
-		}
-
 		// Match node selector for requiredDuringSchedulingIgnoredDuringExecution.
 		if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
 			nodeSelectorTerms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
-			nodeAffinityMatches = nodeAffinityMatches && nodeMatchesNodeSelectorTerms(node, nodeSelectorTerms)
+			if !nodeMatchesNodeSelectorTerms(node, nodeSelectorTerms) {
+				failures = append(failures, explainNodeSelectorTerms(node, nodeSelectorTerms)...)
+			}
+		}
+	}
+
+	return failures
+}
+
+func checkPodToleratesNodeTaints(pod *corev1.Pod, node *corev1.Node) []PredicateFailure {
+	var failures []PredicateFailure
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+
+		if !TolerationsTolerateTaint(pod.Spec.Tolerations, taint) {
+			failures = append(failures, PredicateFailure{
+				Predicate: PredicateTaints,
+				Message:   fmt.Sprintf("taint %s is not tolerated", taint.ToString()),
+			})
+		}
+	}
+
+	return failures
+}
+
+// explainNodeSelectorTerms returns, for each node selector term, the first requirement that the node doesn't match.
+// It should only be called when none of the terms match the node.
+func explainNodeSelectorTerms(node *corev1.Node, nodeSelectorTerms []corev1.NodeSelectorTerm) []PredicateFailure {
+	if len(nodeSelectorTerms) == 0 {
+		return []PredicateFailure{{Predicate: PredicateNodeAffinity, Message: "no node selector terms, nothing matches"}}
+	}
+
+	nodeLabels := labels.Set(node.Labels)
+	nodeFields := fields.Set{podaffinity.NodeFieldSelectorKeyNodeName: node.Name}
+
+	failures := make([]PredicateFailure, 0, len(nodeSelectorTerms))
+	for id, term := range nodeSelectorTerms {
+		failures = append(failures, PredicateFailure{
+			Predicate: PredicateNodeAffinity,
+			Message:   fmt.Sprintf("nodeSelectorTerms[%d]: %s", id, explainNodeSelectorTerm(term, nodeLabels, nodeFields)),
+		})
+	}
+
+	return failures
+}
+
+func explainNodeSelectorTerm(term corev1.NodeSelectorTerm, nodeLabels labels.Labels, nodeFields fields.Fields) string {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return "empty term matches no node"
+	}
+
+	for _, req := range term.MatchExpressions {
+		selector, err := NodeSelectorRequirementsAsSelector([]corev1.NodeSelectorRequirement{req})
+		if err != nil {
+			return fmt.Sprintf("invalid matchExpressions requirement %s, err: %v", requirementToString(req), err)
+		}
+		if !selector.Matches(nodeLabels) {
+			return fmt.Sprintf("matchExpressions requirement %s not matched", requirementToString(req))
+		}
+	}
+
+	for _, req := range term.MatchFields {
+		selector, err := NodeSelectorRequirementsAsFieldSelector([]corev1.NodeSelectorRequirement{req})
+		if err != nil {
+			return fmt.Sprintf("invalid matchFields requirement %s, err: %v", requirementToString(req), err)
+		}
+		if !selector.Matches(nodeFields) {
+			return fmt.Sprintf("matchFields requirement %s not matched", requirementToString(req))
 		}
 	}
 
-	return nodeAffinityMatches
+	return "term not matched"
 }
 
-func checkPodToleratesNodeTaints(pod *corev1.Pod, node *corev1.Node) bool {
-	filter := func(t *corev1.Taint) bool {
-		return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
+func requirementToString(req corev1.NodeSelectorRequirement) string {
+	str := strings.TrimSpace(fmt.Sprintf("%s %s", req.Key, req.Operator))
+	if len(req.Values) == 0 {
+		return str
 	}
 
-	return TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, filter)
+	return fmt.Sprintf("%s [%s]", str, strings.Join(req.Values, ","))
 }
 
 // nodeMatchesNodeSelectorTerms checks if a node's labels satisfy a list of node selector terms,
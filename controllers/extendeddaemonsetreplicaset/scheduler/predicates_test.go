@@ -86,6 +86,10 @@ func TestCheckNodeFitness(t *testing.T) {
 	node2 := ctrltest.NewNode("node2", nodeKOOptions)
 	node3 := ctrltest.NewNode("node3", nodeUnscheduledOptions)
 	node4 := ctrltest.NewNode("node4", nodeTaintedOptions)
+	node5 := ctrltest.NewNode("node5", &ctrltest.NewNodeOptions{
+		Labels:     map[string]string{"app": "bar"},
+		Conditions: nodeReadyOptions.Conditions,
+	})
 
 	pod1 := ctrltest.NewPod("foo", "pod1", "", &ctrltest.NewPodOptions{
 		CreationTimestamp: metav1.NewTime(now),
@@ -173,9 +177,10 @@ func TestCheckNodeFitness(t *testing.T) {
 		node *corev1.Node
 	}
 	tests := []struct {
-		name string
-		args args
-		want bool
+		name         string
+		args         args
+		want         bool
+		wantFailures []PredicateFailure
 	}{
 		{
 			name: "node ready",
@@ -208,6 +213,9 @@ func TestCheckNodeFitness(t *testing.T) {
 				node: node4,
 			},
 			want: false,
+			wantFailures: []PredicateFailure{
+				{Predicate: PredicateTaints, Message: "taint mytaint:NoSchedule is not tolerated"},
+			},
 		},
 		{
 			name: "pod with match expression",
@@ -216,6 +224,9 @@ func TestCheckNodeFitness(t *testing.T) {
 				node: node1,
 			},
 			want: false,
+			wantFailures: []PredicateFailure{
+				{Predicate: PredicateNodeAffinity, Message: `nodeSelectorTerms[0]: invalid matchExpressions requirement string, err: "" is not a valid node selector operator`},
+			},
 		},
 		{
 			name: "pod with nil RequiredDuringSchedulingIgnoredDuringExecution",
@@ -232,6 +243,9 @@ func TestCheckNodeFitness(t *testing.T) {
 				node: node1,
 			},
 			want: false,
+			wantFailures: []PredicateFailure{
+				{Predicate: PredicateNodeAffinity, Message: `nodeSelectorTerms[0]: invalid matchFields requirement string, err: "" is not a valid node field selector operator`},
+			},
 		},
 		{
 			name: "pod with empty match expression and field",
@@ -240,12 +254,30 @@ func TestCheckNodeFitness(t *testing.T) {
 				node: node1,
 			},
 			want: false,
+			wantFailures: []PredicateFailure{
+				{Predicate: PredicateNodeAffinity, Message: "nodeSelectorTerms[0]: empty term matches no node"},
+			},
+		},
+		{
+			name: "node selector mismatch",
+			args: args{
+				pod:  pod1,
+				node: node5,
+			},
+			want: false,
+			wantFailures: []PredicateFailure{
+				{Predicate: PredicateNodeSelector, Message: "node selector app=foo not matched: node has app=bar"},
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := CheckNodeFitness(log.WithName(tt.name), tt.args.pod, tt.args.node); got != tt.want {
-				t.Errorf("CheckNodeFitness() = %v, want %v", got, tt.want)
+			got := CheckNodeFitness(log.WithName(tt.name), tt.args.pod, tt.args.node)
+			if got.Fit() != tt.want {
+				t.Errorf("CheckNodeFitness().Fit() = %v, want %v", got.Fit(), tt.want)
+			}
+			if !reflect.DeepEqual(got.Failures, tt.wantFailures) {
+				t.Errorf("CheckNodeFitness().Failures = %v, want %v", got.Failures, tt.wantFailures)
 			}
 		})
 	}
@@ -0,0 +1,500 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2020 Datadog, Inc.
+
+// Package simulator runs an offline simulation of an ExtendedDaemonSet rollout.
+// It drives the ExtendedDaemonSetReplicaSet strategies with a fake clock and a synthetic
+// population of nodes and pods, and returns a timeline of the pods updated over time.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	eds "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonset"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/strategy"
+)
+
+const (
+	simulationNamespace = "simulation"
+	simulationEDSName   = "simulation"
+	oldTemplateHash     = "old"
+	newTemplateHash     = "new"
+
+	defaultMaxDuration = 24 * time.Hour
+)
+
+// Phase represents the rollout phase of the simulated ExtendedDaemonSetReplicaSet.
+type Phase string
+
+const (
+	// PhaseCanary the new ExtendedDaemonSetReplicaSet is deployed on the canary nodes.
+	PhaseCanary Phase = "canary"
+	// PhaseRollingUpdate the new ExtendedDaemonSetReplicaSet is rolled out on all the nodes.
+	PhaseRollingUpdate Phase = "rollingUpdate"
+)
+
+// Outcome represents how the simulation ended.
+type Outcome string
+
+const (
+	// OutcomeCompleted all the nodes run a ready pod from the new template.
+	OutcomeCompleted Outcome = "Completed"
+	// OutcomeCanaryFailed the canary deployment was automatically failed.
+	OutcomeCanaryFailed Outcome = "CanaryFailed"
+	// OutcomeCanaryPaused the canary deployment was automatically paused and requires a manual action.
+	OutcomeCanaryPaused Outcome = "CanaryPaused"
+	// OutcomeRollingUpdateFailed the rolling update was failed by its health gate.
+	OutcomeRollingUpdateFailed Outcome = "RollingUpdateFailed"
+	// OutcomeRollingUpdatePaused the rolling update was paused by its health gate and requires a manual action.
+	OutcomeRollingUpdatePaused Outcome = "RollingUpdatePaused"
+	// OutcomeTimeout the rollout didn't complete before Options.MaxDuration.
+	OutcomeTimeout Outcome = "Timeout"
+)
+
+// Options configures a rollout simulation.
+type Options struct {
+	// NbNodes is the number of nodes in the synthetic cluster.
+	NbNodes int
+	// Strategy is the ExtendedDaemonSet strategy to simulate. It is defaulted like the controller does.
+	Strategy datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy
+	// PodStartupDuration is the time needed by a new pod to become ready.
+	PodStartupDuration time.Duration
+	// PodTerminationDuration is the time needed by a deleted pod to disappear.
+	PodTerminationDuration time.Duration
+	// FailureRate is the probability, between 0 and 1, that a new pod never becomes ready and keeps restarting.
+	FailureRate float64
+	// Seed initializes the random generator used to pick the failing pods.
+	Seed int64
+	// MaxDuration stops the simulation if the rollout is not finished. Defaults to 24h.
+	MaxDuration time.Duration
+}
+
+// TimelineEntry is a snapshot of the rollout at a given time.
+type TimelineEntry struct {
+	// Elapsed is the time elapsed since the beginning of the rollout.
+	Elapsed time.Duration
+	Phase   Phase
+	// Updated is the number of nodes running a pod from the new template.
+	Updated int
+	// UpdatedReady is the number of nodes running a ready pod from the new template.
+	UpdatedReady int
+	// Old is the number of nodes still running a pod from the old template.
+	Old int
+	// Unavailable is the number of nodes without a ready pod.
+	Unavailable int
+	// Failing is the number of pods from the new template that keep restarting.
+	Failing int
+	// Created is the number of pods created during this step.
+	Created int
+	// Deleted is the number of pods deleted during this step.
+	Deleted int
+}
+
+// Report is the result of a rollout simulation.
+type Report struct {
+	Outcome Outcome
+	// Reason provides details when the rollout didn't complete.
+	Reason string
+	// Duration is the simulated duration of the rollout.
+	Duration time.Duration
+	// CanaryDuration is the simulated duration of the canary phase.
+	CanaryDuration time.Duration
+	// Timeline contains an entry for each reconcile step that changed the pods.
+	Timeline []TimelineEntry
+}
+
+// simPod is the simulated state of a pod.
+type simPod struct {
+	name         string
+	templateHash string
+	createdAt    time.Time
+	readyAt      time.Time
+	failing      bool
+	deletedAt    *time.Time
+}
+
+// simulation holds the state of a running simulation.
+type simulation struct {
+	opts   Options
+	rand   *rand.Rand
+	client client.Client
+	start  time.Time
+
+	daemonset  *datadoghqv1alpha1.ExtendedDaemonSet
+	replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet
+
+	nodes       []*strategy.NodeItem
+	pods        map[string]*simPod
+	canaryNodes []string
+	podID       int
+}
+
+// Run simulates the rollout of a new ExtendedDaemonSetReplicaSet on a cluster where all the nodes
+// run a ready pod from the previous one. The canary phase is simulated if a canary strategy is set;
+// with the manual validation mode, the canary is considered validated as soon as its duration ended.
+// Likewise, with the manual batch approval, each batch of the rolling update is approved as soon as it is completed.
+func Run(opts Options) (*Report, error) {
+	if err := validateOptions(&opts); err != nil {
+		return nil, err
+	}
+
+	s := newSimulation(opts)
+	report := &Report{}
+	phase := PhaseRollingUpdate
+	if s.canaryNodes != nil {
+		phase = PhaseCanary
+	}
+
+	tick := s.daemonset.Spec.Strategy.ReconcileFrequency.Duration
+	var lastEntry *TimelineEntry
+	for now := s.start; now.Sub(s.start) <= opts.MaxDuration; now = now.Add(tick) {
+		s.advance(now)
+
+		params := s.buildParameters(now, phase)
+		var result *strategy.Result
+		var err error
+		switch phase {
+		case PhaseCanary:
+			result, err = strategy.ManageCanaryDeployment(s.client, s.daemonset, params, metav1.NewTime(now))
+		case PhaseRollingUpdate:
+			result, err = strategy.ManageDeployment(s.client, s.daemonset, params, metav1.NewTime(now))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to run the %s strategy, err: %w", phase, err)
+		}
+		s.replicaset.Status = *result.NewStatus
+
+		entry := s.snapshot(now, phase)
+		entry.Deleted = s.deletePods(now, result.PodsToDelete)
+		entry.Created = s.createPods(now, result.PodsToCreate)
+		if lastEntry == nil || entry.Created != 0 || entry.Deleted != 0 || !sameCounters(lastEntry, &entry) {
+			report.Timeline = append(report.Timeline, entry)
+			lastEntry = &report.Timeline[len(report.Timeline)-1]
+		}
+
+		if phase == PhaseCanary {
+			switch {
+			case result.IsFailed:
+				return s.end(report, now, OutcomeCanaryFailed, fmt.Sprintf("canary failed with reason: %s", result.FailedReason)), nil
+			case result.IsPaused:
+				return s.end(report, now, OutcomeCanaryPaused, fmt.Sprintf("canary paused with reason: %s", result.PausedReason)), nil
+			}
+
+			if ended, _ := eds.IsCanaryDeploymentEnded(s.daemonset.Spec.Strategy.Canary, s.replicaset, now); ended {
+				report.CanaryDuration = now.Sub(s.start)
+				phase = PhaseRollingUpdate
+			}
+
+			continue
+		}
+
+		if entry.UpdatedReady == opts.NbNodes {
+			return s.end(report, now, OutcomeCompleted, ""), nil
+		}
+
+		switch {
+		case result.IsFailed:
+			return s.end(report, now, OutcomeRollingUpdateFailed, fmt.Sprintf("rolling update failed with reason: %s", result.FailedReason)), nil
+		case eds.IsRollingUpdatePausedForUnhealthyPods(s.replicaset):
+			return s.end(report, now, OutcomeRollingUpdatePaused, fmt.Sprintf("rolling update paused with reason: %s", datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods)), nil
+		}
+
+		if eds.IsRollingUpdateWaitingForBatchApproval(s.replicaset) {
+			s.approveNextBatch()
+		}
+	}
+
+	return s.end(report, s.start.Add(opts.MaxDuration), OutcomeTimeout, fmt.Sprintf("rollout not completed after %s", opts.MaxDuration)), nil
+}
+
+func validateOptions(opts *Options) error {
+	if opts.NbNodes <= 0 {
+		return errors.New("the number of nodes must be greater than 0")
+	}
+	if opts.FailureRate < 0 || opts.FailureRate > 1 {
+		return errors.New("the failure rate must be between 0 and 1")
+	}
+	if opts.PodStartupDuration < 0 || opts.PodTerminationDuration < 0 {
+		return errors.New("the pod startup and termination durations must be positive")
+	}
+	if opts.MaxDuration == 0 {
+		opts.MaxDuration = defaultMaxDuration
+	}
+
+	return nil
+}
+
+func newSimulation(opts Options) *simulation {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	daemonset := &datadoghqv1alpha1.ExtendedDaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: simulationNamespace,
+			Name:      simulationEDSName,
+		},
+		Spec: datadoghqv1alpha1.ExtendedDaemonSetSpec{
+			Strategy: *opts.Strategy.DeepCopy(),
+		},
+	}
+	// The validation mode only changes how the canary ends, which is handled by the simulation itself.
+	datadoghqv1alpha1.DefaultExtendedDaemonSetSpec(&daemonset.Spec, datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationModeAuto)
+
+	s := &simulation{
+		opts:      opts,
+		rand:      rand.New(rand.NewSource(opts.Seed)),
+		client:    fake.NewClientBuilder().Build(),
+		start:     start,
+		daemonset: daemonset,
+		replicaset: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         simulationNamespace,
+				Name:              simulationEDSName + "-" + newTemplateHash,
+				CreationTimestamp: metav1.NewTime(start),
+			},
+			Spec: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetSpec{
+				TemplateGeneration: newTemplateHash,
+			},
+		},
+		pods: make(map[string]*simPod, opts.NbNodes),
+	}
+
+	for i := range opts.NbNodes {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("node-%d", i),
+			},
+		}
+		s.nodes = append(s.nodes, strategy.NewNodeItem(node, nil))
+		s.pods[node.Name] = &simPod{
+			name:         s.newPodName(node.Name),
+			templateHash: oldTemplateHash,
+			createdAt:    start.Add(-time.Hour),
+			readyAt:      start.Add(-time.Hour),
+		}
+	}
+
+	if canary := daemonset.Spec.Strategy.Canary; canary != nil {
+		nbCanaryNodes, _ := intstrutil.GetScaledValueFromIntOrPercent(canary.Replicas, opts.NbNodes, true)
+		nbCanaryNodes = max(min(nbCanaryNodes, opts.NbNodes), 1)
+		s.canaryNodes = make([]string, 0, nbCanaryNodes)
+		for _, node := range s.nodes[:nbCanaryNodes] {
+			s.canaryNodes = append(s.canaryNodes, node.Node.Name)
+		}
+	}
+
+	return s
+}
+
+func (s *simulation) newPodName(nodeName string) string {
+	s.podID++
+
+	return fmt.Sprintf("%s-%s-%d", simulationEDSName, nodeName, s.podID)
+}
+
+// advance removes the pods that finished their termination.
+func (s *simulation) advance(now time.Time) {
+	for nodeName, pod := range s.pods {
+		if pod.deletedAt != nil && !now.Before(pod.deletedAt.Add(s.opts.PodTerminationDuration)) {
+			delete(s.pods, nodeName)
+		}
+	}
+}
+
+func (s *simulation) buildParameters(now time.Time, phase Phase) *strategy.Parameters {
+	params := &strategy.Parameters{
+		EDSName:          s.daemonset.Name,
+		Strategy:         &s.daemonset.Spec.Strategy,
+		Replicaset:       s.replicaset,
+		ReplicaSetStatus: string(strategy.ReplicaSetStatusActive),
+		NewStatus:        s.replicaset.Status.DeepCopy(),
+		MinReadySeconds:  s.daemonset.Spec.MinReadySeconds,
+		NodeByName:       make(map[string]*strategy.NodeItem, len(s.nodes)),
+		PodByNodeName:    make(map[*strategy.NodeItem]*corev1.Pod, len(s.nodes)),
+		Logger:           logr.Discard(),
+	}
+	if phase == PhaseCanary {
+		params.ReplicaSetStatus = string(strategy.ReplicaSetStatusCanary)
+		params.CanaryNodes = s.canaryNodes
+	}
+
+	for _, node := range s.nodes {
+		params.NodeByName[node.Node.Name] = node
+		var pod *corev1.Pod
+		if simPod, found := s.pods[node.Node.Name]; found {
+			pod = s.toPod(simPod, node.Node.Name, now, phase)
+		}
+		params.PodByNodeName[node] = pod
+	}
+
+	return params
+}
+
+// toPod generates the pod, as seen by the strategies, at the given simulation time.
+func (s *simulation) toPod(simPod *simPod, nodeName string, now time.Time, phase Phase) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         simulationNamespace,
+			Name:              simPod.name,
+			UID:               types.UID(simPod.name),
+			CreationTimestamp: metav1.NewTime(simPod.createdAt),
+			Annotations: map[string]string{
+				datadoghqv1alpha1.MD5ExtendedDaemonSetAnnotationKey: simPod.templateHash,
+			},
+			Labels: map[string]string{
+				datadoghqv1alpha1.ExtendedDaemonSetNameLabelKey: simulationEDSName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &metav1.Time{Time: simPod.createdAt},
+		},
+	}
+	if simPod.templateHash == newTemplateHash {
+		pod.Labels[datadoghqv1alpha1.ExtendedDaemonSetReplicaSetNameLabelKey] = s.replicaset.Name
+		if phase == PhaseCanary {
+			pod.Labels[datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCanaryLabelKey] = datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCanaryLabelValue
+		}
+	}
+	if simPod.deletedAt != nil {
+		pod.DeletionTimestamp = &metav1.Time{Time: *simPod.deletedAt}
+	}
+
+	containerStatus := corev1.ContainerStatus{Name: "agent"}
+	ready := corev1.ConditionFalse
+	switch {
+	case simPod.failing && !now.Before(simPod.readyAt):
+		// The pod restarts each time it should have become ready.
+		restarts := 1 + int32(now.Sub(simPod.readyAt)/s.restartInterval())
+		lastRestart := simPod.readyAt.Add(time.Duration(restarts-1) * s.restartInterval())
+		containerStatus.RestartCount = restarts
+		containerStatus.State.Waiting = &corev1.ContainerStateWaiting{Reason: string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonCLB)}
+		containerStatus.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+			ExitCode:   1,
+			Reason:     "Error",
+			FinishedAt: metav1.NewTime(lastRestart),
+		}
+	case now.Before(simPod.readyAt):
+		containerStatus.State.Waiting = &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}
+	default:
+		ready = corev1.ConditionTrue
+		containerStatus.Ready = true
+		containerStatus.State.Running = &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(simPod.readyAt)}
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{containerStatus}
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+		{Type: corev1.PodReady, Status: ready, LastTransitionTime: metav1.NewTime(simPod.readyAt)},
+	}
+
+	return pod
+}
+
+func (s *simulation) restartInterval() time.Duration {
+	return max(s.opts.PodStartupDuration, s.daemonset.Spec.Strategy.ReconcileFrequency.Duration)
+}
+
+func (s *simulation) deletePods(now time.Time, nodes []*strategy.NodeItem) int {
+	var deleted int
+	for _, node := range nodes {
+		pod, found := s.pods[node.Node.Name]
+		if !found || pod.deletedAt != nil {
+			continue
+		}
+		deletedAt := now
+		pod.deletedAt = &deletedAt
+		deleted++
+	}
+
+	return deleted
+}
+
+func (s *simulation) createPods(now time.Time, nodes []*strategy.NodeItem) int {
+	var created int
+	for _, node := range nodes {
+		if _, found := s.pods[node.Node.Name]; found {
+			continue
+		}
+		s.pods[node.Node.Name] = &simPod{
+			name:         s.newPodName(node.Node.Name),
+			templateHash: newTemplateHash,
+			createdAt:    now,
+			readyAt:      now.Add(s.opts.PodStartupDuration),
+			failing:      s.rand.Float64() < s.opts.FailureRate,
+		}
+		created++
+	}
+
+	return created
+}
+
+// snapshot counts the pods per state at the given time.
+func (s *simulation) snapshot(now time.Time, phase Phase) TimelineEntry {
+	entry := TimelineEntry{
+		Elapsed: now.Sub(s.start),
+		Phase:   phase,
+	}
+	for _, node := range s.nodes {
+		pod, found := s.pods[node.Node.Name]
+		if !found || pod.deletedAt != nil {
+			entry.Unavailable++
+
+			continue
+		}
+
+		ready := !pod.failing && !now.Before(pod.readyAt)
+		if !ready {
+			entry.Unavailable++
+		}
+		if pod.templateHash == oldTemplateHash {
+			entry.Old++
+
+			continue
+		}
+
+		entry.Updated++
+		if ready {
+			entry.UpdatedReady++
+		}
+		if pod.failing && !now.Before(pod.readyAt) {
+			entry.Failing++
+		}
+	}
+
+	return entry
+}
+
+// approveNextBatch approves the next batch of the rolling update, like `kubectl eds rollout next` does.
+func (s *simulation) approveNextBatch() {
+	if s.daemonset.Annotations == nil {
+		s.daemonset.Annotations = map[string]string{}
+	}
+	s.daemonset.Annotations[datadoghqv1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey] = datadoghqv1alpha1.RollingUpdateApprovedBatchValue(s.replicaset.Name, s.replicaset.Status.RollingUpdateBatch.Number)
+}
+
+func (s *simulation) end(report *Report, now time.Time, outcome Outcome, reason string) *Report {
+	report.Outcome = outcome
+	report.Reason = reason
+	report.Duration = now.Sub(s.start)
+
+	return report
+}
+
+func sameCounters(a, b *TimelineEntry) bool {
+	return a.Phase == b.Phase && a.Updated == b.Updated && a.UpdatedReady == b.UpdatedReady &&
+		a.Old == b.Old && a.Unavailable == b.Unavailable && a.Failing == b.Failing
+}
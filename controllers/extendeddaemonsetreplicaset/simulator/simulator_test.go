@@ -0,0 +1,133 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2020 Datadog, Inc.
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func TestRun(t *testing.T) {
+	maxUnavailable := intstr.FromInt(10)
+	rollingUpdate := datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+		MaxUnavailable: &maxUnavailable,
+	}
+	manualBatchApproval := true
+
+	tests := []struct {
+		name               string
+		opts               Options
+		wantErr            bool
+		wantOutcome        Outcome
+		wantUpdatedReady   int
+		wantCanaryDuration time.Duration
+	}{
+		{
+			name:    "invalid number of nodes",
+			opts:    Options{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid failure rate",
+			opts:    Options{NbNodes: 10, FailureRate: 2},
+			wantErr: true,
+		},
+		{
+			name: "rolling update completed",
+			opts: Options{
+				NbNodes:            100,
+				Strategy:           datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{RollingUpdate: rollingUpdate},
+				PodStartupDuration: 30 * time.Second,
+			},
+			wantOutcome:      OutcomeCompleted,
+			wantUpdatedReady: 100,
+		},
+		{
+			name: "rolling update with manual batch approval completed",
+			opts: Options{
+				NbNodes: 30,
+				Strategy: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+						MaxUnavailable:      &maxUnavailable,
+						ManualBatchApproval: &manualBatchApproval,
+					},
+				},
+				PodStartupDuration: 30 * time.Second,
+			},
+			wantOutcome:      OutcomeCompleted,
+			wantUpdatedReady: 30,
+		},
+		{
+			name: "canary then rolling update completed",
+			opts: Options{
+				NbNodes: 50,
+				Strategy: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: rollingUpdate,
+					Canary: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+						Duration: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+				PodStartupDuration: 20 * time.Second,
+			},
+			wantOutcome:        OutcomeCompleted,
+			wantUpdatedReady:   50,
+			wantCanaryDuration: 5*time.Minute + 10*time.Second,
+		},
+		{
+			name: "canary paused when pods keep restarting",
+			opts: Options{
+				NbNodes: 50,
+				Strategy: datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: rollingUpdate,
+					Canary: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+						Duration: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+				PodStartupDuration: 20 * time.Second,
+				FailureRate:        1,
+			},
+			wantOutcome: OutcomeCanaryPaused,
+		},
+		{
+			name: "rolling update stuck when pods keep restarting",
+			opts: Options{
+				NbNodes:            100,
+				Strategy:           datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{RollingUpdate: rollingUpdate},
+				PodStartupDuration: 20 * time.Second,
+				FailureRate:        1,
+				MaxDuration:        time.Hour,
+			},
+			wantOutcome: OutcomeTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Run(tt.opts)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOutcome, report.Outcome)
+			assert.Equal(t, tt.wantCanaryDuration, report.CanaryDuration)
+			require.NotEmpty(t, report.Timeline)
+			last := report.Timeline[len(report.Timeline)-1]
+			assert.Equal(t, tt.wantUpdatedReady, last.UpdatedReady)
+			if tt.wantOutcome == OutcomeCompleted {
+				assert.Equal(t, report.Duration, last.Elapsed)
+				assert.Equal(t, 0, last.Old)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonsetreplicaset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/strategy"
+	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
+)
+
+// manageStartupTaint removes the ExtendedDaemonSet startup taint from the nodes whose pod is ready, and adds it back on
+// the nodes whose pod is deleted if spec.startupTaint.reAddOnPodDeletion is true.
+// The active replicaset manages all the nodes except the canary ones, which are managed by the canary replicaset.
+func manageStartupTaint(logger logr.Logger, c client.Client, daemonset *datadoghqv1alpha1.ExtendedDaemonSet, params *strategy.Parameters, now metav1.Time) []error {
+	startupTaint := daemonset.Spec.StartupTaint
+	if startupTaint == nil {
+		return nil
+	}
+
+	canaryNodes := make(map[string]bool, len(params.CanaryNodes))
+	for _, nodeName := range params.CanaryNodes {
+		canaryNodes[nodeName] = true
+	}
+
+	var errs []error
+	for node, pod := range params.PodByNodeName {
+		switch strategy.ReplicaSetStatus(params.ReplicaSetStatus) {
+		case strategy.ReplicaSetStatusActive:
+			if canaryNodes[node.Node.Name] {
+				continue
+			}
+		case strategy.ReplicaSetStatusCanary:
+			if !canaryNodes[node.Node.Name] {
+				continue
+			}
+		default:
+			return nil
+		}
+
+		hasTaint := hasNodeTaint(node.Node, startupTaint.Key)
+		switch {
+		case hasTaint && pod != nil && pod.DeletionTimestamp == nil && podutils.IsPodReady(pod):
+			logger.Info("Remove the startup taint from the node", "node", node.Node.Name, "taint", startupTaint.Key)
+			if err := removeNodeTaint(c, node.Node, startupTaint.Key); err != nil {
+				errs = append(errs, err)
+			}
+		case !hasTaint && (pod == nil || pod.DeletionTimestamp != nil) && isStartupTaintReAdded(startupTaint):
+			logger.Info("Add the startup taint back on the node", "node", node.Node.Name, "taint", startupTaint.Key)
+			if err := addNodeTaint(c, node.Node, newStartupTaint(startupTaint, now)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
+
+func isStartupTaintReAdded(startupTaint *datadoghqv1alpha1.ExtendedDaemonSetStartupTaint) bool {
+	return startupTaint.ReAddOnPodDeletion != nil && *startupTaint.ReAddOnPodDeletion
+}
+
+func newStartupTaint(startupTaint *datadoghqv1alpha1.ExtendedDaemonSetStartupTaint, now metav1.Time) corev1.Taint {
+	taint := corev1.Taint{
+		Key:    startupTaint.Key,
+		Effect: startupTaint.Effect,
+	}
+	if taint.Effect == "" {
+		taint.Effect = corev1.TaintEffectNoSchedule
+	}
+	if taint.Effect == corev1.TaintEffectNoExecute {
+		taint.TimeAdded = &now
+	}
+
+	return taint
+}
+
+func hasNodeTaint(node *corev1.Node, key string) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeNodeTaint(c client.Client, node *corev1.Node, key string) error {
+	newNode := node.DeepCopy()
+	newNode.Spec.Taints = nil
+	for _, taint := range node.Spec.Taints {
+		if taint.Key != key {
+			newNode.Spec.Taints = append(newNode.Spec.Taints, taint)
+		}
+	}
+	if err := c.Patch(context.TODO(), newNode, client.MergeFromWithOptions(node, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("unable to remove the taint %s from the node %s, err: %w", key, node.Name, err)
+	}
+
+	return nil
+}
+
+func addNodeTaint(c client.Client, node *corev1.Node, taint corev1.Taint) error {
+	newNode := node.DeepCopy()
+	newNode.Spec.Taints = append(newNode.Spec.Taints, taint)
+	if err := c.Patch(context.TODO(), newNode, client.MergeFromWithOptions(node, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("unable to add the taint %s on the node %s, err: %w", taint.Key, node.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonsetreplicaset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/strategy"
+	ctrltest "github.com/DataDog/extendeddaemonset/pkg/controller/test"
+)
+
+func Test_manageStartupTaint(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	startupTaint := corev1.Taint{Key: "example.com/agent-not-ready", Effect: corev1.TaintEffectNoSchedule}
+	otherTaint := corev1.Taint{Key: "dedicated", Value: "agents", Effect: corev1.TaintEffectNoSchedule}
+	readyPod := ctrltest.NewPod("bar", "pod1", "node1", nil)
+	readyPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	notReadyPod := ctrltest.NewPod("bar", "pod1", "node1", nil)
+
+	tests := []struct {
+		name        string
+		reAdd       bool
+		rsStatus    strategy.ReplicaSetStatus
+		canaryNodes []string
+		nodeTaints  []corev1.Taint
+		pod         *corev1.Pod
+		wantTaints  []corev1.Taint
+	}{
+		{
+			name:       "pod ready, taint removed",
+			rsStatus:   strategy.ReplicaSetStatusActive,
+			nodeTaints: []corev1.Taint{otherTaint, startupTaint},
+			pod:        readyPod,
+			wantTaints: []corev1.Taint{otherTaint},
+		},
+		{
+			name:       "pod not ready, taint kept",
+			rsStatus:   strategy.ReplicaSetStatusActive,
+			nodeTaints: []corev1.Taint{startupTaint},
+			pod:        notReadyPod,
+			wantTaints: []corev1.Taint{startupTaint},
+		},
+		{
+			name:        "canary node, managed by the canary replicaset",
+			rsStatus:    strategy.ReplicaSetStatusActive,
+			canaryNodes: []string{"node1"},
+			nodeTaints:  []corev1.Taint{startupTaint},
+			pod:         readyPod,
+			wantTaints:  []corev1.Taint{startupTaint},
+		},
+		{
+			name:        "canary node, pod ready",
+			rsStatus:    strategy.ReplicaSetStatusCanary,
+			canaryNodes: []string{"node1"},
+			nodeTaints:  []corev1.Taint{startupTaint},
+			pod:         readyPod,
+			wantTaints:  nil,
+		},
+		{
+			name:       "pod deleted, taint not added back",
+			rsStatus:   strategy.ReplicaSetStatusActive,
+			wantTaints: nil,
+		},
+		{
+			name:       "pod deleted, taint added back",
+			reAdd:      true,
+			rsStatus:   strategy.ReplicaSetStatusActive,
+			nodeTaints: []corev1.Taint{otherTaint},
+			wantTaints: []corev1.Taint{otherTaint, startupTaint},
+		},
+		{
+			name:       "unknown replicaset, nothing done",
+			reAdd:      true,
+			rsStatus:   strategy.ReplicaSetStatusUnknown,
+			nodeTaints: []corev1.Taint{startupTaint},
+			pod:        readyPod,
+			wantTaints: []corev1.Taint{startupTaint},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := ctrltest.NewNode("node1", &ctrltest.NewNodeOptions{Taints: tt.nodeTaints})
+			c := fake.NewClientBuilder().WithObjects(node).Build()
+			// use the node returned by the client to get its resource version
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: "node1"}, node))
+
+			daemonset := &datadoghqv1alpha1.ExtendedDaemonSet{
+				Spec: datadoghqv1alpha1.ExtendedDaemonSetSpec{
+					StartupTaint: &datadoghqv1alpha1.ExtendedDaemonSetStartupTaint{Key: startupTaint.Key, ReAddOnPodDeletion: datadoghqv1alpha1.NewBool(tt.reAdd)},
+				},
+			}
+			params := &strategy.Parameters{
+				ReplicaSetStatus: string(tt.rsStatus),
+				CanaryNodes:      tt.canaryNodes,
+				PodByNodeName:    map[*strategy.NodeItem]*corev1.Pod{strategy.NewNodeItem(node, nil): tt.pod},
+			}
+
+			errs := manageStartupTaint(testLogger, c, daemonset, params, now)
+			assert.Empty(t, errs)
+
+			got := &corev1.Node{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: "node1"}, got))
+			assert.Equal(t, tt.wantTaints, got.Spec.Taints)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	eds "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonset"
+	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
+)
+
+// isManualBatchApproval returns true if the rolling update needs a manual approval between each batch.
+func isManualBatchApproval(strategy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy) bool {
+	return strategy.RollingUpdate.ManualBatchApproval != nil && *strategy.RollingUpdate.ManualBatchApproval
+}
+
+// manageRollingUpdateBatch restricts the pods deletions of a rolling update with manual batch approval to the nodes
+// of the current batch. A new batch of `batchSize` nodes is only started once all the pods of the previous batch
+// are up-to-date and available, and the next batch has been approved.
+// It returns the nodes whose pod can be deleted, and true if the rolling update waits for the approval of the next batch.
+func manageRollingUpdateBatch(params *Parameters, dsAnnotations map[string]string, allPodToDelete []*NodeItem, nbPodToDelete, batchSize int, now time.Time) ([]*NodeItem, bool) {
+	batch := params.NewStatus.RollingUpdateBatch
+	if batch != nil && !batch.Completed {
+		batch.Completed = isRollingUpdateBatchCompleted(params, batch, now)
+	}
+
+	if batch == nil || batch.Completed {
+		if len(allPodToDelete) == 0 {
+			// all the pods are already up-to-date
+			return nil, false
+		}
+		if batch != nil && !eds.IsRollingUpdateBatchApproved(dsAnnotations, params.Replicaset.Name, batch.Number) {
+			return nil, true
+		}
+
+		batch = newRollingUpdateBatch(batch, allPodToDelete, batchSize)
+		params.NewStatus.RollingUpdateBatch = batch
+		params.Logger.Info("Start rolling update batch", "batch", batch.Number, "nodes", batch.Nodes)
+	}
+
+	batchNodes := make(map[string]bool, len(batch.Nodes))
+	for _, nodeName := range batch.Nodes {
+		batchNodes[nodeName] = true
+	}
+	podsToDelete := []*NodeItem{}
+	for _, node := range allPodToDelete {
+		if len(podsToDelete) >= nbPodToDelete {
+			break
+		}
+		if batchNodes[node.Node.Name] {
+			podsToDelete = append(podsToDelete, node)
+		}
+	}
+
+	return podsToDelete, false
+}
+
+// newRollingUpdateBatch returns the batch following `previous`, with up to `batchSize` of the nodes running an outdated pod.
+func newRollingUpdateBatch(previous *datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch, allPodToDelete []*NodeItem, batchSize int) *datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch {
+	nodes := make([]string, 0, len(allPodToDelete))
+	for _, node := range allPodToDelete {
+		nodes = append(nodes, node.Node.Name)
+	}
+	// sort the nodes to get a stable batch
+	sort.Strings(nodes)
+
+	batch := &datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{
+		Number: 1,
+		Nodes:  nodes[:min(max(batchSize, 1), len(nodes))],
+	}
+	if previous != nil {
+		batch.Number = previous.Number + 1
+	}
+
+	return batch
+}
+
+// isRollingUpdateBatchCompleted returns true if all the nodes of the batch run an up-to-date and available pod.
+// Nodes removed from the cluster are ignored.
+func isRollingUpdateBatchCompleted(params *Parameters, batch *datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch, now time.Time) bool {
+	for _, nodeName := range batch.Nodes {
+		node, found := params.NodeByName[nodeName]
+		if !found {
+			continue
+		}
+		pod, found := params.PodByNodeName[node]
+		if !found {
+			continue
+		}
+		if pod == nil || !compareCurrentPodWithNewPod(params, pod, node) || !podutils.IsPodAvailable(pod, params.MinReadySeconds, now) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rollingUpdateBatchPausedMessage returns the message of the RollingUpdatePaused condition when the rolling update
+// waits for the approval of the next batch.
+func rollingUpdateBatchPausedMessage(batch *datadoghqv1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch) string {
+	return fmt.Sprintf("batch %d completed, waiting for the approval of the next batch", batch.Number)
+}
@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func Test_manageRollingUpdateBatch(t *testing.T) {
+	now := time.Now()
+	outdatedPods := map[*NodeItem]*v1.Pod{
+		testCanaryNodes["a"]: newTestPodOnNode("foo-a", "a", "v1", readyPodStatus),
+		testCanaryNodes["b"]: newTestPodOnNode("foo-b", "b", "v1", readyPodStatus),
+		testCanaryNodes["c"]: newTestPodOnNode("foo-c", "c", "v1", readyPodStatus),
+	}
+	firstBatchUpdatedPods := map[*NodeItem]*v1.Pod{
+		testCanaryNodes["a"]: newTestPodOnNode("foo-a", "a", "v2", readyPodStatus),
+		testCanaryNodes["b"]: newTestPodOnNode("foo-b", "b", "v1", readyPodStatus),
+		testCanaryNodes["c"]: newTestPodOnNode("foo-c", "c", "v1", readyPodStatus),
+	}
+
+	tests := []struct {
+		name           string
+		batch          *v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch
+		annotations    map[string]string
+		podByNodeName  map[*NodeItem]*v1.Pod
+		allPodToDelete []*NodeItem
+		nbPodToDelete  int
+		wantToDelete   []*NodeItem
+		wantWaiting    bool
+		wantBatch      *v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch
+	}{
+		{
+			name:           "first batch started",
+			podByNodeName:  outdatedPods,
+			allPodToDelete: []*NodeItem{testCanaryNodes["c"], testCanaryNodes["b"], testCanaryNodes["a"]},
+			nbPodToDelete:  1,
+			wantToDelete:   []*NodeItem{testCanaryNodes["b"]},
+			wantBatch:      &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a", "b"}},
+		},
+		{
+			name:           "batch in progress, only its nodes are updated",
+			batch:          &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}},
+			podByNodeName:  outdatedPods,
+			allPodToDelete: []*NodeItem{testCanaryNodes["c"], testCanaryNodes["b"], testCanaryNodes["a"]},
+			nbPodToDelete:  2,
+			wantToDelete:   []*NodeItem{testCanaryNodes["a"]},
+			wantBatch:      &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}},
+		},
+		{
+			name:           "batch completed, waiting for approval",
+			batch:          &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}},
+			podByNodeName:  firstBatchUpdatedPods,
+			allPodToDelete: []*NodeItem{testCanaryNodes["b"], testCanaryNodes["c"]},
+			nbPodToDelete:  2,
+			wantWaiting:    true,
+			wantBatch:      &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}, Completed: true},
+		},
+		{
+			name:           "batch completed, approval of another batch",
+			batch:          &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 2, Nodes: []string{"a"}},
+			annotations:    map[string]string{v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey: "foo-2/1"},
+			podByNodeName:  firstBatchUpdatedPods,
+			allPodToDelete: []*NodeItem{testCanaryNodes["b"], testCanaryNodes["c"]},
+			nbPodToDelete:  2,
+			wantWaiting:    true,
+			wantBatch:      &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 2, Nodes: []string{"a"}, Completed: true},
+		},
+		{
+			name:           "batch completed, approval left over from the previous replicaset",
+			batch:          &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}},
+			annotations:    map[string]string{v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey: "foo-1/1"},
+			podByNodeName:  firstBatchUpdatedPods,
+			allPodToDelete: []*NodeItem{testCanaryNodes["b"], testCanaryNodes["c"]},
+			nbPodToDelete:  2,
+			wantWaiting:    true,
+			wantBatch:      &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}, Completed: true},
+		},
+		{
+			name:           "batch completed and approved, next batch started",
+			batch:          &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}},
+			annotations:    map[string]string{v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey: "foo-2/1"},
+			podByNodeName:  firstBatchUpdatedPods,
+			allPodToDelete: []*NodeItem{testCanaryNodes["c"], testCanaryNodes["b"]},
+			nbPodToDelete:  2,
+			wantToDelete:   []*NodeItem{testCanaryNodes["b"]},
+			wantBatch:      &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 2, Nodes: []string{"b"}},
+		},
+		{
+			name:          "last batch completed",
+			batch:         &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}},
+			podByNodeName: map[*NodeItem]*v1.Pod{testCanaryNodes["a"]: newTestPodOnNode("foo-a", "a", "v2", readyPodStatus)},
+			wantBatch:     &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"a"}, Completed: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Parameters{
+				Logger:        testLogger,
+				NewStatus:     &v1alpha1.ExtendedDaemonSetReplicaSetStatus{RollingUpdateBatch: tt.batch},
+				Replicaset:    &v1alpha1.ExtendedDaemonSetReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "foo-2"}, Spec: v1alpha1.ExtendedDaemonSetReplicaSetSpec{TemplateGeneration: "v2"}},
+				NodeByName:    testCanaryNodes,
+				PodByNodeName: tt.podByNodeName,
+			}
+			batchSize := 2
+			if tt.batch != nil {
+				batchSize = len(tt.batch.Nodes)
+			}
+
+			toDelete, waiting := manageRollingUpdateBatch(params, tt.annotations, tt.allPodToDelete, tt.nbPodToDelete, batchSize, now)
+			assert.ElementsMatch(t, tt.wantToDelete, toDelete)
+			assert.Equal(t, tt.wantWaiting, waiting)
+			assert.Equal(t, tt.wantBatch, params.NewStatus.RollingUpdateBatch)
+		})
+	}
+}
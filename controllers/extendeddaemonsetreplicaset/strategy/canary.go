@@ -17,13 +17,14 @@ import (
 	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	eds "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonset"
 	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+	"github.com/DataDog/extendeddaemonset/pkg/controller/utils"
 	podUtils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
 )
 
 // ManageCanaryDeployment used to manage ReplicaSet in Canary state.
-func ManageCanaryDeployment(client client.Client, daemonset *v1alpha1.ExtendedDaemonSet, params *Parameters) (*Result, error) {
+func ManageCanaryDeployment(client client.Client, daemonset *v1alpha1.ExtendedDaemonSet, params *Parameters, metaNow metav1.Time) (*Result, error) {
 	// Manage canary status
-	result := manageCanaryStatus(daemonset.GetAnnotations(), params, time.Now())
+	result := manageCanaryStatus(daemonset.GetAnnotations(), params, metaNow.Time)
 
 	err := ensureCanaryPodLabels(client, params)
 	if err != nil {
@@ -33,8 +34,11 @@ func ManageCanaryDeployment(client client.Client, daemonset *v1alpha1.ExtendedDa
 	// Populate list of unscheduled pods on nodes due to resource limitation
 	result.UnscheduledNodesDueToResourcesConstraints = manageUnscheduledPodNodes(params.UnscheduledPods)
 
+	// The images pre-pull only runs with the active replicaset
+	result.PrePullPodsToDelete = cleanupPrePullPods(params)
+
 	// Cleanup Pods
-	err = cleanupPods(client, params.Logger, result.NewStatus, params.PodToCleanUp)
+	result.EvictionBlockedNodes, err = cleanupPods(client, params.Logger, result.NewStatus, params.PodToCleanUp, UseEvictionAPI(params.Strategy))
 	if err != nil {
 		result.Result = requeuePromptly()
 	}
@@ -54,7 +58,7 @@ func manageCanaryStatus(annotations map[string]string, params *Parameters, now t
 	result.IsUnpaused = eds.IsCanaryDeploymentUnpaused(annotations)
 
 	var (
-		desiredPods, currentPods, availablePods, readyPods int32
+		desiredPods, currentPods, scheduledPods, availablePods, readyPods int32
 
 		needRequeue            bool
 		podsToCheckForRestarts []*v1.Pod
@@ -87,7 +91,10 @@ func manageCanaryStatus(annotations map[string]string, params *Parameters, now t
 			}
 
 			currentPods++
-			if podUtils.IsPodReady(pod) {
+			if _, scheduled := podUtils.IsPodScheduled(pod); scheduled {
+				scheduledPods++
+			}
+			if podUtils.IsPodAvailable(pod, params.MinReadySeconds, now) {
 				availablePods++
 			}
 			if podUtils.IsPodReady(pod) {
@@ -107,6 +114,8 @@ func manageCanaryStatus(annotations map[string]string, params *Parameters, now t
 	result.NewStatus.Ready = readyPods
 	result.NewStatus.Available = availablePods
 	result.NewStatus.Current = currentPods
+	result.NewStatus.UpdatedNumberScheduled = scheduledPods
+	result.NewStatus.NumberUnavailable = max(desiredPods-availablePods, 0)
 
 	result.PodsToDelete = podsToDelete
 
@@ -130,6 +139,7 @@ func manageCanaryStatus(annotations map[string]string, params *Parameters, now t
 	if needRequeue || !result.IsFailed && !result.IsPaused && result.NewStatus.Desired != result.NewStatus.Ready {
 		result.Result = requeuePromptly()
 	}
+	result.Result = utils.MergeResult(result.Result, requeueUntilAvailable(params.MinReadySeconds, readyPods, availablePods))
 
 	return result
 }
@@ -260,8 +270,17 @@ func manageCanaryPodFailures(pods []*v1.Pod, params *Parameters, result *Result,
 		}
 	}
 
+	// A canary failed outside of the pods analysis, for instance by its rollout group, keeps the reason of its failure
+	var failedMessage string
+	if result.IsFailed && result.FailedReason == "" {
+		if failedCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&params.Replicaset.Status, v1alpha1.ConditionTypeCanaryFailed); failedCondition != nil {
+			result.FailedReason = v1alpha1.ExtendedDaemonSetStatusReason(failedCondition.Reason)
+			failedMessage = failedCondition.Message
+		}
+	}
+
 	// Update Failed and Paused condition
-	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(result.NewStatus, metav1.NewTime(now), v1alpha1.ConditionTypeCanaryFailed, conditions.BoolToCondition(result.IsFailed), string(result.FailedReason), "", false, true)
+	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(result.NewStatus, metav1.NewTime(now), v1alpha1.ConditionTypeCanaryFailed, conditions.BoolToCondition(result.IsFailed), string(result.FailedReason), failedMessage, false, true)
 	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(result.NewStatus, metav1.NewTime(now), v1alpha1.ConditionTypeCanaryPaused, conditions.BoolToCondition(result.IsPaused), string(result.PausedReason), "", false, true)
 
 	var lastRestartTime time.Time
@@ -351,3 +370,13 @@ func requeueIn(requeueAfter time.Duration) reconcile.Result {
 func requeuePromptly() reconcile.Result {
 	return requeueIn(time.Second)
 }
+
+// requeueUntilAvailable returns the result to reconcile again once the ready pods have been ready
+// for minReadySeconds: no pod event is received when a ready pod becomes available.
+func requeueUntilAvailable(minReadySeconds, readyPods, availablePods int32) reconcile.Result {
+	if minReadySeconds == 0 || readyPods == availablePods {
+		return reconcile.Result{}
+	}
+
+	return requeueIn(time.Duration(minReadySeconds) * time.Second)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	datadoghqv1alpha1test "github.com/DataDog/extendeddaemonset/api/v1alpha1/test"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
 )
 
 var (
@@ -58,6 +60,19 @@ var (
 	}
 )
 
+func readySincePodStatus(since time.Time) v1.PodStatus {
+	return v1.PodStatus{
+		Conditions: []v1.PodCondition{
+			{
+				Type:               v1.PodReady,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(since),
+			},
+		},
+		StartTime: &metav1.Time{Time: since},
+	}
+}
+
 func newTestPodOnNode(name, nodeName, hash string, status v1.PodStatus) *v1.Pod {
 	return &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -179,11 +194,12 @@ func TestManageCanaryStatus_NoRestartsAndPodsToCreate(t *testing.T) {
 				testCanaryNodes["c"],
 			},
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   1,
-				Ready:     1,
-				Available: 1,
+				Status:            "canary",
+				Desired:           3,
+				Current:           1,
+				Ready:             1,
+				Available:         1,
+				NumberUnavailable: 2,
 			},
 			Result: requeuePromptly(),
 		},
@@ -236,6 +252,55 @@ func TestManageCanaryStatus_NoRestartsAndNoPodsToCreate(t *testing.T) {
 	test.Run(t)
 }
 
+func TestManageCanaryStatus_MinReadySeconds(t *testing.T) {
+	now := time.Now()
+	test := canaryStatusTest{
+		now: now,
+		params: &Parameters{
+			EDSName: "foo",
+			Strategy: &v1alpha1.ExtendedDaemonSetSpecStrategy{
+				Canary: &v1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+					AutoPause: &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause{
+						Enabled:     v1alpha1.NewBool(true),
+						MaxRestarts: v1alpha1.NewInt32(2),
+					},
+					AutoFail: &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail{
+						Enabled:     v1alpha1.NewBool(true),
+						MaxRestarts: v1alpha1.NewInt32(3),
+					},
+				},
+			},
+			Replicaset: &v1alpha1.ExtendedDaemonSetReplicaSet{
+				Spec: v1alpha1.ExtendedDaemonSetReplicaSetSpec{
+					TemplateGeneration: "v1",
+				},
+			},
+			NewStatus:       &v1alpha1.ExtendedDaemonSetReplicaSetStatus{},
+			MinReadySeconds: 30,
+			CanaryNodes:     testCanaryNodeNames,
+			NodeByName:      testCanaryNodes,
+			PodByNodeName: map[*NodeItem]*v1.Pod{
+				testCanaryNodes["a"]: newTestCanaryPod("foo-a", "v1", readySincePodStatus(now.Add(-time.Minute))),
+				testCanaryNodes["b"]: newTestCanaryPod("foo-b", "v1", readySincePodStatus(now.Add(-time.Minute))),
+				testCanaryNodes["c"]: newTestCanaryPod("foo-c", "v1", readySincePodStatus(now.Add(-10*time.Second))),
+			},
+			Logger: testLogger,
+		},
+		result: &Result{
+			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
+				Status:            "canary",
+				Desired:           3,
+				Current:           3,
+				Ready:             3,
+				Available:         2,
+				NumberUnavailable: 1,
+			},
+			Result: requeueIn(30 * time.Second),
+		},
+	}
+	test.Run(t)
+}
+
 func TestManageCanaryStatus_NoRestartsAndPodWithDeletionTimestamp(t *testing.T) {
 	test := canaryStatusTest{
 		params: &Parameters{
@@ -273,11 +338,12 @@ func TestManageCanaryStatus_NoRestartsAndPodWithDeletionTimestamp(t *testing.T)
 				testCanaryNodes["c"],
 			},
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   0,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary",
+				Desired:           3,
+				Current:           0,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 			},
 			Result: requeuePromptly(),
 		},
@@ -325,11 +391,12 @@ func TestManageCanaryStatus_NoRestartsAndPodsToDelete(t *testing.T) {
 				testCanaryNodes["a"],
 			},
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   0,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary",
+				Desired:           3,
+				Current:           0,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 			},
 			Result: requeuePromptly(),
 		},
@@ -373,11 +440,12 @@ func TestManageCanaryStatus_HighRestartsLeadingToPause(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   1,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary",
+				Desired:           3,
+				Current:           1,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypeCanaryPaused,
@@ -450,11 +518,12 @@ func TestManageCanaryStatus_CanaryPausedAlready(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   0,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary",
+				Desired:           3,
+				Current:           0,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypeCanaryPaused,
@@ -509,11 +578,12 @@ func TestManageCanaryStatus_HighRestartsLeadingToFail(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary-failed",
-				Desired:   3,
-				Current:   1,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary-failed",
+				Desired:           3,
+				Current:           1,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypeCanaryFailed,
@@ -540,6 +610,61 @@ func TestManageCanaryStatus_HighRestartsLeadingToFail(t *testing.T) {
 	test.Run(t)
 }
 
+func TestManageCanaryStatus_FailedByRolloutGroup(t *testing.T) {
+	now := time.Now()
+	failedAt := metav1.NewTime(now.Add(-time.Minute))
+	failedCondition := v1alpha1.ExtendedDaemonSetReplicaSetCondition{
+		Type:               v1alpha1.ConditionTypeCanaryFailed,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: failedAt,
+		LastUpdateTime:     failedAt,
+		Reason:             string(v1alpha1.ExtendedDaemonSetStatusReasonRolloutGroupFailed),
+		Message:            "canary of bar failed in the same rollout group",
+	}
+	params := &Parameters{
+		EDSName: "foo",
+		Strategy: &v1alpha1.ExtendedDaemonSetSpecStrategy{
+			Canary: &v1alpha1.ExtendedDaemonSetSpecStrategyCanary{
+				AutoPause: &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoPause{
+					Enabled:     v1alpha1.NewBool(true),
+					MaxRestarts: v1alpha1.NewInt32(2),
+				},
+				AutoFail: &v1alpha1.ExtendedDaemonSetSpecStrategyCanaryAutoFail{
+					Enabled:     v1alpha1.NewBool(true),
+					MaxRestarts: v1alpha1.NewInt32(5),
+				},
+			},
+		},
+		Replicaset: &v1alpha1.ExtendedDaemonSetReplicaSet{
+			Spec: v1alpha1.ExtendedDaemonSetReplicaSetSpec{
+				TemplateGeneration: "v1",
+			},
+			Status: v1alpha1.ExtendedDaemonSetReplicaSetStatus{
+				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{failedCondition},
+			},
+		},
+		NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
+			Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{failedCondition},
+		},
+		CanaryNodes: testCanaryNodeNames,
+		NodeByName:  testCanaryNodes,
+		PodByNodeName: map[*NodeItem]*v1.Pod{
+			testCanaryNodes["a"]: newTestCanaryPod("foo-a", "v1", readyPodStatus),
+			testCanaryNodes["b"]: nil,
+			testCanaryNodes["c"]: nil,
+		},
+		Logger: testLogger,
+	}
+
+	result := manageCanaryStatus(nil, params, now)
+	assert.True(t, result.IsFailed)
+	assert.Equal(t, v1alpha1.ExtendedDaemonSetStatusReasonRolloutGroupFailed, result.FailedReason)
+	cond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(result.NewStatus, v1alpha1.ConditionTypeCanaryFailed)
+	require.NotNil(t, cond)
+	assert.Equal(t, string(v1alpha1.ExtendedDaemonSetStatusReasonRolloutGroupFailed), cond.Reason)
+	assert.Equal(t, failedCondition.Message, cond.Message)
+}
+
 func TestManageCanaryStatus_LongRestartsDurationLeadingToFail(t *testing.T) {
 	now := time.Now()
 	restartsStartedAt := now.Add(-time.Hour)
@@ -590,11 +715,12 @@ func TestManageCanaryStatus_LongRestartsDurationLeadingToFail(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary-failed",
-				Desired:   3,
-				Current:   1,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary-failed",
+				Desired:           3,
+				Current:           1,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypePodRestarting,
@@ -677,11 +803,12 @@ func TestManageCanaryStatus_LongDurationLeadingToFail(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary-failed",
-				Desired:   3,
-				Current:   1,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary-failed",
+				Desired:           3,
+				Current:           1,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypeCanary,
@@ -758,11 +885,12 @@ func TestManageCanaryStatus_ImagePullErrorLeadingToPause(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   1,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary",
+				Desired:           3,
+				Current:           1,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypeCanaryPaused,
@@ -828,11 +956,12 @@ func TestManageCanaryStatus_AutoPausePendingCreate(t *testing.T) {
 				testCanaryNodes["c"],
 			},
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   2,
-				Ready:     1,
-				Available: 1,
+				Status:            "canary",
+				Desired:           3,
+				Current:           2,
+				Ready:             1,
+				Available:         1,
+				NumberUnavailable: 2,
 			},
 			Result: requeuePromptly(),
 		},
@@ -877,11 +1006,12 @@ func TestManageCanaryStatus_AutoPauseWithMaxSlowStart(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   2,
-				Ready:     1,
-				Available: 1,
+				Status:            "canary",
+				Desired:           3,
+				Current:           2,
+				Ready:             1,
+				Available:         1,
+				NumberUnavailable: 2,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypeCanaryPaused,
@@ -948,11 +1078,12 @@ func TestManageCanaryStatus_Unpaused(t *testing.T) {
 				testCanaryNodes["c"],
 			},
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   2,
-				Ready:     2,
-				Available: 2,
+				Status:            "canary",
+				Desired:           3,
+				Current:           2,
+				Ready:             2,
+				Available:         2,
+				NumberUnavailable: 1,
 			},
 			IsUnpaused: true,
 			Result:     requeuePromptly(),
@@ -1045,11 +1176,12 @@ func TestCreateContainerConfigError_ExceedsMaxSlowStartCondition(t *testing.T) {
 		},
 		result: &Result{
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   1,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary",
+				Desired:           3,
+				Current:           1,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 				Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
 					{
 						Type:               v1alpha1.ConditionTypeCanaryPaused,
@@ -1118,11 +1250,12 @@ func TestCreateContainerConfigError_WithinMaxSlowStartDuration(t *testing.T) {
 				testCanaryNodes["c"],
 			},
 			NewStatus: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
-				Status:    "canary",
-				Desired:   3,
-				Current:   1,
-				Ready:     0,
-				Available: 0,
+				Status:            "canary",
+				Desired:           3,
+				Current:           1,
+				Ready:             0,
+				Available:         0,
+				NumberUnavailable: 3,
 			},
 			Result: requeuePromptly(),
 		},
@@ -0,0 +1,54 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// isNodeDoNotDisrupt returns true if the node carries one of the do-not-disrupt annotation or label keys
+// of the rolling update, with a value other than "false".
+func isNodeDoNotDisrupt(rollingUpdate *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate, node *corev1.Node) bool {
+	for _, key := range rollingUpdate.DoNotDisruptNodeAnnotationKeys {
+		if value, found := node.GetAnnotations()[key]; found && value != datadoghqv1alpha1.ValueStringFalse {
+			return true
+		}
+	}
+	for _, key := range rollingUpdate.DoNotDisruptNodeLabelKeys {
+		if value, found := node.GetLabels()[key]; found && value != datadoghqv1alpha1.ValueStringFalse {
+			return true
+		}
+	}
+
+	return false
+}
+
+// manageDoNotDisruptNodes removes the do-not-disrupt nodes from the pods to delete of a rolling update.
+// Their pods are deferred until the annotation or label disappears. The pods already asked to drain are not deferred,
+// since their replacement already started.
+func manageDoNotDisruptNodes(params *Parameters, allPodToDelete []*NodeItem) []*NodeItem {
+	rollingUpdate := &params.Strategy.RollingUpdate
+	if len(rollingUpdate.DoNotDisruptNodeAnnotationKeys) == 0 && len(rollingUpdate.DoNotDisruptNodeLabelKeys) == 0 {
+		return allPodToDelete
+	}
+
+	podToDelete := []*NodeItem{}
+	var nbDeferred int32
+	for _, node := range allPodToDelete {
+		if isNodeDoNotDisrupt(rollingUpdate, node.Node) && !isPodDrainRequested(params.PodByNodeName[node]) {
+			params.Logger.V(1).Info("Defer the update of a do-not-disrupt node", "node", node.Node.Name)
+			nbDeferred++
+
+			continue
+		}
+		podToDelete = append(podToDelete, node)
+	}
+	params.NewStatus.NumberDeferred = nbDeferred
+
+	return podToDelete
+}
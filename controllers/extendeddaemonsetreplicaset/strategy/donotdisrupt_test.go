@@ -0,0 +1,102 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func Test_manageDoNotDisruptNodes(t *testing.T) {
+	newNode := func(name string, annotations, labels map[string]string) *NodeItem {
+		return &NodeItem{
+			Node: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Annotations: annotations,
+					Labels:      labels,
+				},
+			},
+		}
+	}
+	nodeA := newNode("a", nil, nil)
+	nodeB := newNode("b", map[string]string{"karpenter.sh/do-not-disrupt": "true"}, nil)
+	nodeC := newNode("c", nil, map[string]string{"example.com/critical-job": "running"})
+	nodeD := newNode("d", map[string]string{"karpenter.sh/do-not-disrupt": "false"}, nil)
+	podByNodeName := map[*NodeItem]*v1.Pod{
+		nodeA: newTestPodOnNode("foo-a", "a", "v1", readyPodStatus),
+		nodeB: newTestPodOnNode("foo-b", "b", "v1", readyPodStatus),
+		nodeC: newTestPodOnNode("foo-c", "c", "v1", readyPodStatus),
+		nodeD: newTestPodOnNode("foo-d", "d", "v1", readyPodStatus),
+	}
+	drainingPod := newTestPodOnNode("foo-b", "b", "v1", readyPodStatus)
+	drainingPod.Annotations = map[string]string{v1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey: "2021-01-01T00:00:00Z"}
+
+	tests := []struct {
+		name               string
+		rollingUpdate      v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate
+		podByNodeName      map[*NodeItem]*v1.Pod
+		wantToDelete       []*NodeItem
+		wantNumberDeferred int32
+	}{
+		{
+			name:          "no do-not-disrupt keys",
+			podByNodeName: podByNodeName,
+			wantToDelete:  []*NodeItem{nodeA, nodeB, nodeC, nodeD},
+		},
+		{
+			name: "annotation key",
+			rollingUpdate: v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+				DoNotDisruptNodeAnnotationKeys: []string{"karpenter.sh/do-not-disrupt"},
+			},
+			podByNodeName:      podByNodeName,
+			wantToDelete:       []*NodeItem{nodeA, nodeC, nodeD},
+			wantNumberDeferred: 1,
+		},
+		{
+			name: "annotation and label keys",
+			rollingUpdate: v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+				DoNotDisruptNodeAnnotationKeys: []string{"karpenter.sh/do-not-disrupt"},
+				DoNotDisruptNodeLabelKeys:      []string{"example.com/critical-job"},
+			},
+			podByNodeName:      podByNodeName,
+			wantToDelete:       []*NodeItem{nodeA, nodeD},
+			wantNumberDeferred: 2,
+		},
+		{
+			name: "draining pod is not deferred",
+			rollingUpdate: v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+				DoNotDisruptNodeAnnotationKeys: []string{"karpenter.sh/do-not-disrupt"},
+			},
+			podByNodeName: map[*NodeItem]*v1.Pod{
+				nodeA: podByNodeName[nodeA],
+				nodeB: drainingPod,
+				nodeC: podByNodeName[nodeC],
+				nodeD: podByNodeName[nodeD],
+			},
+			wantToDelete: []*NodeItem{nodeA, nodeB, nodeC, nodeD},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Parameters{
+				Logger:        testLogger,
+				NewStatus:     &v1alpha1.ExtendedDaemonSetReplicaSetStatus{},
+				PodByNodeName: tt.podByNodeName,
+				Strategy:      &v1alpha1.ExtendedDaemonSetSpecStrategy{RollingUpdate: tt.rollingUpdate},
+			}
+
+			toDelete := manageDoNotDisruptNodes(params, []*NodeItem{nodeA, nodeB, nodeC, nodeD})
+			assert.Equal(t, tt.wantToDelete, toDelete)
+			assert.Equal(t, tt.wantNumberDeferred, params.NewStatus.NumberDeferred)
+		})
+	}
+}
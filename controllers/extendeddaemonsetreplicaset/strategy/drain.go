@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// IsDrainPods returns true if the outdated pods are asked to drain before being deleted.
+func IsDrainPods(strategy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy) bool {
+	return strategy.RollingUpdate.DrainTimeout != nil
+}
+
+// isPodDrainRequested returns true if the controller already asked the pod to drain.
+func isPodDrainRequested(pod *corev1.Pod) bool {
+	_, found := pod.GetAnnotations()[datadoghqv1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey]
+
+	return found
+}
+
+// manageDrain removes the draining pods from the pods to delete of a rolling update.
+// It returns the nodes whose pod is not draining yet, the nodes whose pod is drained and can be deleted: the pod set
+// the drain-complete annotation or the drain timeout expired, and the duration after which the next drain timeout expires.
+func manageDrain(params *Parameters, allPodToDelete []*NodeItem, now time.Time) ([]*NodeItem, []*NodeItem, time.Duration) {
+	timeout := params.Strategy.RollingUpdate.DrainTimeout.Duration
+	notDrainingNodes := []*NodeItem{}
+	var drainedNodes []*NodeItem
+	var nbDraining int32
+	var requeueAfter time.Duration
+	for _, node := range allPodToDelete {
+		pod := params.PodByNodeName[node]
+		if !isPodDrainRequested(pod) {
+			notDrainingNodes = append(notDrainingNodes, node)
+
+			continue
+		}
+		if _, found := pod.GetAnnotations()[datadoghqv1alpha1.ExtendedDaemonSetDrainCompleteAnnotationKey]; found {
+			drainedNodes = append(drainedNodes, node)
+
+			continue
+		}
+		requestTime, err := time.Parse(time.RFC3339, pod.GetAnnotations()[datadoghqv1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey])
+		if err != nil {
+			params.Logger.Error(err, "unable to parse the drain request time, consider the drain timed out", "pod.name", pod.Name)
+			drainedNodes = append(drainedNodes, node)
+
+			continue
+		}
+		remaining := requestTime.Add(timeout).Sub(now)
+		if remaining <= 0 {
+			params.Logger.Info("Pod drain timed out", "pod.name", pod.Name, "node", node.Node.Name)
+			drainedNodes = append(drainedNodes, node)
+
+			continue
+		}
+		nbDraining++
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	params.NewStatus.NumberDraining = nbDraining
+
+	return notDrainingNodes, drainedNodes, requeueAfter
+}
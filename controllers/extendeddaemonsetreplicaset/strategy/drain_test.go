@@ -0,0 +1,113 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func Test_manageDrain(t *testing.T) {
+	now := time.Now()
+	newDrainPod := func(name, nodeName string, annotations map[string]string) *v1.Pod {
+		pod := newTestPodOnNode(name, nodeName, "v1", readyPodStatus)
+		pod.Annotations = annotations
+
+		return pod
+	}
+	requestedAt := func(d time.Duration) string {
+		return now.Add(-d).UTC().Format(time.RFC3339)
+	}
+
+	tests := []struct {
+		name               string
+		podByNodeName      map[*NodeItem]*v1.Pod
+		wantNotDraining    []*NodeItem
+		wantDrained        []*NodeItem
+		wantRequeueAfter   time.Duration
+		wantNumberDraining int32
+	}{
+		{
+			name: "no drain requested yet",
+			podByNodeName: map[*NodeItem]*v1.Pod{
+				testCanaryNodes["a"]: newDrainPod("foo-a", "a", nil),
+			},
+			wantNotDraining: []*NodeItem{testCanaryNodes["a"]},
+		},
+		{
+			name: "pod still draining",
+			podByNodeName: map[*NodeItem]*v1.Pod{
+				testCanaryNodes["a"]: newDrainPod("foo-a", "a", map[string]string{v1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey: requestedAt(time.Minute)}),
+			},
+			wantNotDraining:    []*NodeItem{},
+			wantRequeueAfter:   4 * time.Minute,
+			wantNumberDraining: 1,
+		},
+		{
+			name: "drain completed",
+			podByNodeName: map[*NodeItem]*v1.Pod{
+				testCanaryNodes["a"]: newDrainPod("foo-a", "a", map[string]string{
+					v1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey: requestedAt(time.Minute),
+					v1alpha1.ExtendedDaemonSetDrainCompleteAnnotationKey:  "true",
+				}),
+			},
+			wantNotDraining: []*NodeItem{},
+			wantDrained:     []*NodeItem{testCanaryNodes["a"]},
+		},
+		{
+			name: "drain timed out",
+			podByNodeName: map[*NodeItem]*v1.Pod{
+				testCanaryNodes["a"]: newDrainPod("foo-a", "a", map[string]string{v1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey: requestedAt(10 * time.Minute)}),
+			},
+			wantNotDraining: []*NodeItem{},
+			wantDrained:     []*NodeItem{testCanaryNodes["a"]},
+		},
+		{
+			name: "mixed",
+			podByNodeName: map[*NodeItem]*v1.Pod{
+				testCanaryNodes["a"]: newDrainPod("foo-a", "a", nil),
+				testCanaryNodes["b"]: newDrainPod("foo-b", "b", map[string]string{v1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey: requestedAt(2 * time.Minute)}),
+				testCanaryNodes["c"]: newDrainPod("foo-c", "c", map[string]string{v1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey: "invalid"}),
+			},
+			wantNotDraining:    []*NodeItem{testCanaryNodes["a"]},
+			wantDrained:        []*NodeItem{testCanaryNodes["c"]},
+			wantRequeueAfter:   3 * time.Minute,
+			wantNumberDraining: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Parameters{
+				Logger:        testLogger,
+				NewStatus:     &v1alpha1.ExtendedDaemonSetReplicaSetStatus{},
+				PodByNodeName: tt.podByNodeName,
+				Strategy: &v1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+						DrainTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			}
+			var allPodToDelete []*NodeItem
+			for _, name := range []string{"a", "b", "c"} {
+				if _, found := tt.podByNodeName[testCanaryNodes[name]]; found {
+					allPodToDelete = append(allPodToDelete, testCanaryNodes[name])
+				}
+			}
+
+			notDraining, drained, requeueAfter := manageDrain(params, allPodToDelete, now)
+			assert.Equal(t, tt.wantNotDraining, notDraining)
+			assert.Equal(t, tt.wantDrained, drained)
+			assert.InDelta(t, tt.wantRequeueAfter, requeueAfter, float64(time.Second))
+			assert.Equal(t, tt.wantNumberDraining, params.NewStatus.NumberDraining)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
+)
+
+// IsHealthGateEnabled returns true if the rolling update is paused, or failed, when too many up-to-date pods are unhealthy.
+func IsHealthGateEnabled(strategy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy) bool {
+	return strategy.RollingUpdate.MaxUnhealthyPods != nil
+}
+
+// isFailOnUnhealthyPods returns true if the health gate fails the rolling update instead of pausing it.
+func isFailOnUnhealthyPods(strategy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy) bool {
+	return strategy.RollingUpdate.FailOnUnhealthyPods != nil && *strategy.RollingUpdate.FailOnUnhealthyPods
+}
+
+// getHealthGateStartTime returns the time from which the health gate counts the restarts of the up-to-date pods:
+// the creation of the replicaset, or the last time its rolling update was resumed.
+func getHealthGateStartTime(replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet) time.Time {
+	startTime := replicaset.CreationTimestamp.Time
+	cond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&replicaset.Status, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused)
+	if cond != nil && cond.Status == corev1.ConditionFalse && cond.LastTransitionTime.After(startTime) {
+		startTime = cond.LastTransitionTime.Time
+	}
+
+	return startTime
+}
+
+// isPodUnhealthy returns true, and the reason, if the up-to-date pod cannot start, is crash looping or restarted more than maxRestarts times.
+// The restarts are only counted if the pod restarted since startTime.
+func isPodUnhealthy(pod *corev1.Pod, maxRestarts *int32, startTime time.Time) (bool, datadoghqv1alpha1.ExtendedDaemonSetStatusReason) {
+	if cannotStart, reason := podutils.CannotStart(pod); cannotStart {
+		return true, reason
+	}
+	if podutils.CrashLooping(pod) {
+		return true, datadoghqv1alpha1.ExtendedDaemonSetStatusReasonCLB
+	}
+	if maxRestarts == nil {
+		return false, ""
+	}
+	if restartTime, _ := podutils.MostRecentRestart(pod); !restartTime.After(startTime) {
+		return false, ""
+	}
+	if restartCount, reason := podutils.HighestRestartCount(pod); restartCount > int(*maxRestarts) {
+		return true, reason
+	}
+
+	return false, ""
+}
+
+// manageHealthGate checks the health of the up-to-date pods of a rolling update in progress. It returns true, and a message
+// describing the unhealthy pods, if more than rollingUpdate.maxUnhealthyPods up-to-date pods are unhealthy.
+func manageHealthGate(params *Parameters, upToDatePods []*corev1.Pod, startTime time.Time) (bool, string, error) {
+	rollingUpdate := &params.Strategy.RollingUpdate
+	maxUnhealthyPods, err := intstrutil.GetScaledValueFromIntOrPercent(rollingUpdate.MaxUnhealthyPods, len(upToDatePods), false)
+	if err != nil {
+		return false, "", fmt.Errorf("unable to retrieve maxUnhealthyPods from the strategy.rollingUpdate.maxUnhealthyPods parameter, err: %w", err)
+	}
+
+	pods := append([]*corev1.Pod{}, upToDatePods...)
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	var nbUnhealthyPods int
+	var firstPodName string
+	var firstReason datadoghqv1alpha1.ExtendedDaemonSetStatusReason
+	for _, pod := range pods {
+		if unhealthy, reason := isPodUnhealthy(pod, rollingUpdate.MaxUnhealthyPodRestarts, startTime); unhealthy {
+			if nbUnhealthyPods == 0 {
+				firstPodName, firstReason = pod.Name, reason
+			}
+			nbUnhealthyPods++
+		}
+	}
+	params.Logger.V(1).Info("Health gate", "nbUnhealthyPods", nbUnhealthyPods, "nbUpToDatePods", len(pods), "maxUnhealthyPods", maxUnhealthyPods)
+	if nbUnhealthyPods <= maxUnhealthyPods {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("%d/%d up-to-date pods unhealthy, maxUnhealthyPods: %d, pod %s: %s", nbUnhealthyPods, len(pods), maxUnhealthyPods, firstPodName, firstReason), nil
+}
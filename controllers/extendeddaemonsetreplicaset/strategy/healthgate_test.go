@@ -0,0 +1,157 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func Test_manageHealthGate(t *testing.T) {
+	now := time.Now()
+	crashLoopPod := func(name string) *v1.Pod {
+		return newTestPodOnNode(name, "", "v2", podWaitingStatus(string(v1alpha1.ExtendedDaemonSetStatusReasonCLB), "", now))
+	}
+	restartingPod := func(name string, restartCount int32) *v1.Pod {
+		return newTestPodOnNode(name, "", "v2", podTerminatedStatus(restartCount, string(v1alpha1.ExtendedDaemonSetStatusReasonOOM), now))
+	}
+	restartedBeforeRollingUpdatePod := func(name string, restartCount int32) *v1.Pod {
+		return newTestPodOnNode(name, "", "v2", podTerminatedStatus(restartCount, string(v1alpha1.ExtendedDaemonSetStatusReasonOOM), now.Add(-2*time.Hour)))
+	}
+	readyPod := func(name string) *v1.Pod {
+		return newTestPodOnNode(name, "", "v2", readyPodStatus)
+	}
+
+	tests := []struct {
+		name             string
+		maxUnhealthyPods intstr.IntOrString
+		maxRestarts      *int32
+		upToDatePods     []*v1.Pod
+		wantUnhealthy    bool
+		wantMsg          string
+		wantErr          bool
+	}{
+		{
+			name:             "no up-to-date pods",
+			maxUnhealthyPods: intstr.FromInt(0),
+		},
+		{
+			name:             "all pods healthy",
+			maxUnhealthyPods: intstr.FromInt(0),
+			maxRestarts:      v1alpha1.NewInt32(5),
+			upToDatePods:     []*v1.Pod{readyPod("foo-a"), restartingPod("foo-b", 5)},
+		},
+		{
+			name:             "crash looping pods within the limit",
+			maxUnhealthyPods: intstr.FromInt(1),
+			upToDatePods:     []*v1.Pod{crashLoopPod("foo-a"), readyPod("foo-b")},
+		},
+		{
+			name:             "crash looping pods above the limit",
+			maxUnhealthyPods: intstr.FromInt(1),
+			upToDatePods:     []*v1.Pod{readyPod("foo-a"), crashLoopPod("foo-c"), crashLoopPod("foo-b")},
+			wantUnhealthy:    true,
+			wantMsg:          "2/3 up-to-date pods unhealthy, maxUnhealthyPods: 1, pod foo-b: CrashLoopBackOff",
+		},
+		{
+			name:             "restarts above maxUnhealthyPodRestarts",
+			maxUnhealthyPods: intstr.FromInt(0),
+			maxRestarts:      v1alpha1.NewInt32(5),
+			upToDatePods:     []*v1.Pod{readyPod("foo-a"), restartingPod("foo-b", 6)},
+			wantUnhealthy:    true,
+			wantMsg:          "1/2 up-to-date pods unhealthy, maxUnhealthyPods: 0, pod foo-b: OOMKilled",
+		},
+		{
+			name:             "restarts before the rolling update started ignored",
+			maxUnhealthyPods: intstr.FromInt(0),
+			maxRestarts:      v1alpha1.NewInt32(5),
+			upToDatePods:     []*v1.Pod{readyPod("foo-a"), restartedBeforeRollingUpdatePod("foo-b", 6)},
+		},
+		{
+			name:             "restarts ignored without maxUnhealthyPodRestarts",
+			maxUnhealthyPods: intstr.FromInt(0),
+			upToDatePods:     []*v1.Pod{restartingPod("foo-a", 10)},
+		},
+		{
+			name:             "percentage rounded down",
+			maxUnhealthyPods: intstr.FromString("50%"),
+			upToDatePods:     []*v1.Pod{crashLoopPod("foo-a"), crashLoopPod("foo-b"), readyPod("foo-c")},
+			wantUnhealthy:    true,
+			wantMsg:          "2/3 up-to-date pods unhealthy, maxUnhealthyPods: 1, pod foo-a: CrashLoopBackOff",
+		},
+		{
+			name:             "invalid maxUnhealthyPods",
+			maxUnhealthyPods: intstr.FromString("foo"),
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Parameters{
+				Logger: testLogger,
+				Strategy: &v1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: v1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+						MaxUnhealthyPods:        &tt.maxUnhealthyPods,
+						MaxUnhealthyPodRestarts: tt.maxRestarts,
+					},
+				},
+			}
+
+			unhealthy, msg, err := manageHealthGate(params, tt.upToDatePods, now.Add(-time.Hour))
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnhealthy, unhealthy)
+			assert.Equal(t, tt.wantMsg, msg)
+		})
+	}
+}
+
+func Test_getHealthGateStartTime(t *testing.T) {
+	creationTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	resumedTime := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name      string
+		condition *v1alpha1.ExtendedDaemonSetReplicaSetCondition
+		want      time.Time
+	}{
+		{
+			name: "never paused",
+			want: creationTime.Time,
+		},
+		{
+			name:      "paused",
+			condition: &v1alpha1.ExtendedDaemonSetReplicaSetCondition{Type: v1alpha1.ConditionTypeRollingUpdatePaused, Status: v1.ConditionTrue, LastTransitionTime: resumedTime},
+			want:      creationTime.Time,
+		},
+		{
+			name:      "resumed",
+			condition: &v1alpha1.ExtendedDaemonSetReplicaSetCondition{Type: v1alpha1.ConditionTypeRollingUpdatePaused, Status: v1.ConditionFalse, LastTransitionTime: resumedTime},
+			want:      resumedTime.Time,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicaset := &v1alpha1.ExtendedDaemonSetReplicaSet{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creationTime}}
+			if tt.condition != nil {
+				replicaset.Status.Conditions = []v1alpha1.ExtendedDaemonSetReplicaSetCondition{*tt.condition}
+			}
+
+			assert.Equal(t, tt.want, getHealthGateStartTime(replicaset))
+		})
+	}
+}
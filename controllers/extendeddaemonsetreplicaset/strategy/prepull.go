@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
+)
+
+// IsPrePullImages returns true if the images of the new pod template are pulled on a node before deleting its old pod.
+func IsPrePullImages(strategy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy) bool {
+	return strategy.RollingUpdate.PrePullImages != nil && *strategy.RollingUpdate.PrePullImages
+}
+
+// managePrePull restricts the pods deletions of a rolling update to the nodes where the images of the replicaset
+// pod template are already pulled.
+// It returns the nodes whose pod can be deleted, the nodes that need a pre-pull pod, and the pre-pull pods to delete:
+// the ones whose node doesn't run an outdated pod anymore, and the failed ones that will be re-created.
+func managePrePull(params *Parameters, allPodToDelete []*NodeItem) ([]*NodeItem, []*NodeItem, []*corev1.Pod) {
+	outdatedNodes := make(map[string]bool, len(allPodToDelete))
+	prePulledNodes := []*NodeItem{}
+	var prePullPodsToCreate []*NodeItem
+	var prePullPodsToDelete []*corev1.Pod
+	for _, node := range allPodToDelete {
+		outdatedNodes[node.Node.Name] = true
+		prePullPod, found := params.PrePullPodByNodeName[node.Node.Name]
+		switch {
+		case !found:
+			prePullPodsToCreate = append(prePullPodsToCreate, node)
+		case podutils.IsPrePullCompleted(prePullPod):
+			prePulledNodes = append(prePulledNodes, node)
+		case prePullPod.Status.Phase == corev1.PodFailed && prePullPod.DeletionTimestamp == nil:
+			prePullPodsToDelete = append(prePullPodsToDelete, prePullPod)
+		}
+	}
+
+	for nodeName, prePullPod := range params.PrePullPodByNodeName {
+		if !outdatedNodes[nodeName] && prePullPod.DeletionTimestamp == nil {
+			prePullPodsToDelete = append(prePullPodsToDelete, prePullPod)
+		}
+	}
+	params.NewStatus.NumberPrePulled = int32(len(prePulledNodes))
+
+	return prePulledNodes, prePullPodsToCreate, prePullPodsToDelete
+}
+
+// cleanupPrePullPods returns the image pre-pull pods to delete when the pre-pull phase doesn't run, because
+// the prePullImages option was disabled or the replicaset is not active anymore.
+func cleanupPrePullPods(params *Parameters) []*corev1.Pod {
+	var prePullPodsToDelete []*corev1.Pod
+	for _, prePullPod := range params.PrePullPodByNodeName {
+		if prePullPod.DeletionTimestamp == nil {
+			prePullPodsToDelete = append(prePullPodsToDelete, prePullPod)
+		}
+	}
+
+	return prePullPodsToDelete
+}
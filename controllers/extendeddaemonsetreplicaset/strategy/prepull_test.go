@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+func Test_managePrePull(t *testing.T) {
+	pulledStatus := v1.PodStatus{
+		Phase:             v1.PodSucceeded,
+		ContainerStatuses: []v1.ContainerStatus{{State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}}},
+	}
+	pullingStatus := v1.PodStatus{
+		Phase:             v1.PodPending,
+		ContainerStatuses: []v1.ContainerStatus{{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}}},
+	}
+	failedStatus := v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"}
+	newPrePullPod := func(name, nodeName string, status v1.PodStatus) *v1.Pod {
+		pod := newTestPodOnNode(name, nodeName, "", status)
+		pod.Spec.Containers = []v1.Container{{Name: "pre-pull-0"}}
+
+		return pod
+	}
+	pulledOnA := newPrePullPod("pre-pull-a", "a", pulledStatus)
+	pullingOnB := newPrePullPod("pre-pull-b", "b", pullingStatus)
+	failedOnB := newPrePullPod("pre-pull-b", "b", failedStatus)
+	pulledOnC := newPrePullPod("pre-pull-c", "c", pulledStatus)
+
+	tests := []struct {
+		name                 string
+		prePullPodByNodeName map[string]*v1.Pod
+		allPodToDelete       []*NodeItem
+		wantToDelete         []*NodeItem
+		wantPrePullToCreate  []*NodeItem
+		wantPrePullToDelete  []*v1.Pod
+		wantNumberPrePulled  int32
+	}{
+		{
+			name:                "no pre-pull pod yet",
+			allPodToDelete:      []*NodeItem{testCanaryNodes["a"], testCanaryNodes["b"]},
+			wantToDelete:        []*NodeItem{},
+			wantPrePullToCreate: []*NodeItem{testCanaryNodes["a"], testCanaryNodes["b"]},
+		},
+		{
+			name:                 "only the pre-pulled nodes are updated",
+			prePullPodByNodeName: map[string]*v1.Pod{"a": pulledOnA, "b": pullingOnB},
+			allPodToDelete:       []*NodeItem{testCanaryNodes["a"], testCanaryNodes["b"]},
+			wantToDelete:         []*NodeItem{testCanaryNodes["a"]},
+			wantNumberPrePulled:  1,
+		},
+		{
+			name:                 "failed pre-pull pod is re-created",
+			prePullPodByNodeName: map[string]*v1.Pod{"b": failedOnB},
+			allPodToDelete:       []*NodeItem{testCanaryNodes["b"]},
+			wantToDelete:         []*NodeItem{},
+			wantPrePullToDelete:  []*v1.Pod{failedOnB},
+		},
+		{
+			name:                 "pre-pull pod of an updated node is deleted",
+			prePullPodByNodeName: map[string]*v1.Pod{"a": pulledOnA, "c": pulledOnC},
+			allPodToDelete:       []*NodeItem{testCanaryNodes["a"]},
+			wantToDelete:         []*NodeItem{testCanaryNodes["a"]},
+			wantPrePullToDelete:  []*v1.Pod{pulledOnC},
+			wantNumberPrePulled:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Parameters{
+				Logger:               testLogger,
+				NewStatus:            &v1alpha1.ExtendedDaemonSetReplicaSetStatus{},
+				NodeByName:           testCanaryNodes,
+				PrePullPodByNodeName: tt.prePullPodByNodeName,
+			}
+
+			toDelete, prePullToCreate, prePullToDelete := managePrePull(params, tt.allPodToDelete)
+			assert.Equal(t, tt.wantToDelete, toDelete)
+			assert.ElementsMatch(t, tt.wantPrePullToCreate, prePullToCreate)
+			assert.ElementsMatch(t, tt.wantPrePullToDelete, prePullToDelete)
+			assert.Equal(t, tt.wantNumberPrePulled, params.NewStatus.NumberPrePulled)
+		})
+	}
+}
+
+func Test_cleanupPrePullPods(t *testing.T) {
+	prePullOnA := newTestPodOnNode("pre-pull-a", "a", "", v1.PodStatus{Phase: v1.PodPending})
+	deletedOnB := withDeletionTimestamp(newTestPodOnNode("pre-pull-b", "b", "", v1.PodStatus{Phase: v1.PodPending}))
+
+	tests := []struct {
+		name                 string
+		prePullPodByNodeName map[string]*v1.Pod
+		want                 []*v1.Pod
+	}{
+		{
+			name: "no pre-pull pods",
+		},
+		{
+			name:                 "remaining pre-pull pods deleted, except the ones already being deleted",
+			prePullPodByNodeName: map[string]*v1.Pod{"a": prePullOnA, "b": deletedOnB},
+			want:                 []*v1.Pod{prePullOnA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Parameters{PrePullPodByNodeName: tt.prePullPodByNodeName}
+			assert.ElementsMatch(t, tt.want, cleanupPrePullPods(params))
+		})
+	}
+}
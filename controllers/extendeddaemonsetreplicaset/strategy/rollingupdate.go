@@ -14,12 +14,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	eds "github.com/DataDog/extendeddaemonset/controllers/extendeddaemonset"
 	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
 	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/strategy/limits"
 	"github.com/DataDog/extendeddaemonset/pkg/controller/metrics"
+	"github.com/DataDog/extendeddaemonset/pkg/controller/utils"
 	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
 )
 
@@ -34,19 +36,19 @@ func ManageDeployment(client runtimeclient.Client, daemonset *datadoghqv1alpha1.
 		IsPaused: eds.IsRollingUpdatePaused(daemonset.GetAnnotations()),
 		IsFrozen: eds.IsRolloutFrozen(daemonset.GetAnnotations()),
 	}
-	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused, conditions.BoolToCondition(result.IsPaused), "", "", false, false)
-	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeRolloutFrozen, conditions.BoolToCondition(result.IsFrozen), "", "", false, false)
-	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeActive, conditions.BoolToCondition(!result.IsPaused && !result.IsFrozen), "", "", false, false)
-
 	// Remove canary nodes if defined.
 	for _, nodeName := range params.CanaryNodes {
 		delete(params.PodByNodeName, params.NodeByName[nodeName])
 	}
 
-	var desiredPods, availablePods, readyPods, oldUnavailablePods, createdPods, allPods, oldAvailablePods, podsTerminating, nbIgnoredUnresponsiveNodes int32
+	var desiredPods, availablePods, readyPods, oldUnavailablePods, createdPods, scheduledPods, allPods, oldAvailablePods, podsTerminating, nbIgnoredUnresponsiveNodes int32
 
 	allPodToCreate := []*NodeItem{}
+	newNodePodToCreate := []*NodeItem{}
 	allPodToDelete := []*NodeItem{}
+	var upToDatePods []*corev1.Pod
+	var newRestartTime time.Time
+	var restartingPodStatus string
 
 	nbNodes := len(params.PodByNodeName)
 
@@ -60,7 +62,11 @@ func ManageDeployment(client runtimeclient.Client, daemonset *datadoghqv1alpha1.
 	for node, pod := range params.PodByNodeName {
 		desiredPods++
 		if pod == nil {
-			allPodToCreate = append(allPodToCreate, node)
+			if isNewNodeFastPath(params, node) {
+				newNodePodToCreate = append(newNodePodToCreate, node)
+			} else {
+				allPodToCreate = append(allPodToCreate, node)
+			}
 		} else {
 			if podutils.HasPodSchedulerIssue(pod) {
 				nbIgnoredUnresponsiveNodes++
@@ -78,23 +84,69 @@ func ManageDeployment(client runtimeclient.Client, daemonset *datadoghqv1alpha1.
 
 					continue
 				}
-				if podutils.IsPodReady(pod) {
+				switch {
+				case IsDrainPods(params.Strategy) && isPodDrainRequested(pod):
+					// A draining pod is counted as unavailable, against maxUnavailable.
+				case podutils.IsPodAvailable(pod, params.MinReadySeconds, now):
 					oldAvailablePods++
-				} else {
+				default:
 					oldUnavailablePods++
 				}
 			} else {
 				createdPods++
-				if podutils.IsPodReady(pod) {
+				upToDatePods = append(upToDatePods, pod)
+				if _, scheduled := podutils.IsPodScheduled(pod); scheduled {
+					scheduledPods++
+				}
+				if podutils.IsPodAvailable(pod, params.MinReadySeconds, now) {
 					availablePods++
 				}
 				if podutils.IsPodReady(pod) {
 					readyPods++
 				}
+				if restartTime, reason := podutils.MostRecentRestart(pod); restartTime.After(newRestartTime) {
+					newRestartTime = restartTime
+					restartingPodStatus = fmt.Sprintf("Pod %s restarting with reason: %s", pod.Name, string(reason))
+				}
 			}
 		}
 	}
 
+	// The rolling update is completed once all the pods have been updated and are available. It stays completed
+	// afterwards: the health gate only applies to the up-to-date pods while the rolling update is in progress.
+	isRollingUpdateCompleted := conditions.IsConditionTrue(&params.Replicaset.Status, datadoghqv1alpha1.ConditionTypeRollingUpdateCompleted) ||
+		(createdPods > 0 && len(allPodToDelete) == 0 && podsTerminating == 0 && len(allPodToCreate) == 0 && len(newNodePodToCreate) == 0 && availablePods == createdPods)
+	if isRollingUpdateCompleted {
+		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeRollingUpdateCompleted, corev1.ConditionTrue, "", "", false, false)
+	}
+
+	// With the OnDelete strategy, outdated pods are never deleted by the controller:
+	// they are only replaced once they have been deleted externally.
+	isOnDelete := params.Strategy.Type == datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyTypeOnDelete
+
+	// The pods running on a do-not-disrupt node are deferred until the node can be disrupted again.
+	params.NewStatus.NumberDeferred = 0
+	if !isOnDelete {
+		allPodToDelete = manageDoNotDisruptNodes(params, allPodToDelete)
+	}
+
+	// With the images pre-pull, only the pods running on a node where the new images are already pulled can be deleted.
+	var prePullPodsToCreate []*NodeItem
+	params.NewStatus.NumberPrePulled = 0
+	if IsPrePullImages(params.Strategy) && !isOnDelete {
+		allPodToDelete, prePullPodsToCreate, result.PrePullPodsToDelete = managePrePull(params, allPodToDelete)
+	} else {
+		result.PrePullPodsToDelete = cleanupPrePullPods(params)
+	}
+
+	// With the pods drain, the outdated pods are asked to drain and only deleted once drained.
+	var drainedPods []*NodeItem
+	var drainRequeueAfter time.Duration
+	params.NewStatus.NumberDraining = 0
+	if IsDrainPods(params.Strategy) && !isOnDelete {
+		allPodToDelete, drainedPods, drainRequeueAfter = manageDrain(params, allPodToDelete, now)
+	}
+
 	// Retrieves parameters for calculation
 	maxUnavailable, err := intstrutil.GetScaledValueFromIntOrPercent(params.Strategy.RollingUpdate.MaxUnavailable, nbNodes, true)
 	if err != nil {
@@ -122,7 +174,12 @@ func ManageDeployment(client runtimeclient.Client, daemonset *datadoghqv1alpha1.
 		"oldUnavailablePods", oldUnavailablePods,
 		"maxUnavailable", maxUnavailable,
 		"nbPodToCreate", len(allPodToCreate),
+		"nbNewNodePodToCreate", len(newNodePodToCreate),
 		"nbPodToDelete", len(allPodToDelete),
+		"nbPrePullPodToCreate", len(prePullPodsToCreate),
+		"nbPodDraining", params.NewStatus.NumberDraining,
+		"nbPodDrained", len(drainedPods),
+		"nbPodDeferred", params.NewStatus.NumberDeferred,
 		"podsTerminating", podsTerminating)
 
 	limitParams := limits.Parameters{
@@ -139,7 +196,10 @@ func ManageDeployment(client runtimeclient.Client, daemonset *datadoghqv1alpha1.
 		MaxUnschedulablePod:  maxPodSchedulerFailure,
 	}
 	nbPodToCreate, nbPodToDelete := limits.CalculatePodToCreateAndDelete(limitParams)
-	metrics.SetRollingUpdateStuckMetric(params.Replicaset.GetName(), params.Replicaset.GetNamespace(), nbPodToDelete == 0 && len(allPodToDelete) > 0)
+	if isOnDelete {
+		nbPodToDelete = 0
+	}
+	metrics.SetRollingUpdateStuckMetric(params.Replicaset.GetName(), params.Replicaset.GetNamespace(), !isOnDelete && nbPodToDelete == 0 && len(allPodToDelete) > 0 && params.NewStatus.NumberDraining == 0)
 	nbPodToDeleteWithConstraint := min(nbPodToDelete, len(allPodToDelete))
 	nbPodToCreateWithConstraint := min(nbPodToCreate, len(allPodToCreate))
 	params.Logger.V(1).Info(
@@ -150,38 +210,117 @@ func ManageDeployment(client runtimeclient.Client, daemonset *datadoghqv1alpha1.
 		"nbPodToCreateWithConstraint", nbPodToCreateWithConstraint,
 		"isRolloutFrozen", result.IsFrozen,
 		"isRollingUpdatePaused", result.IsPaused,
+		"isOnDelete", isOnDelete,
 	)
 
+	podsToDelete := allPodToDelete[:nbPodToDeleteWithConstraint]
+	var pausedReason, pausedMessage string
+
+	// With the health gate, the rolling update is paused, or failed, once too many up-to-date pods are unhealthy.
+	// A failed rolling update stays failed until the pod template is updated, or until all the pods have been updated.
+	result.IsFailed = eds.IsRollingUpdateFailed(params.Replicaset)
+	if result.IsFailed && isRollingUpdateCompleted {
+		result.IsFailed = false
+		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeRollingUpdateFailed, corev1.ConditionFalse, "", "", false, false)
+	}
+	if IsHealthGateEnabled(params.Strategy) && !isOnDelete && !result.IsFailed && !isRollingUpdateCompleted {
+		// A rolling update paused by the health gate stays paused until it is resumed manually, even if the pods
+		// look healthy again: restarting or crash looping pods look healthy between two failures.
+		if !result.IsPaused && eds.IsRollingUpdatePausedForUnhealthyPods(params.Replicaset) {
+			pausedCond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&params.Replicaset.Status, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused)
+			result.IsPaused = true
+			pausedReason = pausedCond.Reason
+			pausedMessage = pausedCond.Message
+		}
+
+		var unhealthy bool
+		var unhealthyMessage string
+		unhealthy, unhealthyMessage, err = manageHealthGate(params, upToDatePods, getHealthGateStartTime(params.Replicaset))
+		if err != nil {
+			params.Logger.Error(err, "unable to check the health gate of the rolling update")
+
+			return result, err
+		}
+		switch {
+		case unhealthy && isFailOnUnhealthyPods(params.Strategy):
+			params.Logger.Info("Rolling update failed by the health gate", "reason", unhealthyMessage)
+			result.IsFailed = true
+			result.FailedReason = datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods
+			conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeRollingUpdateFailed, corev1.ConditionTrue, string(result.FailedReason), unhealthyMessage, false, false)
+		case unhealthy && !result.IsPaused:
+			params.Logger.Info("Rolling update paused by the health gate", "reason", unhealthyMessage)
+			result.IsPaused = true
+			pausedReason = string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods)
+			pausedMessage = unhealthyMessage
+		}
+	}
+
+	if isManualBatchApproval(params.Strategy) && !isOnDelete {
+		var waitingForApproval bool
+		podsToDelete, waitingForApproval = manageRollingUpdateBatch(params, daemonset.GetAnnotations(), allPodToDelete, nbPodToDelete, maxUnavailable, now)
+		if waitingForApproval && !result.IsPaused {
+			result.IsPaused = true
+			pausedReason = string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonWaitingForBatchApproval)
+			pausedMessage = rollingUpdateBatchPausedMessage(params.NewStatus.RollingUpdateBatch)
+		}
+	}
+	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused, conditions.BoolToCondition(result.IsPaused), pausedReason, pausedMessage, false, false)
+	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeRolloutFrozen, conditions.BoolToCondition(result.IsFrozen), "", "", false, false)
+	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(params.NewStatus, metaNow, datadoghqv1alpha1.ConditionTypeActive, conditions.BoolToCondition(!result.IsPaused && !result.IsFrozen && !result.IsFailed), "", "", false, false)
+
 	// When paused, we only stop deleting pods.
 	// The goal is to pause rolling out the new replicaset but also to continue creating pods
 	// if new nodes join in the meantime.
-	// When frozen, we stop both the deletion and the creation of new pods.
-	if !result.IsPaused && !result.IsFrozen {
-		result.PodsToDelete = allPodToDelete[:nbPodToDeleteWithConstraint]
+	// When frozen or failed, we stop both the deletion and the creation of new pods.
+	if !result.IsPaused && !result.IsFrozen && !result.IsFailed {
+		result.PodsToDelete = podsToDelete
+		if IsDrainPods(params.Strategy) && !isOnDelete {
+			// The selected pods are only asked to drain, the drained pods are deleted instead.
+			result.PodsToDrain = podsToDelete
+			result.PodsToDelete = drainedPods
+		}
 	}
-	if !result.IsFrozen {
+	if !result.IsFrozen && !result.IsFailed {
 		result.PodsToCreate = allPodToCreate[:nbPodToCreateWithConstraint]
+		// The pods of the new nodes are created outside of the slow start and maxParallelPodCreation limits.
+		if len(newNodePodToCreate) > 0 {
+			nbNewNodePodToCreate := min(len(newNodePodToCreate), int(*params.Strategy.RollingUpdate.MaxParallelNewNodePodCreation))
+			result.PodsToCreate = append(result.PodsToCreate, newNodePodToCreate[:nbNewNodePodToCreate]...)
+		}
+		result.PrePullPodsToCreate = prePullPodsToCreate[:min(len(prePullPodsToCreate), int(*params.Strategy.RollingUpdate.MaxParallelPodCreation))]
 	}
 
+	// Track the restarts of the up-to-date pods, used to auto-resume a rolling update paused at a checkpoint.
+	updatePodRestartingCondition(params.NewStatus, newRestartTime, restartingPodStatus)
+
 	result.NewStatus = params.NewStatus.DeepCopy()
 	result.NewStatus.Status = string(ReplicaSetStatusActive)
 	result.NewStatus.Desired = desiredPods
 	result.NewStatus.Ready = readyPods
 	result.NewStatus.Current = createdPods
 	result.NewStatus.Available = availablePods
+	result.NewStatus.UpdatedNumberScheduled = scheduledPods
+	result.NewStatus.NumberUnavailable = max(desiredPods-availablePods, 0)
 	result.NewStatus.IgnoredUnresponsiveNodes = nbIgnoredUnresponsiveNodes
+	result.NewStatus.NewNodes = int32(len(newNodePodToCreate))
 
 	// Populate list of unscheduled pods on nodes due to resource limitation
 	result.UnscheduledNodesDueToResourcesConstraints = manageUnscheduledPodNodes(params.UnscheduledPods)
 	// Cleanup Pods
-	err = cleanupPods(client, params.Logger, result.NewStatus, params.PodToCleanUp)
+	result.EvictionBlockedNodes, err = cleanupPods(client, params.Logger, result.NewStatus, params.PodToCleanUp, UseEvictionAPI(params.Strategy))
 	if result.NewStatus.Desired != result.NewStatus.Ready {
 		result.Result.Requeue = true
 	}
+	result.Result = utils.MergeResult(result.Result, requeueUntilAvailable(params.MinReadySeconds, readyPods, availablePods))
+	result.Result = utils.MergeResult(result.Result, reconcile.Result{RequeueAfter: drainRequeueAfter})
+	// The nodes are not watched: check periodically if the deferred nodes can be disrupted again.
+	if params.NewStatus.NumberDeferred > 0 && params.Strategy.ReconcileFrequency != nil {
+		result.Result = utils.MergeResult(result.Result, reconcile.Result{RequeueAfter: params.Strategy.ReconcileFrequency.Duration})
+	}
 
 	// Remove canary labels from canary pods (if they exist)
 	// We keep retrying these operations only for the first X minutes after starting the rolling update to avoid Listing pods endlessly.
-	if time.Since(rollingUpdateStartTime) < cleanCanaryLabelsThreshold {
+	if now.Sub(rollingUpdateStartTime) < cleanCanaryLabelsThreshold {
 		canaryPods := &corev1.PodList{}
 		listOptions := []runtimeclient.ListOption{
 			runtimeclient.MatchingLabels{
@@ -205,6 +344,28 @@ func ManageDeployment(client runtimeclient.Client, daemonset *datadoghqv1alpha1.
 	return result, err
 }
 
+// updatePodRestartingCondition updates the PodRestarting condition if a pod restarted after the last known restart.
+func updatePodRestartingCondition(status *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus, newRestartTime time.Time, restartingPodStatus string) {
+	if newRestartTime.IsZero() {
+		return
+	}
+	restartCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(status, datadoghqv1alpha1.ConditionTypePodRestarting)
+	if restartCondition != nil && !newRestartTime.After(restartCondition.LastUpdateTime.Time) {
+		return
+	}
+	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(status, metav1.NewTime(newRestartTime), datadoghqv1alpha1.ConditionTypePodRestarting, corev1.ConditionTrue, "", restartingPodStatus, false, true)
+}
+
+// isNewNodeFastPath returns true if the pod of the node can be created outside of the slow start limits: the node joined
+// the cluster after the creation of the replicaset, so it never ran an outdated pod.
+func isNewNodeFastPath(params *Parameters, node *NodeItem) bool {
+	if params.Strategy.RollingUpdate.MaxParallelNewNodePodCreation == nil {
+		return false
+	}
+
+	return node.Node.CreationTimestamp.After(params.Replicaset.CreationTimestamp.Time)
+}
+
 func getRollingUpdateStartTime(status *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus, now time.Time) time.Time {
 	if status == nil {
 		return now
@@ -20,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
 )
 
 func TestManageDeployment(t *testing.T) {
@@ -32,6 +33,11 @@ func TestManageDeployment(t *testing.T) {
 	logf.SetLogger(zap.New())
 	testLogger := logf.Log.WithName("test")
 
+	newNodeRollingUpdate := defaultRollingUpdate.DeepCopy()
+	newNodeRollingUpdate.MaxParallelPodCreation = datadoghqv1alpha1.NewInt32(1)
+	newNodeRollingUpdate.MaxParallelNewNodePodCreation = datadoghqv1alpha1.NewInt32(1)
+	newNode := NewNodeItem(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "new", CreationTimestamp: metaNow}}, nil)
+
 	tests := []struct {
 		name      string
 		params    *Parameters
@@ -108,8 +114,9 @@ func TestManageDeployment(t *testing.T) {
 				},
 				PodsToDelete: []*NodeItem{},
 				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
-					Status:  "active",
-					Desired: 2,
+					Status:            "active",
+					Desired:           2,
+					NumberUnavailable: 2,
 					Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
 						{
 							Type:               datadoghqv1alpha1.ConditionTypeActive,
@@ -159,11 +166,13 @@ func TestManageDeployment(t *testing.T) {
 				},
 				PodsToDelete: []*NodeItem{},
 				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
-					Status:    "active",
-					Desired:   2,
-					Current:   1,
-					Ready:     1,
-					Available: 1,
+					Status:                 "active",
+					Desired:                2,
+					Current:                1,
+					Ready:                  1,
+					Available:              1,
+					UpdatedNumberScheduled: 1,
+					NumberUnavailable:      1,
 					Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
 						{
 							Type:               datadoghqv1alpha1.ConditionTypeActive,
@@ -213,11 +222,12 @@ func TestManageDeployment(t *testing.T) {
 				},
 				PodsToDelete: []*NodeItem{},
 				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
-					Status:    "active",
-					Desired:   2,
-					Current:   0,
-					Ready:     0,
-					Available: 0,
+					Status:            "active",
+					Desired:           2,
+					Current:           0,
+					Ready:             0,
+					Available:         0,
+					NumberUnavailable: 2,
 					Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
 						{
 							Type:               datadoghqv1alpha1.ConditionTypeActive,
@@ -267,11 +277,177 @@ func TestManageDeployment(t *testing.T) {
 				},
 				PodsToDelete: []*NodeItem{},
 				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
-					Status:    "active",
-					Desired:   2,
-					Current:   0,
-					Ready:     0,
-					Available: 0,
+					Status:            "active",
+					Desired:           2,
+					Current:           0,
+					Ready:             0,
+					Available:         0,
+					NumberUnavailable: 2,
+					Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+						{
+							Type:               datadoghqv1alpha1.ConditionTypeActive,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metaNow,
+							LastUpdateTime:     metaNow,
+						},
+					},
+				},
+				Result: reconcile.Result{
+					Requeue: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "minReadySeconds, with one pod ready but not available yet",
+			params: &Parameters{
+				Logger:          testLogger,
+				NewStatus:       &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{},
+				MinReadySeconds: 10,
+				Strategy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: *defaultRollingUpdate,
+				},
+				Replicaset: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+					Status: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+						Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+							{
+								Type:               datadoghqv1alpha1.ConditionTypeActive,
+								Status:             corev1.ConditionTrue,
+								LastTransitionTime: metaNow,
+							},
+						},
+					},
+					Spec: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetSpec{
+						TemplateGeneration: "v1",
+					},
+				},
+				PodByNodeName: map[*NodeItem]*corev1.Pod{
+					testCanaryNodes["a"]: newTestPodOnNode("foo-a", "a", "v1", readySincePodStatus(now.Add(-5*time.Second))),
+					testCanaryNodes["b"]: newTestPodOnNode("foo-b", "b", "v1", readySincePodStatus(now.Add(-time.Minute))),
+				},
+			},
+			daemonset: &datadoghqv1alpha1.ExtendedDaemonSet{},
+			want: &Result{
+				PodsToCreate: []*NodeItem{},
+				PodsToDelete: []*NodeItem{},
+				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+					Status:                 "active",
+					Desired:                2,
+					Current:                2,
+					Ready:                  2,
+					Available:              1,
+					UpdatedNumberScheduled: 2,
+					NumberUnavailable:      1,
+					Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+						{
+							Type:               datadoghqv1alpha1.ConditionTypeActive,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metaNow,
+							LastUpdateTime:     metaNow,
+						},
+					},
+				},
+				Result: reconcile.Result{
+					Requeue:      true,
+					RequeueAfter: 10 * time.Second,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "onDelete, with one outdated pod that is not deleted",
+			params: &Parameters{
+				Logger:    testLogger,
+				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{},
+				Strategy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+					Type:          datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyTypeOnDelete,
+					RollingUpdate: *defaultRollingUpdate,
+				},
+				Replicaset: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+					Status: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+						Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+							{
+								Type:               datadoghqv1alpha1.ConditionTypeActive,
+								Status:             corev1.ConditionTrue,
+								LastTransitionTime: metaNow,
+							},
+						},
+					},
+					Spec: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetSpec{
+						TemplateGeneration: "v1",
+					},
+				},
+				PodByNodeName: map[*NodeItem]*corev1.Pod{
+					testCanaryNodes["a"]: newTestPodOnNode("foo-a", "a", "v2", readyPodStatus),
+					testCanaryNodes["b"]: newTestPodOnNode("foo-b", "b", "v1", readyPodStatus),
+				},
+			},
+			daemonset: &datadoghqv1alpha1.ExtendedDaemonSet{},
+			want: &Result{
+				PodsToCreate: []*NodeItem{},
+				PodsToDelete: []*NodeItem{},
+				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+					Status:                 "active",
+					Desired:                2,
+					Current:                1,
+					Ready:                  1,
+					Available:              1,
+					UpdatedNumberScheduled: 1,
+					NumberUnavailable:      1,
+					Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+						{
+							Type:               datadoghqv1alpha1.ConditionTypeActive,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metaNow,
+							LastUpdateTime:     metaNow,
+						},
+					},
+				},
+				Result: reconcile.Result{
+					Requeue: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "new node fast path, with one pod to create on an existing node and one on a new node",
+			params: &Parameters{
+				Logger:    testLogger,
+				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{},
+				Strategy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: *newNodeRollingUpdate,
+				},
+				Replicaset: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+					},
+					Status: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+						Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+							{
+								Type:               datadoghqv1alpha1.ConditionTypeActive,
+								Status:             corev1.ConditionTrue,
+								LastTransitionTime: metaNow,
+							},
+						},
+					},
+				},
+				PodByNodeName: map[*NodeItem]*corev1.Pod{
+					testCanaryNodes["b"]: nil,
+					newNode:              nil,
+				},
+			},
+			daemonset: &datadoghqv1alpha1.ExtendedDaemonSet{},
+			want: &Result{
+				PodsToCreate: []*NodeItem{
+					testCanaryNodes["b"],
+					newNode,
+				},
+				PodsToDelete: []*NodeItem{},
+				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+					Status:            "active",
+					Desired:           2,
+					NumberUnavailable: 2,
+					NewNodes:          1,
 					Conditions: []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
 						{
 							Type:               datadoghqv1alpha1.ConditionTypeActive,
@@ -301,6 +477,193 @@ func TestManageDeployment(t *testing.T) {
 	}
 }
 
+func TestManageDeployment_HealthGate(t *testing.T) {
+	now := time.Now()
+	metaNow := metav1.NewTime(now)
+
+	crashLoopStatus := podWaitingStatus(string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonCLB), "", now)
+	restartedStatus := podTerminatedStatus(10, string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonOOM), now)
+	// running again between two crashes, after restarting more than maxUnhealthyPodRestarts times
+	restartedReadyStatus := podTerminatedStatus(6, string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonCLB), now)
+	restartedReadyStatus.Conditions = readyPodStatus.Conditions
+	maxUnhealthyPods := intstr.FromInt(0)
+	completedCond := datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+		Type:   datadoghqv1alpha1.ConditionTypeRollingUpdateCompleted,
+		Status: corev1.ConditionTrue,
+	}
+	failedCond := datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{
+		Type:   datadoghqv1alpha1.ConditionTypeRollingUpdateFailed,
+		Status: corev1.ConditionTrue,
+	}
+
+	tests := []struct {
+		name            string
+		failOnUnhealthy bool
+		conditions      []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition
+		podStatus       corev1.PodStatus
+		// nextPodStatuses are the statuses of the up-to-date pod in the following reconciles,
+		// each reconcile starting from the status returned by the previous one.
+		nextPodStatuses []corev1.PodStatus
+		// resumed resumes the rolling update manually before the last reconcile.
+		resumed            bool
+		rolledOut          bool
+		newNode            bool
+		wantPaused         bool
+		wantFailed         bool
+		wantPodsToDelete   int
+		wantPausedReason   string
+		wantFailedReason   datadoghqv1alpha1.ExtendedDaemonSetStatusReason
+		wantFailedCondSet  bool
+		wantActiveCondTrue bool
+		wantCompleted      bool
+		wantPodsToCreate   int
+	}{
+		{
+			name:               "healthy up-to-date pods",
+			podStatus:          readyPodStatus,
+			wantPodsToDelete:   1,
+			wantActiveCondTrue: true,
+		},
+		{
+			name:             "unhealthy up-to-date pods pause the rolling update",
+			podStatus:        crashLoopStatus,
+			wantPaused:       true,
+			wantPausedReason: string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods),
+		},
+		{
+			name:              "unhealthy up-to-date pods fail the rolling update",
+			failOnUnhealthy:   true,
+			podStatus:         crashLoopStatus,
+			wantFailed:        true,
+			wantFailedReason:  datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods,
+			wantFailedCondSet: true,
+		},
+		{
+			name:              "failed rolling update stays failed",
+			failOnUnhealthy:   true,
+			conditions:        []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{failedCond},
+			podStatus:         readyPodStatus,
+			wantFailed:        true,
+			wantFailedCondSet: true,
+		},
+		{
+			name:               "all pods updated and available => rolling update completed",
+			podStatus:          readyPodStatus,
+			rolledOut:          true,
+			wantActiveCondTrue: true,
+			wantCompleted:      true,
+		},
+		{
+			name:               "completed rolling update with restarted pods => health gate not checked",
+			failOnUnhealthy:    true,
+			conditions:         []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{completedCond},
+			podStatus:          restartedStatus,
+			rolledOut:          true,
+			newNode:            true,
+			wantActiveCondTrue: true,
+			wantCompleted:      true,
+			wantPodsToCreate:   1,
+		},
+		{
+			name:               "completed rolling update => failed condition cleared, pods created on new nodes",
+			failOnUnhealthy:    true,
+			conditions:         []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetCondition{completedCond, failedCond},
+			podStatus:          crashLoopStatus,
+			rolledOut:          true,
+			newNode:            true,
+			wantActiveCondTrue: true,
+			wantCompleted:      true,
+			wantPodsToCreate:   1,
+		},
+		{
+			name:             "paused by the health gate => stays paused while the pods flap",
+			podStatus:        crashLoopStatus,
+			nextPodStatuses:  []corev1.PodStatus{restartedReadyStatus, crashLoopStatus, restartedReadyStatus},
+			wantPaused:       true,
+			wantPausedReason: string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods),
+		},
+		{
+			name:               "paused by the health gate, resumed manually => restarts before the resume ignored",
+			podStatus:          crashLoopStatus,
+			nextPodStatuses:    []corev1.PodStatus{restartedReadyStatus, restartedReadyStatus},
+			resumed:            true,
+			wantPodsToDelete:   1,
+			wantActiveCondTrue: true,
+		},
+	}
+	client := fake.NewClientBuilder().Build()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollingUpdate := datadoghqv1alpha1.DefaultExtendedDaemonSetSpecStrategyRollingUpdate(&datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyRollingUpdate{
+				MaxUnhealthyPods:        &maxUnhealthyPods,
+				MaxUnhealthyPodRestarts: datadoghqv1alpha1.NewInt32(5),
+				FailOnUnhealthyPods:     datadoghqv1alpha1.NewBool(tt.failOnUnhealthy),
+			})
+			podBTemplateGeneration := "v1"
+			if tt.rolledOut {
+				podBTemplateGeneration = "v2"
+			}
+			params := &Parameters{
+				Logger: testLogger,
+				NewStatus: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+					Conditions: tt.conditions,
+				},
+				Strategy: &datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy{
+					RollingUpdate: *rollingUpdate,
+				},
+				Replicaset: &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+					Status: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
+						Conditions: tt.conditions,
+					},
+					Spec: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetSpec{
+						TemplateGeneration: "v2",
+					},
+				},
+				PodByNodeName: map[*NodeItem]*corev1.Pod{
+					testCanaryNodes["a"]: newTestPodOnNode("foo-a", "a", "v2", tt.podStatus),
+					testCanaryNodes["b"]: newTestPodOnNode("foo-b", "b", podBTemplateGeneration, readyPodStatus),
+				},
+			}
+			if tt.newNode {
+				params.PodByNodeName[testCanaryNodes["c"]] = nil
+			}
+
+			got, err := ManageDeployment(client, &datadoghqv1alpha1.ExtendedDaemonSet{}, params, metaNow)
+			require.NoError(t, err)
+			for i, podStatus := range tt.nextPodStatuses {
+				if tt.wantPaused {
+					assert.Empty(t, got.PodsToDelete, "reconcile %d", i)
+				}
+				reconcileTime := metav1.NewTime(now.Add(time.Duration(i+1) * time.Minute))
+				params.Replicaset.Status = *got.NewStatus
+				if tt.resumed && i == len(tt.nextPodStatuses)-1 {
+					conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(&params.Replicaset.Status, reconcileTime, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused, corev1.ConditionFalse, "", "", false, false)
+				}
+				params.NewStatus = params.Replicaset.Status.DeepCopy()
+				params.PodByNodeName[testCanaryNodes["a"]] = newTestPodOnNode("foo-a", "a", "v2", podStatus)
+
+				got, err = ManageDeployment(client, &datadoghqv1alpha1.ExtendedDaemonSet{}, params, reconcileTime)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPaused, got.IsPaused)
+			assert.Equal(t, tt.wantFailed, got.IsFailed)
+			assert.Equal(t, tt.wantFailedReason, got.FailedReason)
+			assert.Len(t, got.PodsToDelete, tt.wantPodsToDelete)
+			assert.Len(t, got.PodsToCreate, tt.wantPodsToCreate)
+
+			pausedCond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(got.NewStatus, datadoghqv1alpha1.ConditionTypeRollingUpdatePaused)
+			if tt.wantPaused {
+				require.NotNil(t, pausedCond)
+				assert.Equal(t, tt.wantPausedReason, pausedCond.Reason)
+			}
+			assert.Equal(t, tt.wantFailedCondSet, conditions.IsConditionTrue(got.NewStatus, datadoghqv1alpha1.ConditionTypeRollingUpdateFailed))
+			assert.Equal(t, tt.wantActiveCondTrue, conditions.IsConditionTrue(got.NewStatus, datadoghqv1alpha1.ConditionTypeActive))
+			assert.Equal(t, tt.wantCompleted, conditions.IsConditionTrue(got.NewStatus, datadoghqv1alpha1.ConditionTypeRollingUpdateCompleted))
+		})
+	}
+}
+
 func Test_calculateMaxCreation(t *testing.T) {
 	now := time.Now()
 
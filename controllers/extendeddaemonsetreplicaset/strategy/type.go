@@ -39,12 +39,17 @@ type Parameters struct {
 
 	NewStatus *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus
 
+	// MinReadySeconds is the minimum number of seconds a pod should be ready to be considered available.
+	MinReadySeconds int32
+
 	CanaryNodes []string
 
 	NodeByName      map[string]*NodeItem
 	PodByNodeName   map[*NodeItem]*corev1.Pod
 	PodToCleanUp    []*corev1.Pod
 	UnscheduledPods []*corev1.Pod
+	// PrePullPodByNodeName contains the image pre-pull pods of the replicaset, by node name.
+	PrePullPodByNodeName map[string]*corev1.Pod
 
 	Logger logr.Logger
 }
@@ -55,8 +60,16 @@ type Result struct {
 	PodsToCreate []*NodeItem
 	// PodsToDelete list of NodeItem for Pods deletion.
 	PodsToDelete []*NodeItem
+	// PodsToDrain list of NodeItem whose Pod should be asked to drain before its deletion.
+	PodsToDrain []*NodeItem
+	// PrePullPodsToCreate list of NodeItem for image pre-pull Pods creation.
+	PrePullPodsToCreate []*NodeItem
+	// PrePullPodsToDelete list of image pre-pull Pods to delete.
+	PrePullPodsToDelete []*corev1.Pod
 
 	UnscheduledNodesDueToResourcesConstraints []string
+	// EvictionBlockedNodes list of the nodes whose pod eviction is blocked by a PodDisruptionBudget.
+	EvictionBlockedNodes []string
 
 	// IsFrozen represents frozen status of the deployment.
 	IsFrozen bool
@@ -8,20 +8,21 @@ package strategy
 import (
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
 )
 
 // ManageUnknown use to manage ReplicaSet with unknown status.
-func ManageUnknown(client client.Client, params *Parameters) (*Result, error) {
+func ManageUnknown(client client.Client, params *Parameters, metaNow metav1.Time) (*Result, error) {
 	result := &Result{}
 	// remove canary node if define
 	for _, nodeName := range params.CanaryNodes {
 		delete(params.PodByNodeName, params.NodeByName[nodeName])
 	}
 
-	var desiredPods, currentPods, availablePods, readyPods, nbIgnoredUnresponsiveNodes int32
+	var desiredPods, currentPods, scheduledPods, availablePods, readyPods, nbIgnoredUnresponsiveNodes int32
 
 	for node, pod := range params.PodByNodeName {
 		desiredPods++
@@ -34,7 +35,10 @@ func ManageUnknown(client client.Client, params *Parameters) (*Result, error) {
 				}
 
 				currentPods++
-				if podutils.IsPodReady(pod) {
+				if _, scheduled := podutils.IsPodScheduled(pod); scheduled {
+					scheduledPods++
+				}
+				if podutils.IsPodAvailable(pod, params.MinReadySeconds, metaNow.Time) {
 					availablePods++
 				}
 				if podutils.IsPodReady(pod) {
@@ -50,9 +54,14 @@ func ManageUnknown(client client.Client, params *Parameters) (*Result, error) {
 	result.NewStatus.Ready = readyPods
 	result.NewStatus.Current = currentPods
 	result.NewStatus.Available = availablePods
+	result.NewStatus.UpdatedNumberScheduled = scheduledPods
+	result.NewStatus.NumberUnavailable = 0
 	result.NewStatus.IgnoredUnresponsiveNodes = nbIgnoredUnresponsiveNodes
 	params.Logger.V(1).Info("Status:", "Desired", result.NewStatus.Desired, "Ready", readyPods, "Available", availablePods)
 
+	// The images pre-pull only runs with the active replicaset
+	result.PrePullPodsToDelete = cleanupPrePullPods(params)
+
 	if result.NewStatus.Desired != result.NewStatus.Ready {
 		result.Result.Requeue = true
 	}
@@ -7,6 +7,7 @@ package strategy
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -39,8 +40,24 @@ func compareCurrentPodWithNewPod(params *Parameters, pod *corev1.Pod, node *Node
 	return true
 }
 
+// OutdatedPodReason returns why the pod doesn't correspond to the replicaset and the node anymore,
+// or an empty string if the pod is up to date. It follows the same checks as compareCurrentPodWithNewPod.
+func OutdatedPodReason(params *Parameters, pod *corev1.Pod, node *NodeItem) string {
+	if !compareSpecTemplateMD5Hash(params.Replicaset.Spec.TemplateGeneration, pod) {
+		return fmt.Sprintf("pod template hash %q doesn't match the replicaset template hash %q", pod.Annotations[datadoghqv1alpha1.MD5ExtendedDaemonSetAnnotationKey], params.Replicaset.Spec.TemplateGeneration)
+	}
+	if !compareWithExtendedDaemonsetSettingOverwrite(pod, node) {
+		return fmt.Sprintf("pod resources don't match the ExtendedDaemonsetSetting %s", node.ExtendedDaemonsetSetting.Name)
+	}
+	if !compareNodeResourcesOverwriteMD5Hash(params.EDSName, params.Replicaset, pod, node) {
+		return "pod resources don't match the node resources overwrite annotations"
+	}
+
+	return ""
+}
+
 func compareNodeResourcesOverwriteMD5Hash(edsName string, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, pod *corev1.Pod, node *NodeItem) bool {
-	nodeHash := comparison.GenerateHashFromEDSResourceNodeAnnotation(replicaset.Namespace, edsName, node.Node.GetAnnotations())
+	nodeHash := comparison.GenerateNodeHash(replicaset.Namespace, edsName, replicaset.Spec.NodeMetadata, node.Node)
 	if val, ok := pod.Annotations[datadoghqv1alpha1.MD5NodeExtendedDaemonSetAnnotationKey]; !ok && nodeHash == "" || ok && val == nodeHash {
 		return true
 	}
@@ -87,22 +104,26 @@ func compareSpecTemplateMD5Hash(hash string, pod *corev1.Pod) bool {
 	return false
 }
 
-func cleanupPods(client client.Client, logger logr.Logger, status *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus, pods []*corev1.Pod) error {
-	errs := deletePodSlice(client, logger, pods)
+// cleanupPods deletes the pods, or evicts them if useEviction is true. It returns the nodes whose pod eviction
+// is blocked by a PodDisruptionBudget, which is not an error: the eviction is retried later.
+func cleanupPods(client client.Client, logger logr.Logger, status *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus, pods []*corev1.Pod, useEviction bool) ([]string, error) {
+	blockedNodes, errs := deletePodSlice(client, logger, pods, useEviction)
 	now := metav1.NewTime(time.Now())
 	conditionStatus := corev1.ConditionTrue
-	if len(errs) > 0 {
+	if len(errs) > 0 || len(blockedNodes) > 0 {
 		conditionStatus = corev1.ConditionFalse
 	}
 	if len(pods) != 0 {
 		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypePodsCleanupDone, conditionStatus, "", "", false, false)
 	}
 
-	return utilserrors.NewAggregate(errs)
+	return blockedNodes, utilserrors.NewAggregate(errs)
 }
 
-func deletePodSlice(client client.Client, logger logr.Logger, podsToDelete []*corev1.Pod) []error {
+func deletePodSlice(client client.Client, logger logr.Logger, podsToDelete []*corev1.Pod, useEviction bool) ([]string, []error) {
+	var blockedNodes []string
 	var errs []error
+	var mutex sync.Mutex
 	var wg sync.WaitGroup
 	for id, pod := range podsToDelete {
 		if pod.DeletionTimestamp != nil {
@@ -113,16 +134,30 @@ func deletePodSlice(client client.Client, logger logr.Logger, podsToDelete []*co
 		go func(id int) {
 			defer wg.Done()
 			pod := podsToDelete[id]
-			logger.Info("cleanupPods delete pod", "pod_name", pod.Name)
-			err := client.Delete(context.TODO(), pod)
-			if err != nil {
-				errs = append(errs, err)
+			logger.Info("cleanupPods delete pod", "pod_name", pod.Name, "eviction", useEviction)
+			err := podutils.DeletePod(client, pod, useEviction)
+			if err == nil {
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if podutils.IsEvictionBlocked(err) {
+				nodeName, _ := podutils.GetNodeNameFromPod(pod)
+				blockedNodes = append(blockedNodes, nodeName)
+
+				return
 			}
+			errs = append(errs, err)
 		}(id)
 	}
 	wg.Wait()
 
-	return errs
+	return blockedNodes, errs
+}
+
+// UseEvictionAPI returns true if the pods are evicted with the Eviction API instead of being deleted.
+func UseEvictionAPI(strategy *datadoghqv1alpha1.ExtendedDaemonSetSpecStrategy) bool {
+	return strategy.UseEvictionAPI != nil && *strategy.UseEvictionAPI
 }
 
 func manageUnscheduledPodNodes(pods []*corev1.Pod) []string {
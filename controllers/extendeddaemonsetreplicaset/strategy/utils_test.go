@@ -6,11 +6,13 @@
 package strategy
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -254,11 +256,12 @@ func Test_cleanupPods(t *testing.T) {
 	logger := logf.Log.WithName("test")
 
 	status := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{
-		Status:    "canary",
-		Desired:   3,
-		Current:   1,
-		Ready:     1,
-		Available: 1,
+		Status:            "canary",
+		Desired:           3,
+		Current:           1,
+		Ready:             1,
+		Available:         1,
+		NumberUnavailable: 2,
 	}
 
 	pod1 := newTestCanaryPod("foo-a", "v1", readyPodStatus)
@@ -267,8 +270,17 @@ func Test_cleanupPods(t *testing.T) {
 	}
 	client := fake.NewClientBuilder().WithObjects(pod1).Build()
 
-	err := cleanupPods(client, logger, status, pods)
+	blockedNodes, err := cleanupPods(client, logger, status, pods, false)
 	require.NoErrorf(t, err, "error must be nil, err: %v", err)
+	assert.Empty(t, blockedNodes)
+
+	// with the Eviction API
+	pod2 := newTestCanaryPod("foo-b", "v1", readyPodStatus)
+	client = fake.NewClientBuilder().WithObjects(pod2).Build()
+	blockedNodes, err = cleanupPods(client, logger, status, []*corev1.Pod{pod2}, true)
+	require.NoErrorf(t, err, "error must be nil, err: %v", err)
+	assert.Empty(t, blockedNodes)
+	assert.True(t, apierrors.IsNotFound(client.Get(context.TODO(), types.NamespacedName{Namespace: pod2.Namespace, Name: pod2.Name}, &corev1.Pod{})), "the pod should be evicted")
 }
 
 func Test_manageUnscheduledPodNodes(t *testing.T) {
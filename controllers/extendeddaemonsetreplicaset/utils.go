@@ -7,18 +7,29 @@ package extendeddaemonsetreplicaset
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
 	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/strategy"
 	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
 )
 
+// maxPlannedActionsInStatus is the maximum number of actions reported in the ExtendedDaemonSetReplicaSet
+// status.dryRunPlan, to keep the status size independent of the cluster size.
+const maxPlannedActionsInStatus = 20
+
 func createPods(logger logr.Logger, client client.Client, scheme *runtime.Scheme, podAffinitySupported bool, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, podsToCreate []*strategy.NodeItem) []error {
 	var errs []error
 	var wg sync.WaitGroup
@@ -55,18 +66,84 @@ func createPods(logger logr.Logger, client client.Client, scheme *runtime.Scheme
 	return errs
 }
 
-func deletePods(logger logr.Logger, c client.Client, podByNodeName map[*strategy.NodeItem]*corev1.Pod, nodes []*strategy.NodeItem) []error {
+// deletePods deletes the pods of the nodes, or evicts them if useEviction is true. It returns the nodes whose pod
+// eviction is blocked by a PodDisruptionBudget, which is not an error: the eviction is retried later.
+func deletePods(logger logr.Logger, c client.Client, podByNodeName map[*strategy.NodeItem]*corev1.Pod, nodes []*strategy.NodeItem, useEviction bool) ([]string, []error) {
+	var blockedNodes []string
 	var errs []error
 	var wg sync.WaitGroup
 	errsChan := make(chan error, len(nodes))
+	blockedChan := make(chan string, len(nodes))
 	for _, node := range nodes {
 		wg.Add(1)
 		go func(n *strategy.NodeItem) {
 			defer wg.Done()
-			logger.V(1).Info("Delete pod", "name", podByNodeName[n].Name, "node", n.Node.Name)
-			err := c.Delete(context.TODO(), podByNodeName[n])
+			logger.V(1).Info("Delete pod", "name", podByNodeName[n].Name, "node", n.Node.Name, "eviction", useEviction)
+			err := podutils.DeletePod(c, podByNodeName[n], useEviction)
+			if podutils.IsEvictionBlocked(err) {
+				logger.Info("Pod eviction blocked by a PodDisruptionBudget", "name", podByNodeName[n].Name, "node", n.Node.Name)
+				blockedChan <- n.Node.Name
+			} else if err != nil {
+				errsChan <- err
+			}
+		}(node)
+	}
+	go func() {
+		wg.Wait()
+		close(errsChan)
+		close(blockedChan)
+	}()
+
+	for err := range errsChan {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for nodeName := range blockedChan {
+		blockedNodes = append(blockedNodes, nodeName)
+	}
+
+	return blockedNodes, errs
+}
+
+// requestPodsDrain adds the drain-requested annotation on the pods of the nodes, with the request time as value.
+func requestPodsDrain(logger logr.Logger, c client.Client, podByNodeName map[*strategy.NodeItem]*corev1.Pod, nodes []*strategy.NodeItem, now metav1.Time) []error {
+	var errs []error
+	for _, node := range nodes {
+		pod := podByNodeName[node]
+		logger.V(1).Info("Request pod drain", "name", pod.Name, "node", node.Node.Name)
+		newPod := pod.DeepCopy()
+		if newPod.Annotations == nil {
+			newPod.Annotations = make(map[string]string)
+		}
+		newPod.Annotations[datadoghqv1alpha1.ExtendedDaemonSetDrainRequestedAnnotationKey] = now.UTC().Format(time.RFC3339)
+		// A merge patch will preserve the annotations set by the pod itself.
+		if err := c.Patch(context.TODO(), newPod, client.MergeFrom(pod)); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("unable to request the drain of the pod %s, err: %w", pod.Name, err))
+		}
+	}
+
+	return errs
+}
+
+func createPrePullPods(logger logr.Logger, c client.Client, scheme *runtime.Scheme, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, nodes []*strategy.NodeItem) []error {
+	var errs []error
+	var wg sync.WaitGroup
+	errsChan := make(chan error, len(nodes))
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(n *strategy.NodeItem) {
+			defer wg.Done()
+			newPod, err := podutils.CreatePrePullPodFromDaemonSetReplicaSet(scheme, replicaset, n.Node)
 			if err != nil {
 				errsChan <- err
+
+				return
+			}
+			logger.V(1).Info("Create pre-pull pod", "name", newPod.GenerateName, "node", n.Node.Name)
+			if err = c.Create(context.TODO(), newPod); err != nil {
+				logger.Error(err, "Create pre-pull pod failed", "name", newPod.GenerateName)
+				errsChan <- err
 			}
 		}(node)
 	}
@@ -83,3 +160,122 @@ func deletePods(logger logr.Logger, c client.Client, podByNodeName map[*strategy
 
 	return errs
 }
+
+func deletePrePullPods(logger logr.Logger, c client.Client, pods []*corev1.Pod) []error {
+	var errs []error
+	for _, pod := range pods {
+		logger.V(1).Info("Delete pre-pull pod", "name", pod.Name, "node", pod.Spec.NodeName)
+		if err := c.Delete(context.TODO(), pod); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// updateEvictionBlockedCondition reports the nodes whose pod eviction is blocked by a PodDisruptionBudget.
+// The condition is only added once an eviction is blocked.
+func updateEvictionBlockedCondition(status *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus, now metav1.Time, blockedNodes []string) {
+	if len(blockedNodes) == 0 {
+		if conditions.GetExtendedDaemonSetReplicaSetStatusCondition(status, datadoghqv1alpha1.ConditionTypePodEvictionBlocked) != nil {
+			conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypePodEvictionBlocked, corev1.ConditionFalse, "", "", false, false)
+		}
+
+		return
+	}
+	sort.Strings(blockedNodes)
+	desc := "nodes:" + strings.Join(blockedNodes, ";")
+	conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(status, now, datadoghqv1alpha1.ConditionTypePodEvictionBlocked, corev1.ConditionTrue, "PodDisruptionBudget", desc, false, false)
+}
+
+// isDryRun returns true if the ExtendedDaemonSet pods creations and deletions should only be planned.
+func isDryRun(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) bool {
+	return daemonset.GetAnnotations()[datadoghqv1alpha1.ExtendedDaemonSetDryRunAnnotationKey] == datadoghqv1alpha1.ValueStringTrue
+}
+
+// planPodActions returns the pods creations and deletions that the strategy result would have triggered,
+// including the pods cleanup done by the strategy itself.
+func planPodActions(params *strategy.Parameters, result *strategy.Result) *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetDryRunPlan {
+	plan := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetDryRunPlan{}
+	var actions []datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPlannedAction
+	for _, node := range result.PodsToCreate {
+		plan.PodsToCreate++
+		actions = append(actions, datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPlannedAction{
+			Type:   datadoghqv1alpha1.PlannedActionTypeCreate,
+			Node:   node.Node.Name,
+			Reason: "no pod of the replicaset is running on the node",
+		})
+	}
+
+	for _, node := range result.PodsToDelete {
+		pod := params.PodByNodeName[node]
+		if pod == nil {
+			continue
+		}
+		reason := strategy.OutdatedPodReason(params, pod, node)
+		if reason == "" {
+			reason = fmt.Sprintf("pod deleted by the %s strategy", params.ReplicaSetStatus)
+		}
+		plan.PodsToDelete++
+		actions = append(actions, datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPlannedAction{
+			Type:   datadoghqv1alpha1.PlannedActionTypeDelete,
+			Node:   node.Node.Name,
+			Pod:    pod.Name,
+			Reason: reason,
+		})
+	}
+
+	for _, pod := range params.PodToCleanUp {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		nodeName, _ := podutils.GetNodeNameFromPod(pod)
+		plan.PodsToDelete++
+		actions = append(actions, datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPlannedAction{
+			Type:   datadoghqv1alpha1.PlannedActionTypeDelete,
+			Node:   nodeName,
+			Pod:    pod.Name,
+			Reason: "pod cleanup: the pod is duplicated, failed, or its node doesn't match the replicaset anymore",
+		})
+	}
+
+	// keep a stable sample between reconcile loops
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].Type != actions[j].Type {
+			return actions[i].Type < actions[j].Type
+		}
+
+		return actions[i].Node < actions[j].Node
+	})
+	if len(actions) > maxPlannedActionsInStatus {
+		actions = actions[:maxPlannedActionsInStatus]
+	}
+	plan.Actions = actions
+
+	return plan
+}
+
+// recordDryRunEvents emits one event per type of planned action, with the names of the nodes reported in the plan.
+func (r *Reconciler) recordDryRunEvents(replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, plan *datadoghqv1alpha1.ExtendedDaemonSetReplicaSetDryRunPlan) {
+	nodesByType := map[datadoghqv1alpha1.PlannedActionType][]string{}
+	for _, action := range plan.Actions {
+		nodesByType[action.Type] = append(nodesByType[action.Type], action.Node)
+	}
+
+	if plan.PodsToCreate > 0 {
+		r.recorder.Eventf(replicaset, corev1.EventTypeNormal, "DryRun Create Pods", "%d pod(s) would be created on nodes: %s", plan.PodsToCreate, joinNodeNames(nodesByType[datadoghqv1alpha1.PlannedActionTypeCreate], plan.PodsToCreate))
+	}
+	if plan.PodsToDelete > 0 {
+		r.recorder.Eventf(replicaset, corev1.EventTypeNormal, "DryRun Delete Pods", "%d pod(s) would be deleted on nodes: %s", plan.PodsToDelete, joinNodeNames(nodesByType[datadoghqv1alpha1.PlannedActionTypeDelete], plan.PodsToDelete))
+	}
+}
+
+// joinNodeNames joins the node names, and marks the list as truncated if it contains less than total names.
+func joinNodeNames(nodeNames []string, total int32) string {
+	output := strings.Join(nodeNames, ", ")
+	if int32(len(nodeNames)) < total {
+		output += ", ..."
+	}
+
+	return output
+}
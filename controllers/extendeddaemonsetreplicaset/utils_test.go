@@ -0,0 +1,76 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package extendeddaemonsetreplicaset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/strategy"
+	ctrltest "github.com/DataDog/extendeddaemonset/pkg/controller/test"
+)
+
+func Test_deletePods(t *testing.T) {
+	node1 := strategy.NewNodeItem(ctrltest.NewNode("node1", nil), nil)
+	node2 := strategy.NewNodeItem(ctrltest.NewNode("node2", nil), nil)
+	pod1 := ctrltest.NewPod("bar", "pod1", "node1", nil)
+	pod2 := ctrltest.NewPod("bar", "pod2", "node2", nil)
+	podByNodeName := map[*strategy.NodeItem]*corev1.Pod{node1: pod1, node2: pod2}
+
+	// the eviction of pod2 is refused because of a PodDisruptionBudget
+	c := fake.NewClientBuilder().WithObjects(pod1, pod2).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+			if obj.GetName() == "pod2" {
+				return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+			}
+
+			return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+		},
+	}).Build()
+
+	blockedNodes, errs := deletePods(testLogger, c, podByNodeName, []*strategy.NodeItem{node1, node2}, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"node2"}, blockedNodes)
+	assert.True(t, apierrors.IsNotFound(c.Get(context.TODO(), client.ObjectKeyFromObject(pod1), &corev1.Pod{})), "pod1 should be evicted")
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(pod2), &corev1.Pod{}), "pod2 should not be evicted")
+
+	// without the Eviction API, the pods are deleted
+	blockedNodes, errs = deletePods(testLogger, c, podByNodeName, []*strategy.NodeItem{node2}, false)
+	assert.Empty(t, errs)
+	assert.Empty(t, blockedNodes)
+	assert.True(t, apierrors.IsNotFound(c.Get(context.TODO(), client.ObjectKeyFromObject(pod2), &corev1.Pod{})), "pod2 should be deleted")
+}
+
+func Test_updateEvictionBlockedCondition(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	status := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSetStatus{}
+
+	updateEvictionBlockedCondition(status, now, nil)
+	assert.Nil(t, conditions.GetExtendedDaemonSetReplicaSetStatusCondition(status, datadoghqv1alpha1.ConditionTypePodEvictionBlocked), "the condition should not be added without blocked eviction")
+
+	updateEvictionBlockedCondition(status, now, []string{"node2", "node1"})
+	cond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(status, datadoghqv1alpha1.ConditionTypePodEvictionBlocked)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Equal(t, "nodes:node1;node2", cond.Message)
+
+	updateEvictionBlockedCondition(status, now, nil)
+	cond = conditions.GetExtendedDaemonSetReplicaSetStatusCondition(status, datadoghqv1alpha1.ConditionTypePodEvictionBlocked)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+}
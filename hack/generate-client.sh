@@ -0,0 +1,39 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+ROOT=$(git rev-parse --show-toplevel)
+BIN_DIR="$ROOT/bin"
+MODULE=github.com/DataDog/extendeddaemonset
+OUTPUT_DIR="$ROOT/api/client"
+OUTPUT_PKG="$MODULE/api/client"
+HEADER_FILE="$ROOT/hack/boilerplate.go.txt"
+
+# the generators resolve the packages from the api module
+cd "$ROOT/api"
+
+rm -rf "$OUTPUT_DIR/clientset" "$OUTPUT_DIR/listers" "$OUTPUT_DIR/informers"
+
+"$BIN_DIR/client-gen" \
+  --clientset-name versioned \
+  --input-base "$MODULE" \
+  --input api/v1alpha1 \
+  --output-dir "$OUTPUT_DIR/clientset" \
+  --output-pkg "$OUTPUT_PKG/clientset" \
+  --go-header-file "$HEADER_FILE"
+
+"$BIN_DIR/lister-gen" \
+  --output-dir "$OUTPUT_DIR/listers" \
+  --output-pkg "$OUTPUT_PKG/listers" \
+  --go-header-file "$HEADER_FILE" \
+  "$MODULE/api/v1alpha1"
+
+"$BIN_DIR/informer-gen" \
+  --versioned-clientset-package "$OUTPUT_PKG/clientset/versioned" \
+  --listers-package "$OUTPUT_PKG/listers" \
+  --output-dir "$OUTPUT_DIR/informers" \
+  --output-pkg "$OUTPUT_PKG/informers" \
+  --go-header-file "$HEADER_FILE" \
+  "$MODULE/api/v1alpha1"
@@ -27,7 +27,8 @@ done
 $YQ m -i "$ROOT_DIR/$v1beta1/datadoghq.com_extendeddaemonsetreplicasets.yaml" "$ROOT_DIR/hack/patch-crd-v1beta1-protocol-kube1.18.yaml"
 $YQ m -i "$ROOT_DIR/$v1beta1/datadoghq.com_extendeddaemonsets.yaml" "$ROOT_DIR/hack/patch-crd-v1beta1-protocol-kube1.18.yaml"
 $YQ m -i "$ROOT_DIR/$v1/datadoghq.com_extendeddaemonsetreplicasets.yaml" "$ROOT_DIR/hack/patch-crd-v1-protocol-kube1.18.yaml"
-$YQ m -i "$ROOT_DIR/$v1/datadoghq.com_extendeddaemonsets.yaml" "$ROOT_DIR/hack/patch-crd-v1-protocol-kube1.18.yaml"
+# The ExtendedDaemonSet CRD serves both v1alpha1 and v1beta1, its patch contains one entry per version.
+$YQ m -i "$ROOT_DIR/$v1/datadoghq.com_extendeddaemonsets.yaml" "$ROOT_DIR/hack/patch-crd-v1-protocol-kube1.18-eds.yaml"
 
 
 # Update `metadata` attribute of v1.PodTemplateSpec to properly validate the
@@ -35,7 +36,8 @@ $YQ m -i "$ROOT_DIR/$v1/datadoghq.com_extendeddaemonsets.yaml" "$ROOT_DIR/hack/p
 # insufficient.
 patch_CRD_metadata="$ROOT_DIR/hack/patch-crd-metadata.yaml"
 
-# Different object path in v1beta1 and v1. Notice that v1 assumes that there's just 1 version
+# Different object path in v1beta1 and v1. Notice that v1 assumes that there's just 1 version,
+# except for the ExtendedDaemonSet CRD that also serves v1beta1
 openAPIV3Schema_path_v1beta1="spec.validation.openAPIV3Schema"
 openAPIV3Schema_path="spec.versions[0].schema.openAPIV3Schema"
 metadata_subpath="properties.spec.properties.template.properties.metadata"
@@ -44,3 +46,4 @@ $YQ w -i "$ROOT_DIR/$v1beta1/datadoghq.com_extendeddaemonsetreplicasets.yaml" "$
 $YQ w -i "$ROOT_DIR/$v1beta1/datadoghq.com_extendeddaemonsets.yaml" "$openAPIV3Schema_path_v1beta1"."$metadata_subpath" -f "$patch_CRD_metadata"
 $YQ w -i "$ROOT_DIR/$v1/datadoghq.com_extendeddaemonsetreplicasets.yaml" "$openAPIV3Schema_path"."$metadata_subpath" -f "$patch_CRD_metadata"
 $YQ w -i "$ROOT_DIR/$v1/datadoghq.com_extendeddaemonsets.yaml" "$openAPIV3Schema_path"."$metadata_subpath" -f "$patch_CRD_metadata"
+$YQ w -i "$ROOT_DIR/$v1/datadoghq.com_extendeddaemonsets.yaml" "spec.versions[1].schema.openAPIV3Schema"."$metadata_subpath" -f "$patch_CRD_metadata"
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	datadoghqv1beta1 "github.com/DataDog/extendeddaemonset/api/v1beta1"
 	"github.com/DataDog/extendeddaemonset/controllers"
 	"github.com/DataDog/extendeddaemonset/pkg/config"
 	"github.com/DataDog/extendeddaemonset/pkg/controller/debug"
@@ -55,6 +56,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(datadoghqv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(datadoghqv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -62,10 +64,13 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var leaderElectionResourceLock string
+	var enableConversionWebhook bool
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", true,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&leaderElectionResourceLock, "leader-election-resource", "leases", "determines which resource lock to use for leader election. option:[leases]")
+	flag.BoolVar(&enableConversionWebhook, "enable-conversion-webhook", false,
+		"Enable the conversion webhook serving the ExtendedDaemonSet v1beta1 version. It requires the webhook serving certificate.")
 
 	// Custom flags
 	var printVersion, pprofActive, ddProfilingEnabled bool
@@ -169,6 +174,15 @@ func main() {
 		return
 	}
 
+	if enableConversionWebhook {
+		if err = ctrl.NewWebhookManagedBy(mgr).For(&datadoghqv1alpha1.ExtendedDaemonSet{}).Complete(); err != nil {
+			setupLog.Error(err, "unable to setup the conversion webhook")
+			exitCode = 1
+
+			return
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
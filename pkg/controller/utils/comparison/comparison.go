@@ -26,7 +26,7 @@ import (
 
 // IsReplicaSetUpToDate returns true if the ExtendedDaemonSetReplicaSet is up to date with the ExtendedDaemonSet pod template.
 func IsReplicaSetUpToDate(rs *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, daemonset *datadoghqv1alpha1.ExtendedDaemonSet) bool {
-	hash, err := GenerateMD5PodTemplateSpec(&daemonset.Spec.Template)
+	hash, err := GenerateMD5ReplicaSetSpec(daemonset)
 	if err != nil {
 		return false
 	}
@@ -59,9 +59,37 @@ func GenerateMD5PodTemplateSpec(tpl *corev1.PodTemplateSpec) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// GenerateMD5ReplicaSetSpec used to generate the MD5 hash of the ExtendedDaemonSet parts copied in its ExtendedDaemonSetReplicaSets:
+// the pod template, and the node metadata copied into the pods if any.
+func GenerateMD5ReplicaSetSpec(daemonset *datadoghqv1alpha1.ExtendedDaemonSet) (string, error) {
+	if len(daemonset.Spec.NodeMetadata) == 0 {
+		// keep the pod template hash to not replace the existing replicasets
+		return GenerateMD5PodTemplateSpec(&daemonset.Spec.Template)
+	}
+
+	b, err := json.Marshal(struct {
+		Template     corev1.PodTemplateSpec                            `json:"template"`
+		NodeMetadata []datadoghqv1alpha1.ExtendedDaemonSetNodeMetadata `json:"nodeMetadata"`
+	}{
+		Template:     daemonset.Spec.Template,
+		NodeMetadata: daemonset.Spec.NodeMetadata,
+	})
+	if err != nil {
+		return "", err
+	}
+	/* #nosec */
+	hash := md5.New()
+	_, err = io.Copy(hash, bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // SetMD5PodTemplateSpecAnnotation used to set the md5 annotation key/value from the ExtendedDaemonSetReplicaSet.Spec.Template.
 func SetMD5PodTemplateSpecAnnotation(rs *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, daemonset *datadoghqv1alpha1.ExtendedDaemonSet) (string, error) {
-	md5Spec, err := GenerateMD5PodTemplateSpec(&daemonset.Spec.Template)
+	md5Spec, err := GenerateMD5ReplicaSetSpec(daemonset)
 	if err != nil {
 		return "", fmt.Errorf("unable to generates the JobSpec MD5, %w", err)
 	}
@@ -81,6 +109,22 @@ func StringsContains(a []string, x string) bool {
 // GenerateHashFromEDSResourceNodeAnnotation is used to generate the MD5 hash from EDS Node annotations that allow a user
 // to overwrites the containers resources specification for a specific Node.
 func GenerateHashFromEDSResourceNodeAnnotation(edsNamespace, edsName string, nodeAnnotations map[string]string) string {
+	return generateMD5Strings(edsResourceNodeAnnotations(edsNamespace, edsName, nodeAnnotations))
+}
+
+// GenerateNodeHash is used to generate the MD5 hash of the Node information used to create a pod: the EDS Node annotations
+// that overwrite the containers resources, and the Node labels and annotations copied into the pod with nodeMetadata.
+func GenerateNodeHash(edsNamespace, edsName string, nodeMetadata []datadoghqv1alpha1.ExtendedDaemonSetNodeMetadata, node *corev1.Node) string {
+	values := edsResourceNodeAnnotations(edsNamespace, edsName, node.GetAnnotations())
+	for id := range nodeMetadata {
+		value, _ := datadoghqv1alpha1.GetNodeMetadataValue(&nodeMetadata[id], node.GetLabels(), node.GetAnnotations())
+		values = append(values, fmt.Sprintf("nodeMetadata:%s%s=%s", nodeMetadata[id].NodeLabel, nodeMetadata[id].NodeAnnotation, value))
+	}
+
+	return generateMD5Strings(values)
+}
+
+func edsResourceNodeAnnotations(edsNamespace, edsName string, nodeAnnotations map[string]string) []string {
 	// build prefix for this specific eds
 	prefixKey := fmt.Sprintf(datadoghqv1alpha1.ExtendedDaemonSetRessourceNodeAnnotationKey, edsNamespace, edsName, "")
 
@@ -90,14 +134,19 @@ func GenerateHashFromEDSResourceNodeAnnotation(edsNamespace, edsName string, nod
 			resourcesAnnotations = append(resourcesAnnotations, fmt.Sprintf("%s=%s", key, value))
 		}
 	}
-	if len(resourcesAnnotations) == 0 {
-		// no annotation == no hash
+
+	return resourcesAnnotations
+}
+
+func generateMD5Strings(values []string) string {
+	if len(values) == 0 {
+		// no value == no hash
 		return ""
 	}
-	sort.Strings(resourcesAnnotations)
+	sort.Strings(values)
 	/* #nosec */
 	hash := md5.New()
-	for _, val := range resourcesAnnotations {
+	for _, val := range values {
 		_, _ = hash.Write([]byte(val))
 	}
 
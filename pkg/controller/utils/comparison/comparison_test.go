@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
@@ -76,6 +77,43 @@ func TestGenerateHashFromEDSResourceNodeAnnotation(t *testing.T) {
 	}
 }
 
+func TestGenerateNodeHash(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "us-east-1a"},
+			Annotations: map[string]string{
+				"resources.extendeddaemonset.datadoghq.com/bar.foo.daemons": "{\"limits\":{\"cpu\": \"1\"}}",
+			},
+		},
+	}
+	nodeMetadata := []datadoghqv1alpha1.ExtendedDaemonSetNodeMetadata{{NodeLabel: "topology.kubernetes.io/zone", Env: "ZONE"}}
+
+	// without nodeMetadata, the hash only depends on the resources annotations
+	assert.Equal(t, "bc9eacb89b7a44531492e87a37922dc3", GenerateNodeHash("bar", "foo", nil, node))
+
+	hash := GenerateNodeHash("bar", "foo", nodeMetadata, node)
+	assert.NotEqual(t, "bc9eacb89b7a44531492e87a37922dc3", hash)
+
+	node.Labels["topology.kubernetes.io/zone"] = "us-east-1b"
+	assert.NotEqual(t, hash, GenerateNodeHash("bar", "foo", nodeMetadata, node), "the hash should change with the node label value")
+}
+
+func TestGenerateMD5ReplicaSetSpec(t *testing.T) {
+	ds := &datadoghqv1alpha1.ExtendedDaemonSet{}
+	ds = datadoghqv1alpha1.DefaultExtendedDaemonSet(ds, datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationModeAuto)
+
+	// without nodeMetadata, the hash is the pod template hash
+	got, err := GenerateMD5ReplicaSetSpec(ds)
+	require.NoError(t, err)
+	assert.Equal(t, "a2bb34618483323482d9a56ae2515eed", got)
+
+	ds.Spec.NodeMetadata = []datadoghqv1alpha1.ExtendedDaemonSetNodeMetadata{{NodeLabel: "topology.kubernetes.io/zone", Env: "ZONE"}}
+	got, err = GenerateMD5ReplicaSetSpec(ds)
+	require.NoError(t, err)
+	assert.NotEqual(t, "a2bb34618483323482d9a56ae2515eed", got)
+}
+
 func TestGenerateMD5PodTemplateSpec(t *testing.T) {
 	ds := &datadoghqv1alpha1.ExtendedDaemonSet{}
 	ds = datadoghqv1alpha1.DefaultExtendedDaemonSet(ds, datadoghqv1alpha1.ExtendedDaemonSetSpecStrategyCanaryValidationModeAuto)
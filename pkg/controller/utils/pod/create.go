@@ -12,6 +12,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
@@ -19,6 +21,8 @@ import (
 	"github.com/DataDog/extendeddaemonset/pkg/controller/utils/comparison"
 )
 
+var log = ctrl.Log.WithName("pod")
+
 // CreatePodFromDaemonSetReplicaSet use to create a Pod from a ReplicaSet instance and a specific Node name.
 func CreatePodFromDaemonSetReplicaSet(scheme *runtime.Scheme, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, node *corev1.Node, edsNode *datadoghqv1alpha1.ExtendedDaemonsetSetting, addNodeAffinity bool) (*corev1.Pod, error) {
 	var err error
@@ -47,7 +51,8 @@ func CreatePodFromDaemonSetReplicaSet(scheme *runtime.Scheme, replicaset *datado
 
 	if node != nil {
 		err = overwriteResourcesFromNode(templateCopy, replicaset.Namespace, edsName, node)
-		hash := comparison.GenerateHashFromEDSResourceNodeAnnotation(replicaset.Namespace, edsName, node.Annotations)
+		injectNodeMetadata(templateCopy, replicaset.Spec.NodeMetadata, node)
+		hash := comparison.GenerateNodeHash(replicaset.Namespace, edsName, replicaset.Spec.NodeMetadata, node)
 		if hash != "" {
 			templateCopy.Annotations[datadoghqv1alpha1.MD5NodeExtendedDaemonSetAnnotationKey] = hash
 		}
@@ -93,6 +98,51 @@ func overwriteResourcesFromEdsNode(template *corev1.PodTemplateSpec, edsNode *da
 	template.Labels[datadoghqv1alpha1.ExtendedDaemonSetSettingNamespaceLabelKey] = edsNode.GetNamespace()
 }
 
+// injectNodeMetadata copies the node labels and annotations listed in nodeMetadata into the pod labels, annotations
+// and containers environment variables. The values missing on the node are skipped, like the values that are not
+// valid label values for a pod label, since they would fail the pod creation.
+func injectNodeMetadata(template *corev1.PodTemplateSpec, nodeMetadata []datadoghqv1alpha1.ExtendedDaemonSetNodeMetadata, node *corev1.Node) {
+	for id := range nodeMetadata {
+		metadata := &nodeMetadata[id]
+		value, found := datadoghqv1alpha1.GetNodeMetadataValue(metadata, node.GetLabels(), node.GetAnnotations())
+		if !found {
+			continue
+		}
+
+		if metadata.PodLabel != "" {
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				log.Info("Node metadata value skipped, invalid label value", "node", node.Name, "podLabel", metadata.PodLabel, "errors", errs)
+			} else {
+				template.Labels[metadata.PodLabel] = value
+			}
+		}
+		if metadata.PodAnnotation != "" {
+			template.Annotations[metadata.PodAnnotation] = value
+		}
+		if metadata.Env != "" {
+			envVar := corev1.EnvVar{Name: metadata.Env, Value: value}
+			for cID := range template.Spec.InitContainers {
+				template.Spec.InitContainers[cID].Env = setEnvVar(template.Spec.InitContainers[cID].Env, envVar)
+			}
+			for cID := range template.Spec.Containers {
+				template.Spec.Containers[cID].Env = setEnvVar(template.Spec.Containers[cID].Env, envVar)
+			}
+		}
+	}
+}
+
+func setEnvVar(envVars []corev1.EnvVar, envVar corev1.EnvVar) []corev1.EnvVar {
+	for id := range envVars {
+		if envVars[id].Name == envVar.Name {
+			envVars[id] = envVar
+
+			return envVars
+		}
+	}
+
+	return append(envVars, envVar)
+}
+
 func overwriteResourcesFromNode(template *corev1.PodTemplateSpec, edsNamespace, edsName string, node *corev1.Node) error {
 	if node == nil {
 		return nil
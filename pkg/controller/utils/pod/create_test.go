@@ -177,3 +177,36 @@ func Test_overwriteResourcesFromEdsNode(t *testing.T) {
 	assert.NotEqual(t, templateCopy, templateOriginal)
 	assert.Equal(t, resourcesRef, templateOriginal.Spec.Containers[0].Resources.Requests)
 }
+
+func Test_injectNodeMetadata(t *testing.T) {
+	node := ctrltest.NewNode("node1", &ctrltest.NewNodeOptions{
+		Labels:      map[string]string{"topology.kubernetes.io/zone": "us-east-1a"},
+		Annotations: map[string]string{"example.com/rack": "r42", "example.com/description": "rack 42, row 3"},
+	})
+	nodeMetadata := []datadoghqv1alpha1.ExtendedDaemonSetNodeMetadata{
+		{NodeLabel: "topology.kubernetes.io/zone", PodLabel: "zone", Env: "ZONE"},
+		{NodeAnnotation: "example.com/rack", PodAnnotation: "example.com/rack"},
+		{NodeLabel: "missing", PodLabel: "missing", Env: "MISSING"},
+		{NodeAnnotation: "example.com/description", PodLabel: "description", PodAnnotation: "example.com/description"},
+	}
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: v1.ObjectMeta{
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "init"},
+			},
+			Containers: []corev1.Container{
+				{Name: "container1", Env: []corev1.EnvVar{{Name: "ZONE", Value: "default"}, {Name: "FOO", Value: "bar"}}},
+			},
+		},
+	}
+
+	injectNodeMetadata(template, nodeMetadata, node)
+	assert.Equal(t, map[string]string{"zone": "us-east-1a"}, template.Labels)
+	assert.Equal(t, map[string]string{"example.com/rack": "r42", "example.com/description": "rack 42, row 3"}, template.Annotations)
+	assert.Equal(t, []corev1.EnvVar{{Name: "ZONE", Value: "us-east-1a"}}, template.Spec.InitContainers[0].Env)
+	assert.Equal(t, []corev1.EnvVar{{Name: "ZONE", Value: "us-east-1a"}, {Name: "FOO", Value: "bar"}}, template.Spec.Containers[0].Env)
+}
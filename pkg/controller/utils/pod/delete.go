@@ -0,0 +1,38 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package pod
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeletePod deletes the pod, or evicts it with the Eviction API if useEviction is true, to respect the PodDisruptionBudgets.
+func DeletePod(c client.Client, pod *corev1.Pod, useEviction bool) error {
+	if !useEviction {
+		return c.Delete(context.TODO(), pod)
+	}
+
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+	}
+
+	return c.SubResource("eviction").Create(context.TODO(), pod, eviction)
+}
+
+// IsEvictionBlocked returns true if the pod eviction was refused because it would violate a PodDisruptionBudget.
+// The eviction should be retried later.
+func IsEvictionBlocked(err error) bool {
+	return apierrors.IsTooManyRequests(err)
+}
@@ -63,6 +63,20 @@ func IsPodReady(pod *v1.Pod) bool {
 	return IsPodReadyConditionTrue(pod.Status)
 }
 
+// IsPodAvailable returns true if a pod is ready for at least minReadySeconds; false otherwise.
+func IsPodAvailable(pod *v1.Pod, minReadySeconds int32, now time.Time) bool {
+	condition := GetPodReadyCondition(pod.Status)
+	if condition == nil || condition.Status != v1.ConditionTrue {
+		return false
+	}
+	if minReadySeconds == 0 {
+		return true
+	}
+	minReadySecondsDuration := time.Duration(minReadySeconds) * time.Second
+
+	return !condition.LastTransitionTime.IsZero() && condition.LastTransitionTime.Add(minReadySecondsDuration).Before(now)
+}
+
 // IsPodReadyConditionTrue returns true if a pod is ready; false otherwise.
 func IsPodReadyConditionTrue(status v1.PodStatus) bool {
 	condition := GetPodReadyCondition(status)
@@ -186,6 +200,17 @@ func CannotStart(pod *v1.Pod) (bool, datadoghqv1alpha1.ExtendedDaemonSetStatusRe
 	return false, datadoghqv1alpha1.ExtendedDaemonSetStatusReasonUnknown
 }
 
+// CrashLooping returns true if a container of the Pod is waiting in CrashLoopBackOff.
+func CrashLooping(pod *v1.Pod) bool {
+	for _, s := range containerStatusList(pod) {
+		if s.State.Waiting != nil && s.State.Waiting.Reason == string(datadoghqv1alpha1.ExtendedDaemonSetStatusReasonCLB) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func convertReasonToEDSStatusReason(reason string) datadoghqv1alpha1.ExtendedDaemonSetStatusReason {
 	t := datadoghqv1alpha1.ExtendedDaemonSetStatusReason(reason)
 	switch t {
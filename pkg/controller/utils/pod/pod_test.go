@@ -217,6 +217,63 @@ func TestIsPodReady(t *testing.T) {
 	assert.True(t, isReady)
 }
 
+func TestIsPodAvailable(t *testing.T) {
+	now := time.Now()
+	newPod := func(status v1.ConditionStatus, lastTransitionTime time.Time) *v1.Pod {
+		pod := ctrltest.NewPod("bar", "pod1", "node1", &ctrltest.NewPodOptions{})
+		pod.Status.Conditions = []v1.PodCondition{
+			{
+				Type:               v1.PodReady,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(lastTransitionTime),
+			},
+		}
+
+		return pod
+	}
+
+	tests := []struct {
+		name            string
+		pod             *v1.Pod
+		minReadySeconds int32
+		want            bool
+	}{
+		{
+			name: "no ready condition",
+			pod:  ctrltest.NewPod("bar", "pod1", "node1", &ctrltest.NewPodOptions{}),
+			want: false,
+		},
+		{
+			name:            "not ready",
+			pod:             newPod(v1.ConditionFalse, now.Add(-time.Hour)),
+			minReadySeconds: 10,
+			want:            false,
+		},
+		{
+			name: "ready, no minReadySeconds",
+			pod:  newPod(v1.ConditionTrue, now),
+			want: true,
+		},
+		{
+			name:            "ready for less than minReadySeconds",
+			pod:             newPod(v1.ConditionTrue, now.Add(-5*time.Second)),
+			minReadySeconds: 10,
+			want:            false,
+		},
+		{
+			name:            "ready for more than minReadySeconds",
+			pod:             newPod(v1.ConditionTrue, now.Add(-11*time.Second)),
+			minReadySeconds: 10,
+			want:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPodAvailable(tt.pod, tt.minReadySeconds, now))
+		})
+	}
+}
+
 func TestIsCannotStartReason(t *testing.T) {
 	for reason := range cannotStartReasons {
 		cannotStart := IsCannotStartReason(reason)
@@ -256,6 +313,24 @@ func TestCannotStart(t *testing.T) {
 	assert.Equal(t, datadoghqv1alpha1.ExtendedDaemonSetStatusReasonErrImagePull, reason)
 }
 
+func TestCrashLooping(t *testing.T) {
+	now := metav1.Now()
+	pod := newPod(now, true, 5)
+	assert.False(t, CrashLooping(pod))
+
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{
+		{
+			RestartCount: 10,
+			State: v1.ContainerState{
+				Waiting: &v1.ContainerStateWaiting{
+					Reason: "CrashLoopBackOff",
+				},
+			},
+		},
+	}
+	assert.True(t, CrashLooping(pod))
+}
+
 func TestPendingCreate(t *testing.T) {
 	now := metav1.Now()
 	pod := newPod(now, true, 5)
@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// CreatePrePullPodFromDaemonSetReplicaSet use to create a Pod that pulls the images of the ReplicaSet pod template on a specific Node.
+// The pre-pull pod containers don't run the images entrypoint: they only run `true`, and they are not restarted. The container
+// start can fail if the image doesn't contain a `true` binary, which doesn't matter since the image is already pulled at this point.
+func CreatePrePullPodFromDaemonSetReplicaSet(scheme *runtime.Scheme, replicaset *datadoghqv1alpha1.ExtendedDaemonSetReplicaSet, node *corev1.Node) (*corev1.Pod, error) {
+	template := &replicaset.Spec.Template
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    replicaset.Namespace,
+			GenerateName: replicaset.Name + "-pre-pull-",
+			Labels: map[string]string{
+				datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPrePullLabelKey: replicaset.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:                     node.Name,
+			RestartPolicy:                corev1.RestartPolicyNever,
+			ServiceAccountName:           template.Spec.ServiceAccountName,
+			AutomountServiceAccountToken: datadoghqv1alpha1.NewBool(false),
+			ImagePullSecrets:             template.Spec.ImagePullSecrets,
+			PriorityClassName:            template.Spec.PriorityClassName,
+			Tolerations:                  append(append([]corev1.Toleration{}, template.Spec.Tolerations...), StandardDaemonSetTolerations...),
+		},
+	}
+
+	images := map[string]bool{}
+	for _, container := range append(append([]corev1.Container{}, template.Spec.InitContainers...), template.Spec.Containers...) {
+		if images[container.Image] {
+			continue
+		}
+		images[container.Image] = true
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name:            fmt.Sprintf("pre-pull-%d", len(pod.Spec.Containers)),
+			Image:           container.Image,
+			ImagePullPolicy: container.ImagePullPolicy,
+			Command:         []string{"true"},
+		})
+	}
+
+	var err error
+	if scheme != nil {
+		err = controllerutil.SetControllerReference(replicaset, pod, scheme)
+	}
+
+	return pod, err
+}
+
+// IsPrePullCompleted returns true if all the images of the pre-pull pod are pulled on its node,
+// meaning that all its containers are started or terminated.
+func IsPrePullCompleted(pod *corev1.Pod) bool {
+	if len(pod.Status.ContainerStatuses) < len(pod.Spec.Containers) {
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running == nil && status.State.Terminated == nil {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,65 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	ctrltest "github.com/DataDog/extendeddaemonset/pkg/controller/test"
+)
+
+func TestCreatePrePullPodFromDaemonSetReplicaSet(t *testing.T) {
+	replicaset := &datadoghqv1alpha1.ExtendedDaemonSetReplicaSet{
+		ObjectMeta: v1.ObjectMeta{Namespace: "bar", Name: "foo-1"},
+		Spec: datadoghqv1alpha1.ExtendedDaemonSetReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init", Image: "agent:7.1"}},
+					Containers: []corev1.Container{
+						{Name: "agent", Image: "agent:7.1", Command: []string{"agent", "run"}},
+						{Name: "trace-agent", Image: "trace-agent:7.1", ImagePullPolicy: corev1.PullAlways},
+					},
+					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+				},
+			},
+		},
+	}
+
+	pod, err := CreatePrePullPodFromDaemonSetReplicaSet(nil, replicaset, ctrltest.NewNode("node1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "bar", pod.Namespace)
+	assert.Equal(t, map[string]string{datadoghqv1alpha1.ExtendedDaemonSetReplicaSetPrePullLabelKey: "foo-1"}, pod.Labels, "the pod template labels should not be copied")
+	assert.Equal(t, "node1", pod.Spec.NodeName)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	assert.Len(t, pod.Spec.Tolerations, 1+len(StandardDaemonSetTolerations))
+	assert.Equal(t, []corev1.Container{
+		{Name: "pre-pull-0", Image: "agent:7.1", Command: []string{"true"}},
+		{Name: "pre-pull-1", Image: "trace-agent:7.1", ImagePullPolicy: corev1.PullAlways, Command: []string{"true"}},
+	}, pod.Spec.Containers)
+}
+
+func TestIsPrePullCompleted(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "pre-pull-0"}, {Name: "pre-pull-1"}}},
+	}
+	assert.False(t, IsPrePullCompleted(pod), "no container status yet")
+
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "pre-pull-0", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+		{Name: "pre-pull-1", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+	}
+	assert.False(t, IsPrePullCompleted(pod), "one image is still pulling")
+
+	pod.Status.ContainerStatuses[1].State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 128, Reason: "StartError"}}
+	assert.True(t, IsPrePullCompleted(pod))
+}
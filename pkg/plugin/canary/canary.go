@@ -17,6 +17,7 @@ func NewCmdCanary(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd.AddCommand(newCmdUnpause(streams))
 	cmd.AddCommand(newCmdFail(streams))
 	cmd.AddCommand(newCmdPods(streams))
+	cmd.AddCommand(newCmdNodes(streams))
 
 	return cmd
 }
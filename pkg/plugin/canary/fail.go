@@ -11,14 +11,11 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
-	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
 )
 
 const (
@@ -124,48 +121,12 @@ func (o *failOptions) validate() error {
 
 // run use to run the command.
 func (o *failOptions) run() error {
-	eds := &v1alpha1.ExtendedDaemonSet{}
-	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
-	if err != nil && apierrors.IsNotFound(err) {
-		return fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
-	} else if err != nil {
-		return fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
-	}
-
-	if eds.Spec.Strategy.Canary == nil {
-		return errors.New("the ExtendedDaemonset does not have a canary strategy")
-	}
-	if eds.Status.Canary == nil {
-		return errors.New("the ExtendedDaemonset does not have an active canary deployment")
-	}
-
-	// Get Canary ERS
-	canaryERSName := eds.Status.Canary.ReplicaSet
-	canaryERS := &v1alpha1.ExtendedDaemonSetReplicaSet{}
-	err = o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: canaryERSName}, canaryERS)
-	if err != nil && apierrors.IsNotFound(err) {
-		return fmt.Errorf("ERS %s/%s not found", o.userNamespace, canaryERSName)
-	} else if err != nil {
-		return fmt.Errorf("unable to get ERS, err: %w", err)
-	}
-
-	newCanaryERS := canaryERS.DeepCopy()
-
-	newCanaryERS.Status.Conditions = append(
-		newCanaryERS.Status.Conditions,
-		conditions.NewExtendedDaemonSetReplicaSetCondition(
-			v1alpha1.ConditionTypeCanaryFailed,
-			conditions.BoolToCondition(true),
-			metav1.Now(),
-			"Manually failed",
-			"",
-			true),
-	)
-	if err = o.client.Status().Update(context.TODO(), newCanaryERS); err != nil {
-		return fmt.Errorf("unable to update ERS status, err: %w", err)
+	rsName, err := rollout.FailCanary(context.TODO(), o.client, client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName})
+	if err != nil {
+		return err
 	}
 
-	fmt.Fprintf(o.Out, "ExtendedDaemonSetReplicaSet '%s/%s' canary deployment set to failed\n", o.userNamespace, o.userExtendedDaemonSetName)
+	fmt.Fprintf(o.Out, "ExtendedDaemonSetReplicaSet '%s/%s' canary deployment set to failed\n", o.userNamespace, rsName)
 
 	return nil
 }
@@ -0,0 +1,155 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
+)
+
+type nodesAction string
+
+const (
+	cmdAddNodes     nodesAction = "add"
+	cmdExcludeNodes nodesAction = "exclude"
+	cmdRemoveNodes  nodesAction = "remove"
+)
+
+var nodesExample = `
+	# always select the nodes node-1 and node-2 for the canary deployments
+	kubectl eds canary nodes add node-1 node-2
+
+	# never select the node node-3 for the canary deployments
+	kubectl eds canary nodes exclude node-3
+
+	# select the nodes node-1 and node-3 like any other node again
+	kubectl eds canary nodes remove node-1 node-3
+`
+
+// nodesOptions provides information required to manage the canary nodes.
+type nodesOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	args        []string
+
+	client client.Client
+
+	genericclioptions.IOStreams
+
+	action nodesAction
+}
+
+// newNodesOptions provides an instance of nodesOptions with default values.
+func newNodesOptions(streams genericclioptions.IOStreams, action nodesAction) *nodesOptions {
+	return &nodesOptions{
+		configFlags: genericclioptions.NewConfigFlags(false),
+
+		IOStreams: streams,
+
+		action: action,
+	}
+}
+
+// newCmdNodes provides a cobra command to manage the nodes opted in or out of the canary deployments.
+func newCmdNodes(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "nodes [subcommand] [flags]",
+		Short:   "manage the nodes always or never selected for the canary deployments",
+		Example: nodesExample,
+	}
+
+	cmd.AddCommand(newCmdNodesAction(streams, cmdAddNodes, "always select the nodes for the canary deployments"))
+	cmd.AddCommand(newCmdNodesAction(streams, cmdExcludeNodes, "never select the nodes for the canary deployments"))
+	cmd.AddCommand(newCmdNodesAction(streams, cmdRemoveNodes, "select the nodes for the canary deployments like any other node"))
+
+	return cmd
+}
+
+// newCmdNodesAction provides a cobra command wrapping nodesOptions.
+func newCmdNodesAction(streams genericclioptions.IOStreams, action nodesAction, short string) *cobra.Command {
+	o := newNodesOptions(streams, action)
+
+	cmd := &cobra.Command{
+		Use:          string(action) + " [node name]...",
+		Short:        short,
+		Example:      nodesExample,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *nodesOptions) complete(_ *cobra.Command, args []string) error {
+	o.args = args
+	var err error
+
+	clientConfig := o.configFlags.ToRawKubeConfigLoader()
+	// Create the Client for Read/Write operations.
+	o.client, err = common.NewClient(clientConfig)
+	if err != nil {
+		return fmt.Errorf("unable to instantiate client, err: %w", err)
+	}
+
+	return nil
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *nodesOptions) validate() error {
+	if len(o.args) < 1 {
+		return errors.New("at least one node name is required")
+	}
+
+	return nil
+}
+
+// run use to run the command.
+func (o *nodesOptions) run() error {
+	for _, nodeName := range o.args {
+		var err error
+		var msg string
+		switch o.action {
+		case cmdAddNodes:
+			err = rollout.AddCanaryNode(context.TODO(), o.client, nodeName)
+			msg = "Node '%s' added to the canary deployments\n"
+		case cmdExcludeNodes:
+			err = rollout.ExcludeCanaryNode(context.TODO(), o.client, nodeName)
+			msg = "Node '%s' excluded from the canary deployments\n"
+		case cmdRemoveNodes:
+			err = rollout.RemoveCanaryNode(context.TODO(), o.client, nodeName)
+			msg = "Node '%s' selected for the canary deployments like any other node\n"
+		}
+		switch {
+		case rollout.IsPreconditionError(err):
+			fmt.Fprintf(o.Out, "%v\n", err)
+		case err != nil:
+			return err
+		default:
+			fmt.Fprintf(o.Out, msg, nodeName)
+		}
+	}
+
+	return nil
+}
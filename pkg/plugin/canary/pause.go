@@ -9,15 +9,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
 )
 
 const (
@@ -150,46 +148,15 @@ func (o *pauseOptions) validate() error {
 
 // run use to run the command.
 func (o *pauseOptions) run() error {
-	eds := &v1alpha1.ExtendedDaemonSet{}
-	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
-	if err != nil && apierrors.IsNotFound(err) {
-		return fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
-	} else if err != nil {
-		return fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
-	}
-
-	if eds.Spec.Strategy.Canary == nil {
-		return errors.New("the ExtendedDaemonset does not have a canary strategy")
-	}
-	if eds.Status.Canary == nil {
-		return errors.New("the ExtendedDaemonset does not have an active canary deployment")
-	}
-
-	newEds := eds.DeepCopy()
-
-	// TODO: update pause action to be less dependent on annotations as in the fail action
-	if newEds.Annotations == nil {
-		newEds.Annotations = make(map[string]string)
-	} else if isPaused, ok := newEds.Annotations[v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey]; ok {
-		if o.pauseStatus && isPaused == v1alpha1.ValueStringTrue {
-			return errors.New("canary deployment already paused")
-		} else if !o.pauseStatus && isPaused == v1alpha1.ValueStringFalse {
-			return errors.New("canary deployment is not paused; cannot unpause")
-		}
-	}
-	// Set appropriate annotation depending on whether cmd is to pause or unpause
+	key := client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}
+	var err error
 	if o.pauseStatus {
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey] = v1alpha1.ValueStringTrue
-		// Set to false in case it was previously true
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey] = v1alpha1.ValueStringFalse
+		err = rollout.PauseCanary(context.TODO(), o.client, key)
 	} else {
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey] = v1alpha1.ValueStringFalse
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey] = v1alpha1.ValueStringTrue
+		err = rollout.ResumeCanary(context.TODO(), o.client, key)
 	}
-
-	patch := client.MergeFrom(eds)
-	if err = o.client.Patch(context.TODO(), newEds, patch); err != nil {
-		return fmt.Errorf("unable to %s ExtendedDaemonset deployment, err: %w", strconv.FormatBool(o.pauseStatus), err)
+	if err != nil {
+		return err
 	}
 
 	fmt.Fprintf(o.Out, "ExtendedDaemonset '%s/%s' deployment paused set to %t\n", o.userNamespace, o.userExtendedDaemonSetName, o.pauseStatus)
@@ -11,12 +11,11 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
 )
 
 var validateExample = `
@@ -115,32 +114,9 @@ func (o *validateOptions) validate() error {
 
 // run use to run the command.
 func (o *validateOptions) run() error {
-	eds := &v1alpha1.ExtendedDaemonSet{}
-	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
-	if err != nil && apierrors.IsNotFound(err) {
-		return fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
-	} else if err != nil {
-		return fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
-	}
-
-	if eds.Status.Canary == nil {
-		return errors.New("the ExtendedDaemonset is not currently running a canary replicaset")
-	}
-	rsName := eds.Status.Canary.ReplicaSet
-	newEds := eds.DeepCopy()
-	if newEds.Annotations == nil {
-		newEds.Annotations = make(map[string]string)
-	}
-
-	if name, ok := newEds.Annotations[v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey]; ok {
-		if name == rsName {
-			return fmt.Errorf("canary replicaset '%s' already validated", name)
-		}
-	}
-	newEds.Annotations[v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey] = rsName
-	patch := client.MergeFrom(eds)
-	if err = o.client.Patch(context.TODO(), newEds, patch); err != nil {
-		return fmt.Errorf("unable to validate the canary replicaset, err: %w", err)
+	rsName, err := rollout.ValidateCanary(context.TODO(), o.client, client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName})
+	if err != nil {
+		return err
 	}
 
 	fmt.Fprintf(o.Out, "Canary replicaset '%s' was validated properly for extendeddaemonset %s/%s.\n", rsName, o.userNamespace, o.userExtendedDaemonSetName)
@@ -0,0 +1,262 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2020 Datadog, Inc.
+
+package explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/scheduler"
+	podutils "github.com/DataDog/extendeddaemonset/pkg/controller/utils/pod"
+	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+)
+
+var explainExample = `
+	# explain why the node node-1 has no pod of the ExtendedDaemonSet foo
+	%[1]s explain foo --node node-1
+`
+
+const (
+	roleActive = "active"
+	roleCanary = "canary"
+)
+
+// explainOptions provides information required to manage ExtendedDaemonSet.
+type explainOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	args        []string
+	client      client.Client
+	genericclioptions.IOStreams
+	userNamespace             string
+	userExtendedDaemonSetName string
+	nodeName                  string
+}
+
+// newExplainOptions provides an instance of explainOptions with default values.
+func newExplainOptions(streams genericclioptions.IOStreams) *explainOptions {
+	return &explainOptions{
+		configFlags: genericclioptions.NewConfigFlags(false),
+		IOStreams:   streams,
+	}
+}
+
+// NewCmdExplain provides a cobra command wrapping explainOptions.
+func NewCmdExplain(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newExplainOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "explain [ExtendedDaemonSet name] --node [Node name]",
+		Short:        "explain why a node has or doesn't have a pod of the ExtendedDaemonSet",
+		Example:      fmt.Sprintf(explainExample, "kubectl eds"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.nodeName, "node", "", "", "Name of the node to explain")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *explainOptions) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	var err error
+
+	clientConfig := o.configFlags.ToRawKubeConfigLoader()
+	// Create the Client for Read/Write operations.
+	o.client, err = common.NewClient(clientConfig)
+	if err != nil {
+		return fmt.Errorf("unable to instantiate client, err: %w", err)
+	}
+
+	o.userNamespace, _, err = clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	ns, err2 := cmd.Flags().GetString("namespace")
+	if err2 != nil {
+		return err2
+	}
+	if ns != "" {
+		o.userNamespace = ns
+	}
+
+	if len(args) > 0 {
+		o.userExtendedDaemonSetName = args[0]
+	}
+
+	return nil
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *explainOptions) validate() error {
+	if len(o.args) < 1 {
+		return errors.New("the extendeddaemonset name is required")
+	}
+
+	if o.nodeName == "" {
+		return errors.New("the --node flag is required")
+	}
+
+	return nil
+}
+
+// run use to run the command.
+func (o *explainOptions) run() error {
+	eds := &v1alpha1.ExtendedDaemonSet{}
+	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
+	if err != nil && apierrors.IsNotFound(err) {
+		return fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
+	} else if err != nil {
+		return fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
+	}
+
+	node := &corev1.Node{}
+	err = o.client.Get(context.TODO(), client.ObjectKey{Name: o.nodeName}, node)
+	if err != nil && apierrors.IsNotFound(err) {
+		return fmt.Errorf("node %s not found", o.nodeName)
+	} else if err != nil {
+		return fmt.Errorf("unable to get node, err: %w", err)
+	}
+
+	if eds.Status.ActiveReplicaSet == "" {
+		return fmt.Errorf("the ExtendedDaemonset %s/%s does not have an active replicaset", o.userNamespace, o.userExtendedDaemonSetName)
+	}
+
+	if err = o.explainReplicaSet(eds, eds.Status.ActiveReplicaSet, roleActive, node); err != nil {
+		return err
+	}
+
+	if eds.Status.Canary != nil {
+		return o.explainReplicaSet(eds, eds.Status.Canary.ReplicaSet, roleCanary, node)
+	}
+
+	return nil
+}
+
+func (o *explainOptions) explainReplicaSet(eds *v1alpha1.ExtendedDaemonSet, ersName, role string, node *corev1.Node) error {
+	ers := &v1alpha1.ExtendedDaemonSetReplicaSet{}
+	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: ersName}, ers)
+	if err != nil {
+		return fmt.Errorf("unable to get extendedreplicaset %s, err: %w", ersName, err)
+	}
+
+	fmt.Fprintf(o.Out, "ExtendedDaemonSetReplicaSet %s (%s):\n", ers.Name, role)
+
+	pod, err := o.getPodOnNode(ers, node.Name)
+	if err != nil {
+		return err
+	}
+	if pod != nil {
+		fmt.Fprintf(o.Out, "  pod %s is present on the node, phase: %s\n", pod.Name, pod.Status.Phase)
+
+		return nil
+	}
+
+	if eds.Status.Canary != nil {
+		isCanaryNode := slices.Contains(eds.Status.Canary.Nodes, node.Name)
+		if role == roleCanary && !isCanaryNode {
+			fmt.Fprintf(o.Out, "  node is not selected as a canary node\n")
+
+			return nil
+		}
+		if role == roleActive && isCanaryNode {
+			fmt.Fprintf(o.Out, "  node is used by the canary replicaset\n")
+
+			return nil
+		}
+	}
+
+	if ers.Spec.Selector != nil {
+		selector, err2 := metav1.LabelSelectorAsSelector(ers.Spec.Selector)
+		if err2 != nil {
+			return fmt.Errorf("unable to parse the replicaset selector, err: %w", err2)
+		}
+		if !selector.Matches(labels.Set(node.Labels)) {
+			fmt.Fprintf(o.Out, "  node labels don't match the replicaset selector: %s\n", selector.String())
+
+			return nil
+		}
+	}
+
+	// Reasons reported by the controller are only available for a sample of the nodes,
+	// otherwise evaluate the scheduling predicates against the replicaset pod template.
+	for _, unfitNode := range ers.Status.UnfitNodes {
+		if unfitNode.Node != node.Name {
+			continue
+		}
+
+		fmt.Fprintf(o.Out, "  node doesn't fit the pod template (reported by the controller):\n")
+		for _, reason := range unfitNode.Reasons {
+			fmt.Fprintf(o.Out, "    - %s: %s\n", reason.Predicate, reason.Message)
+		}
+
+		return nil
+	}
+
+	newPod, err := podutils.CreatePodFromDaemonSetReplicaSet(nil, ers, nil, nil, false)
+	if err != nil {
+		return fmt.Errorf("unable to generate a pod from the replicaset, err: %w", err)
+	}
+
+	fitness := scheduler.CheckNodeFitness(logr.Discard(), newPod, node)
+	if fitness.Fit() {
+		fmt.Fprintf(o.Out, "  node fits the pod template, the pod is not created yet\n")
+
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, "  node doesn't fit the pod template:\n")
+	for _, failure := range fitness.Failures {
+		fmt.Fprintf(o.Out, "    - %s\n", failure.String())
+	}
+
+	return nil
+}
+
+// getPodOnNode returns the replicaset pod associated to the node, or nil if there is none.
+func (o *explainOptions) getPodOnNode(ers *v1alpha1.ExtendedDaemonSetReplicaSet, nodeName string) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	err := o.client.List(context.TODO(), podList, client.InNamespace(ers.Namespace), client.MatchingLabels{v1alpha1.ExtendedDaemonSetReplicaSetNameLabelKey: ers.Name})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods, err: %w", err)
+	}
+
+	for id := range podList.Items {
+		podNodeName, err := podutils.GetNodeNameFromPod(&podList.Items[id])
+		if err != nil {
+			continue
+		}
+		if podNodeName == nodeName {
+			return &podList.Items[id], nil
+		}
+	}
+
+	return nil, nil
+}
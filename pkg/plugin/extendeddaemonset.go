@@ -12,10 +12,13 @@ import (
 
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/canary"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/diff"
+	"github.com/DataDog/extendeddaemonset/pkg/plugin/explain"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/freeze"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/get"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/pause"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/pods"
+	"github.com/DataDog/extendeddaemonset/pkg/plugin/rollout"
+	"github.com/DataDog/extendeddaemonset/pkg/plugin/simulate"
 )
 
 // ExtendedDaemonsetOptions provides information required to manage ExtendedDaemonset.
@@ -49,7 +52,10 @@ func NewCmdExtendedDaemonset(streams genericclioptions.IOStreams) *cobra.Command
 	cmd.AddCommand(pause.NewCmdUnpause(streams))
 	cmd.AddCommand(freeze.NewCmdFreeze(streams))
 	cmd.AddCommand(freeze.NewCmdUnfreeze(streams))
+	cmd.AddCommand(rollout.NewCmdRollout(streams))
 	cmd.AddCommand(diff.NewCmdDiff(streams))
+	cmd.AddCommand(explain.NewCmdExplain(streams))
+	cmd.AddCommand(simulate.NewCmdSimulate(streams))
 
 	o.configFlags.AddFlags(cmd.Flags())
 
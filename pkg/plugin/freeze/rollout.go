@@ -10,11 +10,10 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
 
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -151,48 +150,16 @@ func (o *freezeOptions) validate() error {
 
 // run used to run the command.
 func (o *freezeOptions) run() error {
-	eds := &v1alpha1.ExtendedDaemonSet{}
-	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
-	if err != nil && apierrors.IsNotFound(err) {
-		return fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
-	} else if err != nil {
-		return fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
-	}
-
-	if eds.Status.Canary != nil {
-		return errors.New("cannot freeze rollout: the ExtendedDaemonset has an active canary deployment. You should either fail or validate the canary first")
-	}
-
-	newEds := eds.DeepCopy()
-
-	if newEds.Annotations == nil {
-		newEds.Annotations = make(map[string]string)
-	}
-
-	isFrozen, found := newEds.Annotations[v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey]
-	if o.want == frozen && isFrozen == v1alpha1.ValueStringTrue {
-		// One case where freezing is impossible:
-		// - EDS is already frozen
-		return errors.New("rollout already frozen")
-	}
-	if o.want == unfrozen && (isFrozen == v1alpha1.ValueStringFalse || !found) {
-		// Two cases where unfreezing is impossible:
-		// - EDS is already unfrozen
-		// - EDS was never frozen (freeze annotation not found)
-		return errors.New("rollout is not frozen; cannot unfreeze")
-	}
-
-	// Set appropriate annotation depending on whether cmd is to freeze or unfreeze
+	key := client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}
+	var err error
 	switch o.want {
 	case frozen:
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey] = v1alpha1.ValueStringTrue
+		err = rollout.Freeze(context.TODO(), o.client, key)
 	case unfrozen:
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey] = v1alpha1.ValueStringFalse
+		err = rollout.Unfreeze(context.TODO(), o.client, key)
 	}
-
-	patch := client.MergeFrom(eds)
-	if err = o.client.Patch(context.TODO(), newEds, patch); err != nil {
-		return fmt.Errorf("unable to %s ExtendedDaemonset, err: %w", o.want, err)
+	if err != nil {
+		return err
 	}
 
 	fmt.Fprintf(o.Out, "ExtendedDaemonset '%s/%s' rollout is now %s\n", o.userNamespace, o.userExtendedDaemonSetName, o.want)
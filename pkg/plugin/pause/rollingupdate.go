@@ -10,11 +10,10 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
 	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
 
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -151,48 +150,16 @@ func (o *pauseOptions) validate() error {
 
 // run used to run the command.
 func (o *pauseOptions) run() error {
-	eds := &v1alpha1.ExtendedDaemonSet{}
-	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
-	if err != nil && apierrors.IsNotFound(err) {
-		return fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
-	} else if err != nil {
-		return fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
-	}
-
-	if eds.Status.Canary != nil {
-		return errors.New("cannot pause rolling update: the ExtendedDaemonset has an active canary deployment. You can use the canary pause command instead")
-	}
-
-	newEds := eds.DeepCopy()
-
-	if newEds.Annotations == nil {
-		newEds.Annotations = make(map[string]string)
-	}
-
-	isPaused, found := newEds.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey]
-	if o.want == paused && isPaused == v1alpha1.ValueStringTrue {
-		// One case where pausing is impossible:
-		// - EDS is already paused
-		return errors.New("rolling update already paused")
-	}
-	if o.want == unpaused && (isPaused == v1alpha1.ValueStringFalse || !found) {
-		// Two cases where unpausing is impossible:
-		// - EDS is already unpaused
-		// - EDS was never paused (pause annotation not found)
-		return errors.New("rolling update is not paused; cannot unpause")
-	}
-
-	// Set appropriate annotation depending on whether cmd is to pause or unpause
+	key := client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}
+	var err error
 	switch o.want {
 	case paused:
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] = v1alpha1.ValueStringTrue
+		err = rollout.PauseRollingUpdate(context.TODO(), o.client, key)
 	case unpaused:
-		newEds.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] = v1alpha1.ValueStringFalse
+		err = rollout.ResumeRollingUpdate(context.TODO(), o.client, key)
 	}
-
-	patch := client.MergeFrom(eds)
-	if err = o.client.Patch(context.TODO(), newEds, patch); err != nil {
-		return fmt.Errorf("unable to %s ExtendedDaemonset, err: %w", o.want, err)
+	if err != nil {
+		return err
 	}
 
 	fmt.Fprintf(o.Out, "ExtendedDaemonset '%s/%s' rolling update is now %s\n", o.userNamespace, o.userExtendedDaemonSetName, o.want)
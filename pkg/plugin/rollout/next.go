@@ -0,0 +1,125 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+	"github.com/DataDog/extendeddaemonset/pkg/rollout"
+)
+
+var nextExample = `
+	# approve the next batch of a rolling update with manual batch approval
+	kubectl eds rollout next foo
+`
+
+// nextOptions provides information required to manage ExtendedDaemonSet.
+type nextOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	args        []string
+
+	client client.Client
+
+	genericclioptions.IOStreams
+
+	userNamespace             string
+	userExtendedDaemonSetName string
+}
+
+// newNextOptions provides an instance of nextOptions with default values.
+func newNextOptions(streams genericclioptions.IOStreams) *nextOptions {
+	return &nextOptions{
+		configFlags: genericclioptions.NewConfigFlags(false),
+
+		IOStreams: streams,
+	}
+}
+
+// newCmdNext provides a cobra command wrapping nextOptions.
+func newCmdNext(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newNextOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "next [ExtendedDaemonSet name]",
+		Short:        "approve the next batch of a rolling update with manual batch approval",
+		Example:      nextExample,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *nextOptions) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	var err error
+
+	clientConfig := o.configFlags.ToRawKubeConfigLoader()
+	// Create the Client for Read/Write operations.
+	o.client, err = common.NewClient(clientConfig)
+	if err != nil {
+		return fmt.Errorf("unable to instantiate client, err: %w", err)
+	}
+
+	o.userNamespace, _, err = clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	ns, err2 := cmd.Flags().GetString("namespace")
+	if err2 != nil {
+		return err2
+	}
+	if ns != "" {
+		o.userNamespace = ns
+	}
+
+	if len(args) > 0 {
+		o.userExtendedDaemonSetName = args[0]
+	}
+
+	return nil
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *nextOptions) validate() error {
+	if len(o.args) < 1 {
+		return errors.New("the extendeddaemonset name is required")
+	}
+
+	return nil
+}
+
+// run used to run the command.
+func (o *nextOptions) run() error {
+	batch, err := rollout.ApproveNextBatch(context.TODO(), o.client, client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "ExtendedDaemonset '%s/%s' batch %d is completed, the next batch is approved\n", o.userNamespace, o.userExtendedDaemonSetName, batch)
+
+	return nil
+}
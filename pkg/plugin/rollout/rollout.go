@@ -0,0 +1,23 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package rollout
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewCmdRollout provides a cobra command to control the ExtendedDaemonSet rolling updates.
+func NewCmdRollout(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout [subcommand] [flags]",
+		Short: "control ExtendedDaemonSet rolling update",
+	}
+
+	cmd.AddCommand(newCmdNext(streams))
+
+	return cmd
+}
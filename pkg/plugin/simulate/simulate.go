@@ -0,0 +1,256 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2020 Datadog, Inc.
+
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	jy "github.com/ghodss/yaml"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/simulator"
+	"github.com/DataDog/extendeddaemonset/pkg/plugin/common"
+)
+
+var simulateExample = `
+	# simulate the rollout of the ExtendedDaemonSet foo on a 500 nodes cluster
+	%[1]s simulate foo --nodes 500
+
+	# simulate the rollout of a local ExtendedDaemonSet definition with a faster slow start
+	%[1]s simulate -f eds.yaml --nodes 500 --slow-start-additive-increase 20%%
+
+	# simulate a rollout where 10%% of the new pods keep restarting
+	%[1]s simulate foo --nodes 500 --failure-rate 0.1
+`
+
+// simulateOptions provides information required to manage ExtendedDaemonSet.
+type simulateOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	args        []string
+	client      client.Client
+	genericclioptions.IOStreams
+	userNamespace             string
+	userExtendedDaemonSetName string
+	filename                  string
+
+	nbNodes                   int
+	podStartupDuration        time.Duration
+	podTerminationDuration    time.Duration
+	failureRate               float64
+	seed                      int64
+	maxDuration               time.Duration
+	maxUnavailable            string
+	slowStartIntervalDuration time.Duration
+	slowStartAdditiveIncrease string
+}
+
+// newSimulateOptions provides an instance of simulateOptions with default values.
+func newSimulateOptions(streams genericclioptions.IOStreams) *simulateOptions {
+	return &simulateOptions{
+		configFlags:        genericclioptions.NewConfigFlags(false),
+		IOStreams:          streams,
+		nbNodes:            100,
+		podStartupDuration: 30 * time.Second,
+		maxDuration:        24 * time.Hour,
+	}
+}
+
+// NewCmdSimulate provides a cobra command wrapping simulateOptions.
+func NewCmdSimulate(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newSimulateOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:          "simulate [ExtendedDaemonSet name] [flags]",
+		Short:        "simulate the rollout of an ExtendedDaemonSet on a synthetic cluster",
+		Example:      fmt.Sprintf(simulateExample, "kubectl eds"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.filename, "filename", "f", "", "File containing the ExtendedDaemonSet definition to simulate, instead of fetching it from the cluster")
+	cmd.Flags().IntVarP(&o.nbNodes, "nodes", "", o.nbNodes, "Number of nodes of the simulated cluster")
+	cmd.Flags().DurationVarP(&o.podStartupDuration, "pod-startup-duration", "", o.podStartupDuration, "Time needed by a new pod to become ready")
+	cmd.Flags().DurationVarP(&o.podTerminationDuration, "pod-termination-duration", "", o.podTerminationDuration, "Time needed by a deleted pod to terminate")
+	cmd.Flags().Float64VarP(&o.failureRate, "failure-rate", "", o.failureRate, "Probability, between 0 and 1, that a new pod keeps restarting")
+	cmd.Flags().Int64VarP(&o.seed, "seed", "", o.seed, "Seed used to select the failing pods")
+	cmd.Flags().DurationVarP(&o.maxDuration, "max-duration", "", o.maxDuration, "Maximum simulated duration")
+	cmd.Flags().StringVarP(&o.maxUnavailable, "max-unavailable", "", "", "Override the rollingUpdate.maxUnavailable strategy parameter")
+	cmd.Flags().DurationVarP(&o.slowStartIntervalDuration, "slow-start-interval-duration", "", 0, "Override the rollingUpdate.slowStartIntervalDuration strategy parameter")
+	cmd.Flags().StringVarP(&o.slowStartAdditiveIncrease, "slow-start-additive-increase", "", "", "Override the rollingUpdate.slowStartAdditiveIncrease strategy parameter")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *simulateOptions) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	if len(args) > 0 {
+		o.userExtendedDaemonSetName = args[0]
+	}
+
+	// The cluster is only needed to fetch the ExtendedDaemonSet definition.
+	if o.userExtendedDaemonSetName == "" {
+		return nil
+	}
+
+	var err error
+	clientConfig := o.configFlags.ToRawKubeConfigLoader()
+	// Create the Client for Read/Write operations.
+	o.client, err = common.NewClient(clientConfig)
+	if err != nil {
+		return fmt.Errorf("unable to instantiate client, err: %w", err)
+	}
+
+	o.userNamespace, _, err = clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	ns, err2 := cmd.Flags().GetString("namespace")
+	if err2 != nil {
+		return err2
+	}
+	if ns != "" {
+		o.userNamespace = ns
+	}
+
+	return nil
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *simulateOptions) validate() error {
+	if o.userExtendedDaemonSetName == "" && o.filename == "" {
+		return errors.New("the extendeddaemonset name or the --filename flag is required")
+	}
+
+	if o.userExtendedDaemonSetName != "" && o.filename != "" {
+		return errors.New("the extendeddaemonset name and the --filename flag are mutually exclusive")
+	}
+
+	return nil
+}
+
+// run use to run the command.
+func (o *simulateOptions) run() error {
+	eds, err := o.getExtendedDaemonSet()
+	if err != nil {
+		return err
+	}
+
+	strategy := *eds.Spec.Strategy.DeepCopy()
+	if o.maxUnavailable != "" {
+		value := intstr.Parse(o.maxUnavailable)
+		strategy.RollingUpdate.MaxUnavailable = &value
+	}
+	if o.slowStartIntervalDuration != 0 {
+		strategy.RollingUpdate.SlowStartIntervalDuration = &metav1.Duration{Duration: o.slowStartIntervalDuration}
+	}
+	if o.slowStartAdditiveIncrease != "" {
+		value := intstr.Parse(o.slowStartAdditiveIncrease)
+		strategy.RollingUpdate.SlowStartAdditiveIncrease = &value
+	}
+
+	report, err := simulator.Run(simulator.Options{
+		NbNodes:                o.nbNodes,
+		Strategy:               strategy,
+		PodStartupDuration:     o.podStartupDuration,
+		PodTerminationDuration: o.podTerminationDuration,
+		FailureRate:            o.failureRate,
+		Seed:                   o.seed,
+		MaxDuration:            o.maxDuration,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to simulate the rollout, err: %w", err)
+	}
+
+	printTimeline(o.Out, report)
+	fmt.Fprintf(o.Out, "\nOutcome: %s after %s", report.Outcome, report.Duration)
+	if report.CanaryDuration != 0 {
+		fmt.Fprintf(o.Out, " (canary: %s)", report.CanaryDuration)
+	}
+	if report.Reason != "" {
+		fmt.Fprintf(o.Out, ", %s", report.Reason)
+	}
+	fmt.Fprintf(o.Out, "\n")
+
+	return nil
+}
+
+func (o *simulateOptions) getExtendedDaemonSet() (*v1alpha1.ExtendedDaemonSet, error) {
+	eds := &v1alpha1.ExtendedDaemonSet{}
+	if o.filename != "" {
+		data, err := os.ReadFile(o.filename)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read file %s, err: %w", o.filename, err)
+		}
+		if err = jy.Unmarshal(data, eds); err != nil {
+			return nil, fmt.Errorf("unable to decode ExtendedDaemonSet from file %s, err: %w", o.filename, err)
+		}
+
+		return eds, nil
+	}
+
+	err := o.client.Get(context.TODO(), client.ObjectKey{Namespace: o.userNamespace, Name: o.userExtendedDaemonSetName}, eds)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("ExtendedDaemonSet %s/%s not found", o.userNamespace, o.userExtendedDaemonSetName)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
+	}
+
+	return eds, nil
+}
+
+func printTimeline(out io.Writer, report *simulator.Report) {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"Elapsed", "Phase", "Updated", "Updated Ready", "Old", "Unavailable", "Failing", "Created", "Deleted"})
+	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(false)
+
+	for _, entry := range report.Timeline {
+		table.Append([]string{
+			entry.Elapsed.String(),
+			string(entry.Phase),
+			strconv.Itoa(entry.Updated),
+			strconv.Itoa(entry.UpdatedReady),
+			strconv.Itoa(entry.Old),
+			strconv.Itoa(entry.Unavailable),
+			strconv.Itoa(entry.Failing),
+			strconv.Itoa(entry.Created),
+			strconv.Itoa(entry.Deleted),
+		})
+	}
+
+	table.Render()
+}
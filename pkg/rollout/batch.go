@@ -0,0 +1,50 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// ApproveNextBatch approves the next batch of a rolling update with manual batch approval, and returns
+// the number of the batch that was completed before the approval.
+func ApproveNextBatch(ctx context.Context, c client.Client, key client.ObjectKey) (int32, error) {
+	var completedBatch int32
+	_, err := updateExtendedDaemonSet(ctx, c, key, func(eds *v1alpha1.ExtendedDaemonSet) error {
+		if manual := eds.Spec.Strategy.RollingUpdate.ManualBatchApproval; manual == nil || !*manual {
+			return newPreconditionError("the ExtendedDaemonset rolling update does not have the manual batch approval enabled")
+		}
+		if eds.Status.Canary != nil {
+			return newPreconditionError("the ExtendedDaemonset has an active canary deployment. You should either fail or validate the canary first")
+		}
+		batch := eds.Status.RollingUpdateBatch
+		if batch == nil {
+			return newPreconditionError("the ExtendedDaemonset rolling update has not started any batch yet")
+		}
+		if !batch.Completed {
+			return newPreconditionError("the batch %d of the rolling update is not completed yet", batch.Number)
+		}
+
+		completedBatch = batch.Number
+		approved := v1alpha1.RollingUpdateApprovedBatchValue(eds.Status.ActiveReplicaSet, completedBatch)
+		if eds.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey] == approved {
+			return newPreconditionError("the batch following batch %d is already approved", completedBatch)
+		}
+		eds.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey] = approved
+
+		return nil
+	})
+	if err != nil && !IsPreconditionError(err) {
+		return 0, fmt.Errorf("unable to approve the next batch of the rolling update, err: %w", err)
+	}
+
+	return completedBatch, err
+}
@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+)
+
+// canaryManuallyFailedReason is the reason of the Canary-Failed condition set by FailCanary.
+const canaryManuallyFailedReason = "Manually failed"
+
+// ValidateCanary validates the active canary deployment, and returns the name of the validated canary replicaset.
+// The rolling update of the canary replicaset starts after the validation.
+func ValidateCanary(ctx context.Context, c client.Client, key client.ObjectKey) (string, error) {
+	var rsName string
+	_, err := updateExtendedDaemonSet(ctx, c, key, func(eds *v1alpha1.ExtendedDaemonSet) error {
+		if eds.Status.Canary == nil {
+			return newPreconditionError("the ExtendedDaemonset is not currently running a canary replicaset")
+		}
+
+		rsName = eds.Status.Canary.ReplicaSet
+		if name, found := eds.Annotations[v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey]; found && name == rsName {
+			return newPreconditionError("canary replicaset '%s' already validated", name)
+		}
+		eds.Annotations[v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey] = rsName
+
+		return nil
+	})
+	if err != nil && !IsPreconditionError(err) {
+		return "", fmt.Errorf("unable to validate the canary replicaset, err: %w", err)
+	}
+
+	return rsName, err
+}
+
+// FailCanary fails the active canary deployment by setting the Canary-Failed condition on the canary replicaset,
+// and returns the name of the failed canary replicaset.
+func FailCanary(ctx context.Context, c client.Client, key client.ObjectKey) (string, error) {
+	var rsName string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		eds, err := GetExtendedDaemonSet(ctx, c, key)
+		if err != nil {
+			return err
+		}
+		if err = checkActiveCanary(eds); err != nil {
+			return err
+		}
+
+		rsName = eds.Status.Canary.ReplicaSet
+		canaryERS := &v1alpha1.ExtendedDaemonSetReplicaSet{}
+		err = c.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: rsName}, canaryERS)
+		if err != nil && apierrors.IsNotFound(err) {
+			return fmt.Errorf("ERS %s/%s not found: %w", key.Namespace, rsName, err)
+		} else if err != nil {
+			return fmt.Errorf("unable to get ERS, err: %w", err)
+		}
+
+		failedCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&canaryERS.Status, v1alpha1.ConditionTypeCanaryFailed)
+		if failedCondition != nil && failedCondition.Status == corev1.ConditionTrue {
+			return newPreconditionError("canary replicaset '%s' already failed", rsName)
+		}
+
+		newCanaryERS := canaryERS.DeepCopy()
+		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(&newCanaryERS.Status, metav1.Now(), v1alpha1.ConditionTypeCanaryFailed, corev1.ConditionTrue, canaryManuallyFailedReason, "", true, true)
+
+		// The status update relies on the resource version, so a concurrent update of the replicaset returns a conflict.
+		return c.Status().Update(ctx, newCanaryERS)
+	})
+	if err != nil && !IsPreconditionError(err) {
+		return "", fmt.Errorf("unable to fail the canary replicaset, err: %w", err)
+	}
+
+	return rsName, err
+}
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// AddCanaryNode opts the node in the canary deployments: it is always selected as a canary node,
+// even if it doesn't match the canary nodeSelector.
+func AddCanaryNode(ctx context.Context, c client.Client, nodeName string) error {
+	return setCanaryNode(ctx, c, nodeName, v1alpha1.ValueStringTrue)
+}
+
+// ExcludeCanaryNode opts the node out of the canary deployments: it is never selected as a canary node.
+func ExcludeCanaryNode(ctx context.Context, c client.Client, nodeName string) error {
+	return setCanaryNode(ctx, c, nodeName, v1alpha1.ValueStringFalse)
+}
+
+// RemoveCanaryNode removes the canary-node label of the node, added by AddCanaryNode or ExcludeCanaryNode:
+// the node is selected again like any other node.
+func RemoveCanaryNode(ctx context.Context, c client.Client, nodeName string) error {
+	return setCanaryNode(ctx, c, nodeName, "")
+}
+
+// setCanaryNode sets the canary-node label of the node to value, or removes it if value is empty.
+func setCanaryNode(ctx context.Context, c client.Client, nodeName, value string) error {
+	node := &corev1.Node{}
+	err := c.Get(ctx, client.ObjectKey{Name: nodeName}, node)
+	if err != nil && apierrors.IsNotFound(err) {
+		return fmt.Errorf("node %s not found: %w", nodeName, err)
+	} else if err != nil {
+		return fmt.Errorf("unable to get node, err: %w", err)
+	}
+
+	if node.Labels[v1alpha1.ExtendedDaemonSetCanaryNodeLabelKey] == value {
+		switch value {
+		case v1alpha1.ValueStringTrue:
+			return newPreconditionError("node '%s' already added to the canary deployments", nodeName)
+		case v1alpha1.ValueStringFalse:
+			return newPreconditionError("node '%s' already excluded from the canary deployments", nodeName)
+		default:
+			return newPreconditionError("node '%s' is neither added to nor excluded from the canary deployments", nodeName)
+		}
+	}
+
+	newNode := node.DeepCopy()
+	if value == "" {
+		delete(newNode.Labels, v1alpha1.ExtendedDaemonSetCanaryNodeLabelKey)
+	} else {
+		if newNode.Labels == nil {
+			newNode.Labels = make(map[string]string)
+		}
+		newNode.Labels[v1alpha1.ExtendedDaemonSetCanaryNodeLabelKey] = value
+	}
+	if err = c.Patch(ctx, newNode, client.MergeFrom(node)); err != nil {
+		return fmt.Errorf("unable to set the canary-node label of the node %s, err: %w", nodeName, err)
+	}
+
+	return nil
+}
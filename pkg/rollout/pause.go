@@ -0,0 +1,231 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+)
+
+// Pause pauses the current rollout of the ExtendedDaemonSet: the canary deployment if a canary is active,
+// the rolling update otherwise.
+func Pause(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	eds, err := GetExtendedDaemonSet(ctx, c, key)
+	if err != nil {
+		return err
+	}
+	if eds.Status.Canary != nil {
+		return PauseCanary(ctx, c, key)
+	}
+
+	return PauseRollingUpdate(ctx, c, key)
+}
+
+// Resume resumes the current rollout of the ExtendedDaemonSet: the canary deployment if a canary is active,
+// the rolling update otherwise.
+func Resume(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	eds, err := GetExtendedDaemonSet(ctx, c, key)
+	if err != nil {
+		return err
+	}
+	if eds.Status.Canary != nil {
+		return ResumeCanary(ctx, c, key)
+	}
+
+	return ResumeRollingUpdate(ctx, c, key)
+}
+
+// PauseCanary pauses the active canary deployment.
+func PauseCanary(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	return setCanaryPaused(ctx, c, key, true)
+}
+
+// ResumeCanary resumes the active canary deployment.
+func ResumeCanary(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	return setCanaryPaused(ctx, c, key, false)
+}
+
+func setCanaryPaused(ctx context.Context, c client.Client, key client.ObjectKey, pause bool) error {
+	_, err := updateExtendedDaemonSet(ctx, c, key, func(eds *v1alpha1.ExtendedDaemonSet) error {
+		if err := checkActiveCanary(eds); err != nil {
+			return err
+		}
+
+		isPaused, found := eds.Annotations[v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey]
+		if found && pause && isPaused == v1alpha1.ValueStringTrue {
+			return newPreconditionError("canary deployment already paused")
+		} else if found && !pause && isPaused == v1alpha1.ValueStringFalse {
+			return newPreconditionError("canary deployment is not paused; cannot unpause")
+		}
+
+		// The unpaused annotation is set to false in case it was previously true
+		if pause {
+			eds.Annotations[v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey] = v1alpha1.ValueStringTrue
+			eds.Annotations[v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey] = v1alpha1.ValueStringFalse
+		} else {
+			eds.Annotations[v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey] = v1alpha1.ValueStringFalse
+			eds.Annotations[v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey] = v1alpha1.ValueStringTrue
+		}
+
+		return nil
+	})
+	if err != nil && !IsPreconditionError(err) {
+		return fmt.Errorf("unable to set the canary deployment paused to %t, err: %w", pause, err)
+	}
+
+	return err
+}
+
+// PauseRollingUpdate pauses the rolling update: pods are not deleted nor updated anymore, but pods are still
+// created on new nodes.
+func PauseRollingUpdate(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	return setRollingUpdatePaused(ctx, c, key, true)
+}
+
+// ResumeRollingUpdate resumes a paused rolling update, or a rolling update paused by its health gate.
+func ResumeRollingUpdate(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	err := setRollingUpdatePaused(ctx, c, key, false)
+	if !IsPreconditionError(err) {
+		return err
+	}
+
+	resumed, gateErr := resumeHealthGatePause(ctx, c, key)
+	if gateErr != nil || resumed {
+		return gateErr
+	}
+
+	return err
+}
+
+// resumeHealthGatePause resumes the rolling update of the active replicaset paused by its health gate, by setting
+// its RollingUpdatePaused condition to false. It returns false if the rolling update isn't paused by the health gate.
+func resumeHealthGatePause(ctx context.Context, c client.Client, key client.ObjectKey) (bool, error) {
+	var resumed bool
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		eds, err := GetExtendedDaemonSet(ctx, c, key)
+		if err != nil {
+			return err
+		}
+		if eds.Status.Canary != nil || eds.Status.ActiveReplicaSet == "" {
+			return nil
+		}
+
+		activeERS := &v1alpha1.ExtendedDaemonSetReplicaSet{}
+		err = c.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: eds.Status.ActiveReplicaSet}, activeERS)
+		if err != nil && apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("unable to get ERS, err: %w", err)
+		}
+
+		pausedCondition := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&activeERS.Status, v1alpha1.ConditionTypeRollingUpdatePaused)
+		if pausedCondition == nil || pausedCondition.Status != corev1.ConditionTrue || pausedCondition.Reason != string(v1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods) {
+			return nil
+		}
+
+		newActiveERS := activeERS.DeepCopy()
+		conditions.UpdateExtendedDaemonSetReplicaSetStatusCondition(&newActiveERS.Status, metav1.Now(), v1alpha1.ConditionTypeRollingUpdatePaused, corev1.ConditionFalse, "", "", false, false)
+		resumed = true
+
+		// The status update relies on the resource version, so a concurrent update of the replicaset returns a conflict.
+		return c.Status().Update(ctx, newActiveERS)
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to resume the rolling update paused by the health gate, err: %w", err)
+	}
+
+	return resumed, nil
+}
+
+func setRollingUpdatePaused(ctx context.Context, c client.Client, key client.ObjectKey, pause bool) error {
+	_, err := updateExtendedDaemonSet(ctx, c, key, func(eds *v1alpha1.ExtendedDaemonSet) error {
+		if eds.Status.Canary != nil {
+			return newPreconditionError("cannot pause rolling update: the ExtendedDaemonset has an active canary deployment. You can use the canary pause command instead")
+		}
+
+		isPaused, found := eds.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey]
+		if pause && isPaused == v1alpha1.ValueStringTrue {
+			return newPreconditionError("rolling update already paused")
+		}
+		if !pause && (isPaused == v1alpha1.ValueStringFalse || !found) {
+			// the rolling update is already unpaused, or was never paused
+			return newPreconditionError("rolling update is not paused; cannot unpause")
+		}
+
+		eds.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey] = boolToString(pause)
+
+		return nil
+	})
+	if err != nil && !IsPreconditionError(err) {
+		return fmt.Errorf("unable to set the rolling update paused to %t, err: %w", pause, err)
+	}
+
+	return err
+}
+
+// Freeze freezes the rollout: pods are not created anymore, even on new nodes.
+func Freeze(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	return setRolloutFrozen(ctx, c, key, true)
+}
+
+// Unfreeze unfreezes a frozen rollout.
+func Unfreeze(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	return setRolloutFrozen(ctx, c, key, false)
+}
+
+func setRolloutFrozen(ctx context.Context, c client.Client, key client.ObjectKey, freeze bool) error {
+	_, err := updateExtendedDaemonSet(ctx, c, key, func(eds *v1alpha1.ExtendedDaemonSet) error {
+		if eds.Status.Canary != nil {
+			return newPreconditionError("cannot freeze rollout: the ExtendedDaemonset has an active canary deployment. You should either fail or validate the canary first")
+		}
+
+		isFrozen, found := eds.Annotations[v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey]
+		if freeze && isFrozen == v1alpha1.ValueStringTrue {
+			return newPreconditionError("rollout already frozen")
+		}
+		if !freeze && (isFrozen == v1alpha1.ValueStringFalse || !found) {
+			// the rollout is already unfrozen, or was never frozen
+			return newPreconditionError("rollout is not frozen; cannot unfreeze")
+		}
+
+		eds.Annotations[v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey] = boolToString(freeze)
+
+		return nil
+	})
+	if err != nil && !IsPreconditionError(err) {
+		return fmt.Errorf("unable to set the rollout frozen to %t, err: %w", freeze, err)
+	}
+
+	return err
+}
+
+func checkActiveCanary(eds *v1alpha1.ExtendedDaemonSet) error {
+	if eds.Spec.Strategy.Canary == nil {
+		return newPreconditionError("the ExtendedDaemonset does not have a canary strategy")
+	}
+	if eds.Status.Canary == nil {
+		return newPreconditionError("the ExtendedDaemonset does not have an active canary deployment")
+	}
+
+	return nil
+}
+
+func boolToString(value bool) string {
+	if value {
+		return v1alpha1.ValueStringTrue
+	}
+
+	return v1alpha1.ValueStringFalse
+}
@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+// Package rollout contains the ExtendedDaemonSet rollout operations: pause, resume, freeze,
+// canary validation and failure, canary nodes opt-in and opt-out, rolling update batch approval, and waiting for the end of a rollout.
+// They are used by the kubectl plugin and check-eds, and can be used by any other tooling.
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// PreconditionError is returned when the ExtendedDaemonSet state doesn't allow the requested operation,
+// for instance when pausing an already paused rolling update.
+type PreconditionError struct {
+	msg string
+}
+
+func (e *PreconditionError) Error() string {
+	return e.msg
+}
+
+func newPreconditionError(format string, a ...any) error {
+	return &PreconditionError{msg: fmt.Sprintf(format, a...)}
+}
+
+// IsPreconditionError returns true if the error, or one of the errors it wraps, is a PreconditionError.
+func IsPreconditionError(err error) bool {
+	var preconditionErr *PreconditionError
+
+	return errors.As(err, &preconditionErr)
+}
+
+// GetExtendedDaemonSet returns the ExtendedDaemonSet, with an explicit error if it doesn't exist.
+func GetExtendedDaemonSet(ctx context.Context, c client.Client, key client.ObjectKey) (*v1alpha1.ExtendedDaemonSet, error) {
+	eds := &v1alpha1.ExtendedDaemonSet{}
+	err := c.Get(ctx, key, eds)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("ExtendedDaemonSet %s not found: %w", key, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get ExtendedDaemonSet, err: %w", err)
+	}
+
+	return eds, nil
+}
+
+// updateExtendedDaemonSet gets the ExtendedDaemonSet and calls mutate to check the preconditions and update it,
+// then patches it with an optimistic lock. On conflicts, the whole sequence is retried with the latest version,
+// so the preconditions are always checked against the state being patched.
+func updateExtendedDaemonSet(ctx context.Context, c client.Client, key client.ObjectKey, mutate func(eds *v1alpha1.ExtendedDaemonSet) error) (*v1alpha1.ExtendedDaemonSet, error) {
+	var newEds *v1alpha1.ExtendedDaemonSet
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		eds, err := GetExtendedDaemonSet(ctx, c, key)
+		if err != nil {
+			return err
+		}
+
+		newEds = eds.DeepCopy()
+		if newEds.Annotations == nil {
+			newEds.Annotations = make(map[string]string)
+		}
+		if err = mutate(newEds); err != nil {
+			return err
+		}
+
+		return c.Patch(ctx, newEds, client.MergeFromWithOptions(eds, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newEds, nil
+}
@@ -0,0 +1,421 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1/test"
+	"github.com/DataDog/extendeddaemonset/controllers/extendeddaemonsetreplicaset/conditions"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	require.NoError(t, corev1.AddToScheme(s))
+
+	return s
+}
+
+func newClient(t *testing.T, objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(newScheme(t)).WithStatusSubresource(&v1alpha1.ExtendedDaemonSetReplicaSet{}).WithObjects(objs...).Build()
+}
+
+func newEDS(annotations map[string]string, canary bool) *v1alpha1.ExtendedDaemonSet {
+	options := &test.NewExtendedDaemonSetOptions{
+		Annotations: annotations,
+		Status:      &v1alpha1.ExtendedDaemonSetStatus{ActiveReplicaSet: "foo-1"},
+	}
+	if canary {
+		options.Canary = &v1alpha1.ExtendedDaemonSetSpecStrategyCanary{}
+		options.Status.Canary = &v1alpha1.ExtendedDaemonSetStatusCanary{ReplicaSet: "foo-2"}
+	}
+
+	return test.NewExtendedDaemonSet("bar", "foo", options)
+}
+
+var key = client.ObjectKey{Namespace: "bar", Name: "foo"}
+
+func TestAnnotationOperations(t *testing.T) {
+	tests := []struct {
+		name             string
+		eds              *v1alpha1.ExtendedDaemonSet
+		operation        func(ctx context.Context, c client.Client, key client.ObjectKey) error
+		wantPrecondition bool
+		wantAnnotations  map[string]string
+	}{
+		{
+			name:            "pause rolling update",
+			eds:             newEDS(nil, false),
+			operation:       Pause,
+			wantAnnotations: map[string]string{v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: "true"},
+		},
+		{
+			name:             "pause already paused rolling update",
+			eds:              newEDS(map[string]string{v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey: "true"}, false),
+			operation:        PauseRollingUpdate,
+			wantPrecondition: true,
+		},
+		{
+			name:             "resume never paused rolling update",
+			eds:              newEDS(nil, false),
+			operation:        ResumeRollingUpdate,
+			wantPrecondition: true,
+		},
+		{
+			name:             "pause rolling update with an active canary",
+			eds:              newEDS(nil, true),
+			operation:        PauseRollingUpdate,
+			wantPrecondition: true,
+		},
+		{
+			name:      "pause canary",
+			eds:       newEDS(nil, true),
+			operation: Pause,
+			wantAnnotations: map[string]string{
+				v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey:   "true",
+				v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey: "false",
+			},
+		},
+		{
+			name:      "resume canary",
+			eds:       newEDS(map[string]string{v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey: "true"}, true),
+			operation: Resume,
+			wantAnnotations: map[string]string{
+				v1alpha1.ExtendedDaemonSetCanaryPausedAnnotationKey:   "false",
+				v1alpha1.ExtendedDaemonSetCanaryUnpausedAnnotationKey: "true",
+			},
+		},
+		{
+			name:             "pause canary without canary",
+			eds:              newEDS(nil, false),
+			operation:        PauseCanary,
+			wantPrecondition: true,
+		},
+		{
+			name:            "freeze",
+			eds:             newEDS(nil, false),
+			operation:       Freeze,
+			wantAnnotations: map[string]string{v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey: "true"},
+		},
+		{
+			name:             "freeze with an active canary",
+			eds:              newEDS(nil, true),
+			operation:        Freeze,
+			wantPrecondition: true,
+		},
+		{
+			name:            "unfreeze",
+			eds:             newEDS(map[string]string{v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey: "true"}, false),
+			operation:       Unfreeze,
+			wantAnnotations: map[string]string{v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey: "false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, tt.eds)
+			err := tt.operation(t.Context(), c, key)
+			if tt.wantPrecondition {
+				require.Error(t, err)
+				assert.True(t, IsPreconditionError(err), "unexpected error: %v", err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := GetExtendedDaemonSet(t.Context(), c, key)
+			require.NoError(t, err)
+			for k, v := range tt.wantAnnotations {
+				assert.Equal(t, v, got.Annotations[k], "annotation %s", k)
+			}
+		})
+	}
+}
+
+func TestPauseRetriesOnConflict(t *testing.T) {
+	eds := newEDS(map[string]string{"foo": "bar"}, false)
+	c := newClient(t, eds)
+
+	// A concurrent writer freezes the rollout between the first read and the first patch.
+	nbPatch := 0
+	c = interceptor.NewClient(c.(client.WithWatch), interceptor.Funcs{
+		Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			nbPatch++
+			if nbPatch == 1 {
+				other := &v1alpha1.ExtendedDaemonSet{}
+				require.NoError(t, cl.Get(ctx, key, other))
+				other.Annotations[v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey] = v1alpha1.ValueStringTrue
+				require.NoError(t, cl.Update(ctx, other))
+			}
+
+			return cl.Patch(ctx, obj, patch, opts...)
+		},
+	})
+
+	require.NoError(t, PauseRollingUpdate(t.Context(), c, key))
+	assert.Equal(t, 2, nbPatch)
+
+	got, err := GetExtendedDaemonSet(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ValueStringTrue, got.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdatePausedAnnotationKey])
+	assert.Equal(t, v1alpha1.ValueStringTrue, got.Annotations[v1alpha1.ExtendedDaemonSetRolloutFrozenAnnotationKey])
+}
+
+func TestPauseNotFound(t *testing.T) {
+	err := Pause(t.Context(), newClient(t), key)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.False(t, IsPreconditionError(err))
+}
+
+func TestValidateCanary(t *testing.T) {
+	c := newClient(t, newEDS(nil, true))
+
+	rsName, err := ValidateCanary(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, "foo-2", rsName)
+
+	got, err := GetExtendedDaemonSet(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, "foo-2", got.Annotations[v1alpha1.ExtendedDaemonSetCanaryValidAnnotationKey])
+
+	_, err = ValidateCanary(t.Context(), c, key)
+	assert.True(t, IsPreconditionError(err), "unexpected error: %v", err)
+}
+
+func TestApproveNextBatch(t *testing.T) {
+	manual := true
+	eds := newEDS(nil, false)
+	eds.Spec.Strategy.RollingUpdate.ManualBatchApproval = &manual
+	eds.Status.RollingUpdateBatch = &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 2, Nodes: []string{"node-a"}}
+	c := newClient(t, eds)
+
+	_, err := ApproveNextBatch(t.Context(), c, key)
+	assert.True(t, IsPreconditionError(err), "batch not completed, unexpected error: %v", err)
+
+	got, err := GetExtendedDaemonSet(t.Context(), c, key)
+	require.NoError(t, err)
+	got.Status.RollingUpdateBatch.Completed = true
+	require.NoError(t, c.Update(t.Context(), got))
+
+	batch, err := ApproveNextBatch(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), batch)
+
+	got, err = GetExtendedDaemonSet(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, "foo-1/2", got.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey])
+
+	_, err = ApproveNextBatch(t.Context(), c, key)
+	assert.True(t, IsPreconditionError(err), "batch already approved, unexpected error: %v", err)
+
+	_, err = ApproveNextBatch(t.Context(), newClient(t, newEDS(nil, false)), key)
+	assert.True(t, IsPreconditionError(err), "manual batch approval disabled, unexpected error: %v", err)
+}
+
+func TestApproveNextBatch_SuccessiveRollouts(t *testing.T) {
+	manual := true
+	eds := newEDS(nil, false)
+	eds.Spec.Strategy.RollingUpdate.ManualBatchApproval = &manual
+	eds.Status.RollingUpdateBatch = &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"node-a"}, Completed: true}
+	c := newClient(t, eds)
+
+	batch, err := ApproveNextBatch(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), batch)
+
+	// The next rollout starts its batches over with a new replicaset.
+	got, err := GetExtendedDaemonSet(t.Context(), c, key)
+	require.NoError(t, err)
+	got.Status.ActiveReplicaSet = "foo-2"
+	got.Status.RollingUpdateBatch = &v1alpha1.ExtendedDaemonSetStatusRollingUpdateBatch{Number: 1, Nodes: []string{"node-b"}, Completed: true}
+	require.NoError(t, c.Update(t.Context(), got))
+	assert.NotEqual(t, v1alpha1.RollingUpdateApprovedBatchValue("foo-2", 1), got.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey], "the approval of the previous rollout must not approve the new one")
+
+	batch, err = ApproveNextBatch(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), batch)
+
+	got, err = GetExtendedDaemonSet(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, "foo-2/1", got.Annotations[v1alpha1.ExtendedDaemonSetRollingUpdateApprovedBatchAnnotationKey])
+}
+
+func TestResumeRollingUpdate_HealthGate(t *testing.T) {
+	activeERS := test.NewExtendedDaemonSetReplicaSet("bar", "foo-1", &test.NewExtendedDaemonSetReplicaSetOptions{
+		Status: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
+			Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
+				{
+					Type:   v1alpha1.ConditionTypeRollingUpdatePaused,
+					Status: corev1.ConditionTrue,
+					Reason: string(v1alpha1.ExtendedDaemonSetStatusReasonUnhealthyPods),
+				},
+			},
+		},
+	})
+	c := newClient(t, newEDS(nil, false), activeERS)
+
+	require.NoError(t, ResumeRollingUpdate(t.Context(), c, key))
+
+	got := &v1alpha1.ExtendedDaemonSetReplicaSet{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "bar", Name: "foo-1"}, got))
+	cond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&got.Status, v1alpha1.ConditionTypeRollingUpdatePaused)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+
+	// the rolling update is not paused anymore
+	err := ResumeRollingUpdate(t.Context(), c, key)
+	assert.True(t, IsPreconditionError(err), "unexpected error: %v", err)
+}
+
+func TestFailCanary(t *testing.T) {
+	canaryERS := test.NewExtendedDaemonSetReplicaSet("bar", "foo-2", &test.NewExtendedDaemonSetReplicaSetOptions{
+		Status: &v1alpha1.ExtendedDaemonSetReplicaSetStatus{
+			Conditions: []v1alpha1.ExtendedDaemonSetReplicaSetCondition{
+				{Type: v1alpha1.ConditionTypeCanaryFailed, Status: corev1.ConditionFalse},
+			},
+		},
+	})
+	c := newClient(t, newEDS(nil, true), canaryERS)
+
+	rsName, err := FailCanary(t.Context(), c, key)
+	require.NoError(t, err)
+	assert.Equal(t, "foo-2", rsName)
+
+	got := &v1alpha1.ExtendedDaemonSetReplicaSet{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "bar", Name: "foo-2"}, got))
+	require.Len(t, got.Status.Conditions, 1)
+	cond := conditions.GetExtendedDaemonSetReplicaSetStatusCondition(&got.Status, v1alpha1.ConditionTypeCanaryFailed)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Equal(t, canaryManuallyFailedReason, cond.Reason)
+
+	_, err = FailCanary(t.Context(), c, key)
+	assert.True(t, IsPreconditionError(err), "unexpected error: %v", err)
+}
+
+func TestCanaryNodes(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	c := newClient(t, node)
+
+	require.NoError(t, AddCanaryNode(t.Context(), c, "node-a"))
+	got := &corev1.Node{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Name: "node-a"}, got))
+	assert.Equal(t, v1alpha1.ValueStringTrue, got.Labels[v1alpha1.ExtendedDaemonSetCanaryNodeLabelKey])
+
+	err := AddCanaryNode(t.Context(), c, "node-a")
+	assert.True(t, IsPreconditionError(err), "unexpected error: %v", err)
+
+	require.NoError(t, ExcludeCanaryNode(t.Context(), c, "node-a"))
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Name: "node-a"}, got))
+	assert.Equal(t, v1alpha1.ValueStringFalse, got.Labels[v1alpha1.ExtendedDaemonSetCanaryNodeLabelKey])
+
+	err = ExcludeCanaryNode(t.Context(), c, "node-a")
+	assert.True(t, IsPreconditionError(err), "unexpected error: %v", err)
+
+	require.NoError(t, RemoveCanaryNode(t.Context(), c, "node-a"))
+	got = &corev1.Node{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Name: "node-a"}, got))
+	assert.NotContains(t, got.Labels, v1alpha1.ExtendedDaemonSetCanaryNodeLabelKey)
+
+	err = RemoveCanaryNode(t.Context(), c, "node-a")
+	assert.True(t, IsPreconditionError(err), "unexpected error: %v", err)
+
+	// removing the label restores the default selection of a node added to the canary deployments
+	require.NoError(t, AddCanaryNode(t.Context(), c, "node-a"))
+	require.NoError(t, RemoveCanaryNode(t.Context(), c, "node-a"))
+	got = &corev1.Node{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Name: "node-a"}, got))
+	assert.NotContains(t, got.Labels, v1alpha1.ExtendedDaemonSetCanaryNodeLabelKey)
+
+	err = AddCanaryNode(t.Context(), c, "node-b")
+	assert.True(t, apierrors.IsNotFound(err), "unexpected error: %v", err)
+}
+
+func TestWaitForRollout(t *testing.T) {
+	now := time.Now()
+	opts := WaitOptions{
+		Period:            time.Millisecond,
+		Timeout:           100 * time.Millisecond,
+		NodeCompletionPct: 0.95,
+		NodeCompletionMin: 1,
+	}
+
+	tests := []struct {
+		name    string
+		status  v1alpha1.ExtendedDaemonSetStatus
+		ers     *v1alpha1.ExtendedDaemonSetReplicaSet
+		wantErr bool
+	}{
+		{
+			name: "rollout finished",
+			status: v1alpha1.ExtendedDaemonSetStatus{
+				ActiveReplicaSet: "foo-1",
+				State:            v1alpha1.ExtendedDaemonSetStatusStateRunning,
+				Current:          100,
+				UpToDate:         100,
+			},
+		},
+		{
+			name: "rollout not finished before the timeout",
+			status: v1alpha1.ExtendedDaemonSetStatus{
+				ActiveReplicaSet: "foo-1",
+				State:            v1alpha1.ExtendedDaemonSetStatusStateRunning,
+				Current:          100,
+				UpToDate:         50,
+			},
+			wantErr: true,
+		},
+		{
+			name: "canary failed",
+			status: v1alpha1.ExtendedDaemonSetStatus{
+				ActiveReplicaSet: "foo-1",
+				State:            v1alpha1.ExtendedDaemonSetStatusStateRunning,
+				Current:          100,
+				UpToDate:         100,
+				Conditions: []v1alpha1.ExtendedDaemonSetCondition{
+					{Type: v1alpha1.ConditionTypeEDSCanaryFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now)},
+				},
+			},
+			ers:     test.NewExtendedDaemonSetReplicaSet("bar", "foo-1", &test.NewExtendedDaemonSetReplicaSetOptions{CreationTime: ptrTime(now.Add(-time.Hour))}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eds := newEDS(nil, false)
+			eds.Status = tt.status
+			objs := []client.Object{eds}
+			if tt.ers != nil {
+				objs = append(objs, tt.ers)
+			}
+
+			err := WaitForRollout(t.Context(), newClient(t, objs...), key, opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-2019 Datadog, Inc.
+
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/extendeddaemonset/api/v1alpha1"
+)
+
+// WaitOptions configures WaitForRollout.
+type WaitOptions struct {
+	// Period between two checks of the ExtendedDaemonSet status.
+	Period time.Duration
+	// Timeout of the wait.
+	Timeout time.Duration
+	// NodeCompletionPct is the ratio, between 0 and 1, of up-to-date pods needed to consider the rollout finished.
+	NodeCompletionPct float64
+	// NodeCompletionMin is the number of not up-to-date pods below which the rollout is considered finished.
+	NodeCompletionMin int32
+	// Logf, if set, is called to report the progress of the rollout.
+	Logf func(format string, a ...any)
+}
+
+// DefaultWaitOptions returns the WaitOptions used by check-eds.
+func DefaultWaitOptions() WaitOptions {
+	return WaitOptions{
+		Period:            30 * time.Second,
+		Timeout:           2 * time.Hour,
+		NodeCompletionPct: 0.95,
+		NodeCompletionMin: 10,
+	}
+}
+
+// WaitForRollout waits until the rollout of the ExtendedDaemonSet is finished. It returns an error if the canary
+// failed, if the ExtendedDaemonSet is not running after the canary, or if the timeout is reached.
+func WaitForRollout(ctx context.Context, c client.Client, key client.ObjectKey, opts WaitOptions) error {
+	logf := opts.Logf
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+
+	checkRolloutDone := func(ctx context.Context) (bool, error) {
+		eds, err := GetExtendedDaemonSet(ctx, c, key)
+		if err != nil {
+			return false, err
+		}
+
+		return isRolloutDone(ctx, c, eds, opts, logf)
+	}
+
+	return wait.PollUntilContextTimeout(ctx, opts.Period, opts.Timeout, false, checkRolloutDone)
+}
+
+func isRolloutDone(ctx context.Context, c client.Client, eds *v1alpha1.ExtendedDaemonSet, opts WaitOptions, logf func(format string, a ...any)) (bool, error) {
+	if eds.Status.Canary != nil {
+		logf("canary running")
+
+		return false, nil
+	}
+
+	// We need to look at the activeReplicaSet of the current ExtendedDaemonSet object, and look at the creationTimestamp of
+	// that replicaset. If the creationTimestamp is older than the last occurrence of the "CanaryFailed" condition of the ExtendedDaemonSet,
+	// it is safe to assume that the canary failed and we should fail this check.
+	var canaryFailedCondition *v1alpha1.ExtendedDaemonSetCondition
+	for id := range eds.Status.Conditions {
+		if eds.Status.Conditions[id].Type == v1alpha1.ConditionTypeEDSCanaryFailed {
+			canaryFailedCondition = &eds.Status.Conditions[id]
+
+			break
+		}
+	}
+
+	if canaryFailedCondition != nil {
+		ers := &v1alpha1.ExtendedDaemonSetReplicaSet{}
+		err := c.Get(ctx, client.ObjectKey{Namespace: eds.Namespace, Name: eds.Status.ActiveReplicaSet}, ers)
+		if err == nil {
+			if ers.CreationTimestamp.Before(&canaryFailedCondition.LastTransitionTime) {
+				return false, errors.New("active canary has a creation timestamp before the last CanaryFailed condition, meaning the deployment failed")
+			}
+		} else {
+			logf("error getting replicaset %s: %s", eds.Status.ActiveReplicaSet, err.Error())
+		}
+	}
+
+	// Make sure the eds is in a Running state to avoid a case where the eds is in a Canary state and the number of canary pods
+	// is high enough to pass the threshold.
+	if eds.Status.State != v1alpha1.ExtendedDaemonSetStatusStateRunning {
+		return false, fmt.Errorf("eds is not in a Running state, it is in a %s state", eds.Status.State)
+	}
+
+	if float64(eds.Status.UpToDate) > float64(eds.Status.Current)*opts.NodeCompletionPct ||
+		eds.Status.Current-eds.Status.UpToDate < opts.NodeCompletionMin {
+		logf("upgrade is now finished (reached threshold): %d, nb updated pods: %d, threshold pct: %f, min threshold: %d", eds.Status.Current, eds.Status.UpToDate, opts.NodeCompletionPct, opts.NodeCompletionMin)
+
+		return true, nil
+	}
+
+	logf("still upgrading nb pods: %d, nb updated pods: %d", eds.Status.Current, eds.Status.UpToDate)
+
+	return false, nil
+}